@@ -19,6 +19,45 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "API keys with request counters",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ApiKeyUsage"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/city-lite/{addr}": {
             "get": {
                 "produces": [
@@ -35,6 +74,12 @@ const docTemplate = `{
                         "name": "addr",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, en by default. The GeoNames names are used if the database lacks the language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -59,6 +104,55 @@ const docTemplate = `{
                 }
             }
         },
+        "/city-lite/{addr}/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "city lite for all addresses the hostname is resolved to",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ip or hostname",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, en by default. The GeoNames names are used if the database lacks the language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "resolved IP -\u003e city lite",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/entity.CityLite"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/city/{addr}": {
             "get": {
                 "produces": [
@@ -81,6 +175,19 @@ const docTemplate = `{
                         "description": "include ISP info",
                         "name": "isp",
                         "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "geonames"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "add the GeoNames entities of the city GeoNameIDs",
+                        "name": "enrich",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -105,6 +212,68 @@ const docTemplate = `{
                 }
             }
         },
+        "/city/{addr}/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "city for all addresses the hostname is resolved to",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ip or hostname",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include ISP info",
+                        "name": "isp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "geonames"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "add the GeoNames entities of the city GeoNameIDs",
+                        "name": "enrich",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "resolved IP -\u003e city",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/entity.City"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/country/{addr}": {
             "get": {
                 "produces": [
@@ -145,6 +314,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/country/{addr}/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "country for all addresses the hostname is resolved to",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ip or hostname",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "resolved IP -\u003e country",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/entity.Country"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/dump": {
             "get": {
                 "security": [
@@ -398,10 +610,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "name prefix",
+                        "description": "name or its prefix, case and accent insensitive, alternate names are matched too",
                         "name": "name-prefix",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "prefix",
+                            "exact",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "description": "name match: prefix (default), exact, fuzzy (prefix or a few typos)",
+                        "name": "match",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -410,6 +633,92 @@ const docTemplate = `{
                         "description": "comma separated list of GeoNames ids",
                         "name": "geoname-ids",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of continent codes",
+                        "name": "continent-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of subdivision codes: \u003ccountry code\u003e.\u003cadmin1 code\u003e",
+                        "name": "subdivision-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+                        "name": "feature-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of time zones, e.g. Europe/Berlin",
+                        "name": "time-zones",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min population",
+                        "name": "min-population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "description": "bounding box: west,south,east,north; west \u003e east crosses the antimeridian",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of entities on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "population",
+                            "geonameid"
+                        ],
+                        "type": "string",
+                        "description": "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Next-Cursor of the previous page, the offset is ignored if it's set",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -420,6 +729,16 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/entity.GeoName"
                             }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "cursor of the next page, missing on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "number of entities on all the pages"
+                            }
                         }
                     },
                     "400": {
@@ -452,6 +771,14 @@ const docTemplate = `{
                     "geonames"
                 ],
                 "summary": "continent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -486,18 +813,115 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "name prefix",
+                        "description": "name or its prefix, case and accent insensitive, alternate names are matched too",
                         "name": "name-prefix",
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
+                        "enum": [
+                            "prefix",
+                            "exact",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "description": "name match: prefix (default), exact, fuzzy (prefix or a few typos)",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
                         "description": "comma separated list of GeoNames ids",
                         "name": "geoname-ids",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of continent codes",
+                        "name": "continent-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of subdivision codes: \u003ccountry code\u003e.\u003cadmin1 code\u003e",
+                        "name": "subdivision-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+                        "name": "feature-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of time zones, e.g. Europe/Berlin",
+                        "name": "time-zones",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min population",
+                        "name": "min-population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "description": "bounding box: west,south,east,north; west \u003e east crosses the antimeridian",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of entities on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "population",
+                            "geonameid"
+                        ],
+                        "type": "string",
+                        "description": "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Next-Cursor of the previous page, the offset is ignored if it's set",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -508,6 +932,16 @@ const docTemplate = `{
                             "items": {
                                 "$ref": "#/definitions/entity.GeoNameCountry"
                             }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "cursor of the next page, missing on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "number of entities on all the pages"
+                            }
                         }
                     },
                     "400": {
@@ -576,15 +1010,90 @@ const docTemplate = `{
                 }
             }
         },
-        "/geoname/subdivision": {
+        "/geoname/nearest": {
             "get": {
+                "description": "Reverse geocoding: the cities closest to the location, nearest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "geonames"
                 ],
-                "summary": "city lite",
+                "summary": "nearest city",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "longitude",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of cities, 1 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "max distance in km",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoNameNearestCity"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/geoname/postal": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geonames"
+                ],
+                "summary": "postal code",
                 "parameters": [
                     {
                         "type": "array",
@@ -597,17 +1106,105 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "name prefix",
+                        "description": "postal code or place name or its prefix, case and accent insensitive",
                         "name": "name-prefix",
                         "in": "query"
                     },
+                    {
+                        "enum": [
+                            "prefix",
+                            "exact",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "description": "name match: prefix (default), exact, fuzzy (prefix or a few typos)",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the continent and country names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
-                            "type": "integer"
+                            "type": "string"
                         },
-                        "description": "comma separated list of GeoNames ids",
-                        "name": "geoname-ids",
+                        "description": "comma separated list of continent codes",
+                        "name": "continent-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of subdivision codes: \u003ccountry code\u003e.\u003cadmin1 code\u003e",
+                        "name": "subdivision-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+                        "name": "feature-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of time zones, e.g. Europe/Berlin",
+                        "name": "time-zones",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min population",
+                        "name": "min-population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "description": "bounding box: west,south,east,north; west \u003e east crosses the antimeridian",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of entities on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "population",
+                            "geonameid"
+                        ],
+                        "type": "string",
+                        "description": "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Next-Cursor of the previous page, the offset is ignored if it's set",
+                        "name": "cursor",
                         "in": "query"
                     }
                 ],
@@ -617,7 +1214,17 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entity.GeoNameAdminSubdivision"
+                                "$ref": "#/definitions/entity.GeoNamePostalCode"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "cursor of the next page, missing on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "number of entities on all the pages"
                             }
                         }
                     },
@@ -642,32 +1249,1187 @@ const docTemplate = `{
                 }
             }
         },
-        "/hosting/{addr}": {
+        "/geoname/subdivision": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "geo IP"
+                    "geonames"
                 ],
-                "summary": "hosting",
+                "summary": "city lite",
                 "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of country codes",
+                        "name": "country-codes",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "ip or hostname",
-                        "name": "addr",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "name or its prefix, case and accent insensitive, alternate names are matched too",
+                        "name": "name-prefix",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "prefix",
+                            "exact",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "description": "name match: prefix (default), exact, fuzzy (prefix or a few typos)",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "description": "comma separated list of GeoNames ids",
+                        "name": "geoname-ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of continent codes",
+                        "name": "continent-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of subdivision codes: \u003ccountry code\u003e.\u003cadmin1 code\u003e",
+                        "name": "subdivision-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+                        "name": "feature-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of time zones, e.g. Europe/Berlin",
+                        "name": "time-zones",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min population",
+                        "name": "min-population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "description": "bounding box: west,south,east,north; west \u003e east crosses the antimeridian",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of entities on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "population",
+                            "geonameid"
+                        ],
+                        "type": "string",
+                        "description": "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Next-Cursor of the previous page, the offset is ignored if it's set",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoNameAdminSubdivision"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "cursor of the next page, missing on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "number of entities on all the pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/geoname/subdivision2": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geonames"
+                ],
+                "summary": "second-level subdivision (county, district)",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of country codes",
+                        "name": "country-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name or its prefix, case and accent insensitive, alternate names are matched too",
+                        "name": "name-prefix",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "prefix",
+                            "exact",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "description": "name match: prefix (default), exact, fuzzy (prefix or a few typos)",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "description": "comma separated list of GeoNames ids",
+                        "name": "geoname-ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of continent codes",
+                        "name": "continent-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of subdivision codes: \u003ccountry code\u003e.\u003cadmin1 code\u003e",
+                        "name": "subdivision-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+                        "name": "feature-codes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "description": "comma separated list of time zones, e.g. Europe/Berlin",
+                        "name": "time-zones",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "min population",
+                        "name": "min-population",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        },
+                        "description": "bounding box: west,south,east,north; west \u003e east crosses the antimeridian",
+                        "name": "bbox",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of entities on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of entities to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "population",
+                            "geonameid"
+                        ],
+                        "type": "string",
+                        "description": "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "X-Next-Cursor of the previous page, the offset is ignored if it's set",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoNameAdminSubdivision2"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "cursor of the next page, missing on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "number of entities on all the pages"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/geoname/{id}": {
+            "get": {
+                "description": "The continent, country, subdivision, subdivision2 or city with the id, the type is detected if it isn't set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geonames"
+                ],
+                "summary": "geoname by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "GeoNames id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "continent",
+                            "country",
+                            "subdivision",
+                            "subdivision2",
+                            "city"
+                        ],
+                        "type": "string",
+                        "description": "type of the entity, detected if empty",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoNameItem"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/geoname/{id}/ancestors": {
+            "get": {
+                "description": "The entities up the hierarchy, the parent first: e.g. subdivision2, subdivision, country and continent of a city",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geonames"
+                ],
+                "summary": "geoname ancestors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "GeoNames id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "continent",
+                            "country",
+                            "subdivision",
+                            "subdivision2",
+                            "city"
+                        ],
+                        "type": "string",
+                        "description": "type of the entity, detected if empty",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoNameItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/geoname/{id}/children": {
+            "get": {
+                "description": "The entities one level down the hierarchy: the countries of a continent, the subdivisions of a country,\nthe subdivisions2 and the cities of a subdivision, the cities of a subdivision2",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geonames"
+                ],
+                "summary": "geoname children",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "GeoNames id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "continent",
+                            "country",
+                            "subdivision",
+                            "subdivision2",
+                            "city"
+                        ],
+                        "type": "string",
+                        "description": "type of the entity, detected if empty",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of the names, the names aren't translated if empty",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of children",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoNameItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/hosting/{addr}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "hosting",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ip or hostname",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Hosting"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/hosting/{addr}/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "hosting for all addresses the hostname is resolved to",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ip or hostname",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "resolved IP -\u003e hosting",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/entity.Hosting"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "geo IP"
+                ],
+                "summary": "client address, that is used as \"me\". For debugging the trusted proxies configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ClientAddress"
+                        }
+                    }
+                }
+            }
+        },
+        "/overrides/{db}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The network records set at runtime, they are on top of the db and its patches. The expired ones are listed until the next update.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "GeoIP overrides",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.GeoIPOverride"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The record is City, ISP or Hosting, depending on the db. The override is applied immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "add GeoIP override",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "override, the ID and the timestamps are ignored",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoIPOverride"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoIPOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/overrides/{db}/analysis": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The networks of the patches and the runtime overrides that overlap each other, the record of the winner is returned for the whole network.\nThe networks that don't change the records of the upstream db are reported as no-ops.\nThe patches are from the bottom layer to the top one, the order of the archive is set by its manifest.json or by the file names.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "GeoIP patch analysis",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max number of the reported networks, 1000 by default, 0 for all",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PatchAnalysis"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/overrides/{db}/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The patch records with valid_until and the runtime overrides with expiresAt that expire within the period, the earliest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "expiring GeoIP patch records and overrides",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "period, e.g. 72h, 168h by default",
+                        "name": "within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.ExpiringGeoIPRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/overrides/{db}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "GeoIP override",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoIPOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "replace GeoIP override",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "override, the ID and the timestamps are ignored",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoIPOverride"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.GeoIPOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "delete GeoIP override",
+                "parameters": [
+                    {
+                        "enum": [
+                            "city",
+                            "isp",
+                            "hosting"
+                        ],
+                        "type": "string",
+                        "description": "db type",
+                        "name": "db",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "error",
                         "schema": {
-                            "$ref": "#/definitions/entity.Hosting"
+                            "type": "string"
                         }
                     },
-                    "400": {
+                    "401": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
                         "description": "error",
                         "schema": {
                             "type": "string"
@@ -736,6 +2498,41 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.ApiKeyUsage": {
+            "type": "object",
+            "properties": {
+                "denied": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rateLimits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entity.RateLimit"
+                    }
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "entity.City": {
             "type": "object",
             "properties": {
@@ -793,6 +2590,10 @@ const docTemplate = `{
                         }
                     }
                 },
+                "geoNames": {
+                    "description": "with enrich=geonames only",
+                    "$ref": "#/definitions/entity.CityGeoNames"
+                },
                 "location": {
                     "type": "object",
                     "properties": {
@@ -897,6 +2698,27 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.CityGeoNames": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "$ref": "#/definitions/entity.GeoName"
+                },
+                "continent": {
+                    "$ref": "#/definitions/entity.GeoNameContinent"
+                },
+                "country": {
+                    "$ref": "#/definitions/entity.GeoNameCountry"
+                },
+                "subdivisions": {
+                    "description": "subdivision or subdivision2, in the order of the city subdivisions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.GeoNameItem"
+                    }
+                }
+            }
+        },
         "entity.CityLite": {
             "type": "object",
             "properties": {
@@ -924,6 +2746,24 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.ClientAddress": {
+            "type": "object",
+            "properties": {
+                "hops": {
+                    "description": "forwarding chain from the client side to the peer, as reported by the proxies",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "peer": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.Country": {
             "type": "object",
             "properties": {
@@ -1020,6 +2860,65 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.ExpiringGeoIPRecord": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "the author of the runtime override",
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "the comment of the patch record or the reason of the override",
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "overrideID": {
+                    "description": "the runtime override",
+                    "type": "string"
+                },
+                "patch": {
+                    "description": "the patch file of the record",
+                    "type": "string"
+                },
+                "validUntil": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GeoIPOverride": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "the API key name if it's empty",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "never expires if it's empty",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "record": {
+                    "description": "City, ISP or Hosting, depending on the db",
+                    "type": "object"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.GeoName": {
             "type": "object",
             "properties": {
@@ -1086,6 +2985,9 @@ const docTemplate = `{
                 "population": {
                     "type": "integer"
                 },
+                "subdivision2Name": {
+                    "type": "string"
+                },
                 "subdivisionName": {
                     "type": "string"
                 },
@@ -1120,6 +3022,38 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.GeoNameAdminSubdivision2": {
+            "type": "object",
+            "properties": {
+                "asciiName": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "continentCode": {
+                    "type": "string"
+                },
+                "continentName": {
+                    "type": "string"
+                },
+                "countryName": {
+                    "type": "string"
+                },
+                "geonameId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subdivisionName": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GeoNameContinent": {
+            "type": "object"
+        },
         "entity.GeoNameCountry": {
             "type": "object",
             "properties": {
@@ -1185,6 +3119,94 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.GeoNameItem": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "$ref": "#/definitions/entity.GeoName"
+                },
+                "continent": {
+                    "$ref": "#/definitions/entity.GeoNameContinent"
+                },
+                "country": {
+                    "$ref": "#/definitions/entity.GeoNameCountry"
+                },
+                "subdivision": {
+                    "$ref": "#/definitions/entity.GeoNameAdminSubdivision"
+                },
+                "subdivision2": {
+                    "$ref": "#/definitions/entity.GeoNameAdminSubdivision2"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GeoNameNearestCity": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "$ref": "#/definitions/entity.GeoName"
+                },
+                "distanceKm": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.GeoNamePostalCode": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "1 = estimated, 4 = geonameid, 6 = centroid of addresses or shape",
+                    "type": "integer"
+                },
+                "adminCode1": {
+                    "type": "string"
+                },
+                "adminCode2": {
+                    "type": "string"
+                },
+                "adminCode3": {
+                    "type": "string"
+                },
+                "adminName1": {
+                    "description": "state",
+                    "type": "string"
+                },
+                "adminName2": {
+                    "description": "county/province",
+                    "type": "string"
+                },
+                "adminName3": {
+                    "description": "community",
+                    "type": "string"
+                },
+                "continentCode": {
+                    "type": "string"
+                },
+                "continentName": {
+                    "type": "string"
+                },
+                "countryCode": {
+                    "type": "string"
+                },
+                "countryName": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "placeName": {
+                    "type": "string"
+                },
+                "postalCode": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.Hosting": {
             "type": "object",
             "properties": {
@@ -1271,6 +3293,101 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.PatchAnalysis": {
+            "type": "object",
+            "properties": {
+                "networks": {
+                    "description": "Networks are the patch networks that overlap the other patches or don't change the upstream records",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PatchNetworkAnalysis"
+                    }
+                },
+                "noOps": {
+                    "description": "the networks that don't change the upstream records",
+                    "type": "integer"
+                },
+                "overlaps": {
+                    "description": "the networks that overlap the other patches",
+                    "type": "integer"
+                },
+                "patches": {
+                    "description": "from the bottom layer to the top one, the record of the later patch wins",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "shadowed": {
+                    "description": "the networks replaced by the later patches",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "the networks of all the patches",
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "the networks are limited, the counts are not",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entity.PatchNetwork": {
+            "type": "object",
+            "properties": {
+                "network": {
+                    "type": "string"
+                },
+                "patch": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.PatchNetworkAnalysis": {
+            "type": "object",
+            "properties": {
+                "merge": {
+                    "description": "the record is partial",
+                    "type": "boolean"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "noOp": {
+                    "description": "NoOp is true if the upstream network contains the network and has all the values of the record",
+                    "type": "boolean"
+                },
+                "overlaps": {
+                    "description": "the networks of the other patches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PatchNetwork"
+                    }
+                },
+                "patch": {
+                    "type": "string"
+                },
+                "upstream": {
+                    "description": "the upstream network of the address",
+                    "type": "string"
+                },
+                "winner": {
+                    "description": "Winner is the top patch that has a record of the whole network, a partial record is merged onto the lower ones",
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RateLimit": {
+            "type": "object",
+            "properties": {
+                "burst": {
+                    "type": "integer"
+                },
+                "rps": {
+                    "type": "number"
+                }
+            }
+        },
         "entity.geoNameContinentJson": {
             "type": "object",
             "properties": {
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bldsoft/geos/pkg/client"
+	"github.com/bldsoft/geos/pkg/client/discovery"
+	"github.com/bldsoft/geos/pkg/config"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/tlsconfig"
+	gost "github.com/bldsoft/gost/config"
+	gost_discovery "github.com/bldsoft/gost/discovery"
+	"github.com/bldsoft/gost/discovery/common"
+	"github.com/go-resty/resty/v2"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	transportGRPC = "grpc"
+	transportREST = "rest"
+)
+
+var (
+	host         string
+	port         uint
+	transport    string
+	apiKey       string
+	useDiscovery bool
+	useTLS       bool
+	tlsConfig    tlsconfig.ClientConfig
+
+	serviceDiscovery gost_discovery.Discovery
+)
+
+var errRESTOnly = errors.New("the command requires --transport rest")
+
+// restClient is implemented by the REST clients, the endpoints aren't available over gRPC
+type restClient interface {
+	client.Client
+	GeoIPDatabase(ctx context.Context, db, format string) (*resty.Response, error)
+	GeoIPMetaData(ctx context.Context, db string) (*entity.MetaData, error)
+	GeoNameDump(ctx context.Context, filter entity.GeoNameFilter) (*resty.Response, error)
+	CheckGeoIPHostingUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error)
+	UpdateGeoIPHosting(ctx context.Context) error
+}
+
+func clientOpts() ([]client.Opt, error) {
+	var opts []client.Opt
+	if len(apiKey) != 0 {
+		opts = append(opts, client.WithApiKey(apiKey))
+	}
+	if useTLS || tlsConfig != (tlsconfig.ClientConfig{}) {
+		config, err := tlsConfig.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithTLS(config))
+	}
+	return opts, nil
+}
+
+func newClient(ctx *cli.Context) (client.Client, error) {
+	opts, err := clientOpts()
+	if err != nil {
+		return nil, err
+	}
+	if useDiscovery {
+		d, err := startDiscovery()
+		if err != nil {
+			return nil, err
+		}
+		if transport == transportREST {
+			return discovery.NewRestClient(d, opts...), nil
+		}
+		return discovery.NewGrpcClient(d, opts...), nil
+	}
+
+	addr := fmt.Sprintf("%s:%d", host, servicePort(ctx))
+	if transport == transportREST {
+		return client.NewRestClient(addr, opts...)
+	}
+	return client.NewGrpcClient(addr, opts...)
+}
+
+func newRestClient(ctx *cli.Context) (restClient, error) {
+	c, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res, ok := c.(restClient); ok {
+		return res, nil
+	}
+	return nil, errRESTOnly
+}
+
+// servicePort is the port flag, or the default port of the transport
+func servicePort(ctx *cli.Context) uint {
+	if ctx.IsSet("port") {
+		return port
+	}
+	var defaults config.Config
+	_ = gost.SetDefaults(&defaults)
+	if transport == transportREST {
+		return uint(defaults.Server.ServiceAddress.PortInt())
+	}
+	return uint(defaults.GRPCServiceAddress.PortInt())
+}
+
+// startDiscovery starts the discovery configured by the DISCOVERY_* env variables of the service
+func startDiscovery() (gost_discovery.Discovery, error) {
+	if serviceDiscovery != nil {
+		return serviceDiscovery, nil
+	}
+	var cfg config.Config
+	gost.ReadConfig(&cfg, "")
+	d := common.NewDiscovery(cfg.Server, cfg.Discovery)
+	if d == nil {
+		return nil, fmt.Errorf("discovery isn't configured")
+	}
+	go func() {
+		_ = d.Run()
+	}()
+	serviceDiscovery = d
+	return d, nil
+}
+
+func stopDiscovery(ctx *cli.Context) error {
+	if serviceDiscovery == nil {
+		return nil
+	}
+	return serviceDiscovery.Stop(ctx.Context)
+}
+
+func transportFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "transport",
+			Usage:       "Transport: grpc or rest. Hosting, metadata, dumps and the management commands require rest",
+			Value:       transportGRPC,
+			Destination: &transport,
+			Aliases:     []string{"t"},
+			Action: func(ctx *cli.Context, value string) error {
+				if value != transportGRPC && value != transportREST {
+					return fmt.Errorf("unknown transport %q", value)
+				}
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:        "api-key",
+			Usage:       "API key, it's required for dumps and the management commands",
+			EnvVars:     []string{"GEOS_API_KEY"},
+			Destination: &apiKey,
+		},
+		&cli.BoolFlag{
+			Name:        "discovery",
+			Usage:       "Select the service instance by discovery instead of the host and the port. It's configured by the DISCOVERY_* env variables of the service",
+			Destination: &useDiscovery,
+		},
+	}
+}
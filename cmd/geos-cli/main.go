@@ -1,32 +1,94 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
 	"log"
 	"os"
 	"strings"
 
-	grpc "github.com/bldsoft/geos/pkg/client/grpc"
+	"github.com/bldsoft/geos/pkg/client"
 	"github.com/bldsoft/geos/pkg/config"
 	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/go-resty/resty/v2"
 	"github.com/urfave/cli/v2"
 
 	gost "github.com/bldsoft/gost/config"
 )
 
-var (
-	host string
-	port uint
-)
+// batchAddressField is the field of the address in the results of the batch lookups
+const batchAddressField = "address"
 
-func client(ctx *cli.Context) *grpc.Client {
-	addr := fmt.Sprintf("%s:%d", host, port)
-	client, err := grpc.NewClient(addr)
-	if err != nil {
-		log.Fatalf(err.Error())
+// withClient prints the result of the request
+func withClient(request func(ctx *cli.Context, c client.Client) (interface{}, error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		c, err := newClient(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := request(ctx, c)
+		if err != nil {
+			return err
+		}
+		return print(res)
+	}
+}
+
+// lookupAction prints the result of the address, or of the addresses of stdin line by line if the address is "-".
+// The failed lookups of the batch are reported by the error field.
+func lookupAction(lookup func(ctx *cli.Context, c client.Client, address string) (interface{}, error)) cli.ActionFunc {
+	return withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+		if address := addr(ctx); address != "-" {
+			return lookup(ctx, c, address)
+		}
+
+		results := []interface{}{}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			address := strings.TrimSpace(scanner.Text())
+			if len(address) == 0 {
+				continue
+			}
+			res, err := lookup(ctx, c, address)
+			if err != nil {
+				results = append(results, map[string]interface{}{batchAddressField: address, "error": err.Error()})
+				continue
+			}
+			value, err := jsonValue(res)
+			if err != nil {
+				return nil, err
+			}
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				fields = map[string]interface{}{"result": value}
+			}
+			fields[batchAddressField] = address
+			results = append(results, fields)
+		}
+		return results, scanner.Err()
+	})
+}
+
+// pageAction prints the page items, the total count and the next cursor go to stderr not to break the output format
+func pageAction[T any](list func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[T], error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		c, err := newClient(ctx)
+		if err != nil {
+			return err
+		}
+		page, err := list(ctx, c, geoNamesFilter(ctx))
+		if err != nil {
+			return err
+		}
+		if err := print(page.Items); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "total: %d\n", page.Total)
+		if len(page.NextCursor) != 0 {
+			fmt.Fprintf(os.Stderr, "next cursor: %s\n", page.NextCursor)
+		}
+		return nil
 	}
-	return client
 }
 
 func addr(ctx *cli.Context) string {
@@ -37,11 +99,16 @@ func addr(ctx *cli.Context) string {
 	return addr
 }
 
-func geoNamesFilter(ctx *cli.Context) entity.GeoNameFilter {
-	var countryCodes []string
-	if codes := ctx.String("countries"); codes != "" {
-		countryCodes = strings.Split(codes, ",")
+// listFlag splits the comma separated list
+func listFlag(ctx *cli.Context, name string) []string {
+	if list := ctx.String(name); list != "" {
+		return strings.Split(list, ",")
 	}
+	return nil
+}
+
+func geoNamesFilter(ctx *cli.Context) entity.GeoNameFilter {
+	countryCodes := listFlag(ctx, "countries")
 	inGeoNamesIDs := ctx.Uint64Slice("geoname-ids")
 	outGeoNamesIDs := make([]uint32, 0, len(inGeoNamesIDs))
 	for _, id := range inGeoNamesIDs {
@@ -50,15 +117,27 @@ func geoNamesFilter(ctx *cli.Context) entity.GeoNameFilter {
 	return entity.GeoNameFilter{
 		CountryCodes: countryCodes,
 		NamePrefix:   ctx.String("name-prefix"),
+		MatchMode:    entity.GeoNameMatch(ctx.String("match")),
+		Lang:         ctx.String("lang"),
+		Sort:         entity.GeoNameSort(ctx.String("sort")),
+		Offset:       uint32(ctx.Uint("offset")),
+		Cursor:       ctx.String("cursor"),
 		Limit:        uint32(ctx.Int64("limit")),
 		GeoNameIDs:   outGeoNamesIDs,
+
+		ContinentCodes:   listFlag(ctx, "continents"),
+		SubdivisionCodes: listFlag(ctx, "subdivisions"),
+		FeatureCodes:     listFlag(ctx, "feature-codes"),
+		TimeZones:        listFlag(ctx, "time-zones"),
+		MinPopulation:    ctx.Int("min-population"),
+		BBox:             ctx.Float64Slice("bbox"),
 	}
 }
 
 func commonFlags() []cli.Flag {
 	var defaults config.Config
 	_ = gost.SetDefaults(&defaults)
-	return []cli.Flag{
+	return append(append(transportFlags(), outputFlag()), []cli.Flag{
 		&cli.StringFlag{
 			Name:        "host",
 			Usage:       "Service host",
@@ -68,12 +147,41 @@ func commonFlags() []cli.Flag {
 		},
 		&cli.UintFlag{
 			Name:        "port",
-			Usage:       "Service gRPC port",
-			Value:       uint(defaults.GRPCServiceAddress.PortInt()),
+			Usage:       fmt.Sprintf("Service port, %d for grpc and %d for rest by default", defaults.GRPCServiceAddress.PortInt(), defaults.Server.ServiceAddress.PortInt()),
 			Destination: &port,
 			Aliases:     []string{"p"},
 		},
-	}
+		&cli.BoolFlag{
+			Name:        "tls",
+			Usage:       "Connect over TLS. It's implied by the other tls flags",
+			Destination: &useTLS,
+		},
+		&cli.StringFlag{
+			Name:        "tls-ca",
+			Usage:       "PEM CA bundle to verify the server certificate. The system pool is used by default",
+			Destination: &tlsConfig.CAFile,
+		},
+		&cli.StringFlag{
+			Name:        "tls-cert",
+			Usage:       "PEM client certificate for mTLS",
+			Destination: &tlsConfig.CertFile,
+		},
+		&cli.StringFlag{
+			Name:        "tls-key",
+			Usage:       "PEM private key of the client certificate",
+			Destination: &tlsConfig.KeyFile,
+		},
+		&cli.StringFlag{
+			Name:        "tls-server-name",
+			Usage:       "Server name to verify the certificate against, the host is used by default",
+			Destination: &tlsConfig.ServerName,
+		},
+		&cli.BoolFlag{
+			Name:        "tls-insecure-skip-verify",
+			Usage:       "Don't verify the server certificate",
+			Destination: &tlsConfig.InsecureSkipVerify,
+		},
+	}...)
 }
 
 func commonGeoNamesFlags() []cli.Flag {
@@ -89,54 +197,134 @@ func commonGeoNamesFlags() []cli.Flag {
 			Usage:   "Name prefix",
 			Aliases: []string{"np"},
 		},
+		&cli.StringFlag{
+			Name:    "match",
+			Usage:   "Name match: prefix, exact or fuzzy",
+			Value:   string(entity.GeoNameMatchPrefix),
+			Aliases: []string{"m"},
+		},
 		&cli.Int64Flag{
 			Name:    "limit",
 			Aliases: []string{"l"},
 		},
+		&cli.StringFlag{
+			Name:  "continents",
+			Usage: "Comma separated list of continent codes",
+		},
+		&cli.StringFlag{
+			Name:  "subdivisions",
+			Usage: "Comma separated list of subdivision codes: <country code>.<admin1 code>",
+		},
+		&cli.StringFlag{
+			Name:  "feature-codes",
+			Usage: "Comma separated list of GeoNames feature codes, e.g. PPLC,PPLA",
+		},
+		&cli.StringFlag{
+			Name:  "time-zones",
+			Usage: "Comma separated list of time zones, e.g. Europe/Berlin",
+		},
+		&cli.IntFlag{
+			Name:  "min-population",
+			Usage: "Min population",
+		},
+		&cli.Float64SliceFlag{
+			Name:  "bbox",
+			Usage: "Bounding box: west,south,east,north",
+		},
+		&cli.UintFlag{
+			Name:  "offset",
+			Usage: "Number of entities to skip",
+		},
+		&cli.StringFlag{
+			Name:  "cursor",
+			Usage: "Next cursor printed with the previous page. The offset is ignored if it's set",
+		},
+		&cli.StringFlag{
+			Name:  "sort",
+			Usage: "Order: name, population or geonameid",
+		},
 		&cli.Uint64SliceFlag{
 			Name:    "geoname-ids",
 			Aliases: []string{"gid"},
 		},
+		&cli.StringFlag{
+			Name:  "lang",
+			Usage: "Language of the names",
+		},
 	}
 }
 
-func print(obj interface{}) error {
-	data, err := json.MarshalIndent(obj, "", "	")
-	if err != nil {
-		return err
+func hierarchyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.UintFlag{
+			Name:     "id",
+			Usage:    "GeoNames id",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "Type of the entity: continent, country, subdivision, subdivision2 or city. It's detected if empty",
+		},
+		&cli.UintFlag{
+			Name:    "limit",
+			Usage:   "Max number of children",
+			Aliases: []string{"l"},
+		},
+		&cli.StringFlag{
+			Name:  "lang",
+			Usage: "Language of the names",
+		},
 	}
-	fmt.Printf("%s", data)
-	return nil
+}
+
+func hierarchyFilter(ctx *cli.Context) entity.GeoNameHierarchyFilter {
+	return entity.GeoNameHierarchyFilter{
+		Type:  entity.GeoNameType(ctx.String("type")),
+		Lang:  ctx.String("lang"),
+		Limit: uint32(ctx.Uint("limit")),
+	}
+}
+
+var metadataDBFlag = &cli.StringFlag{
+	Name:  "db",
+	Usage: "Database: city, isp or hosting",
+	Value: "city",
 }
 
 func main() {
 	app := &cli.App{
 		Name:  "geos-cli",
-		Usage: "Geos gRPC client",
+		Usage: "Geos gRPC and REST client",
 		Flags: commonFlags(),
+		After: stopDiscovery,
 		Commands: []*cli.Command{
 			{
-				Name: "city",
-				Action: func(ctx *cli.Context) error {
-					city, err := client(ctx).City(ctx.Context, addr(ctx), true)
-					if err != nil {
-						return err
-					}
-					return print(city)
+				Name:  "city",
+				Usage: "The city of the address, \"-\" reads the addresses from stdin",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "enrich",
+						Usage: "additional data: geonames",
+					},
 				},
+				Action: lookupAction(func(ctx *cli.Context, c client.Client, address string) (interface{}, error) {
+					var enrich []entity.CityEnrichment
+					for _, e := range ctx.StringSlice("enrich") {
+						enrich = append(enrich, entity.CityEnrichment(e))
+					}
+					return c.CityEnriched(ctx.Context, address, true, enrich...)
+				}),
 			},
 			{
-				Name: "country",
-				Action: func(ctx *cli.Context) error {
-					country, err := client(ctx).Country(ctx.Context, addr(ctx))
-					if err != nil {
-						return err
-					}
-					return print(country)
-				},
+				Name:  "country",
+				Usage: "The country of the address, \"-\" reads the addresses from stdin",
+				Action: lookupAction(func(ctx *cli.Context, c client.Client, address string) (interface{}, error) {
+					return c.Country(ctx.Context, address)
+				}),
 			},
 			{
-				Name: "city-lite",
+				Name:  "city-lite",
+				Usage: "The city and the country names of the address, \"-\" reads the addresses from stdin",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:    "lang",
@@ -144,53 +332,193 @@ func main() {
 						Usage:   "Language for country 1and city name",
 						Aliases: []string{"l"}},
 				},
+				Action: lookupAction(func(ctx *cli.Context, c client.Client, address string) (interface{}, error) {
+					return c.CityLite(ctx.Context, address, ctx.String("lang"))
+				}),
+			},
+			{
+				Name:  "hosting",
+				Usage: "The hosting of the address, \"-\" reads the addresses from stdin",
+				Action: lookupAction(func(ctx *cli.Context, c client.Client, address string) (interface{}, error) {
+					return c.Hosting(ctx.Context, address)
+				}),
+			},
+			{
+				Name:  "metadata",
+				Usage: "The metadata of the database",
+				Flags: []cli.Flag{metadataDBFlag},
 				Action: func(ctx *cli.Context) error {
-					cityLite, err := client(ctx).CityLite(ctx.Context, addr(ctx), ctx.String("lang"))
+					c, err := newRestClient(ctx)
 					if err != nil {
 						return err
 					}
-					return print(cityLite)
+					meta, err := c.GeoIPMetaData(ctx.Context, ctx.String("db"))
+					if err != nil {
+						return err
+					}
+					return print(meta)
 				},
 			},
 			{
-				Name: "geoname-continent",
-				Action: func(ctx *cli.Context) error {
-					return print(client(ctx).GeoNameContinents(ctx.Context))
+				Name:      "dump",
+				Usage:     "Download the database",
+				UsageText: "geos-cli --transport rest --api-key <key> dump --db isp --format mmdb -f isp.mmdb",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "db",
+						Usage: "Database: city, isp, hosting or geonames",
+						Value: "city",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Format of the city, isp and hosting databases: mmdb or csv",
+						Value: "mmdb",
+					},
+					&cli.StringFlag{
+						Name:    "file",
+						Usage:   "Output file, stdout if it's empty",
+						Aliases: []string{"f"},
+					},
 				},
+				Action: dump,
 			},
 			{
-				Name:  "geoname-country",
-				Flags: commonGeoNamesFlags(),
+				Name:  "update-check",
+				Usage: "Check the updates of the database",
+				Flags: []cli.Flag{managementDBFlag},
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					switch db := ctx.String("db"); db {
+					case "city":
+						return c.CheckGeoIPCityUpdates(ctx.Context)
+					case "isp":
+						return c.CheckGeoIPISPUpdates(ctx.Context)
+					case "geonames":
+						return c.CheckGeonamesUpdates(ctx.Context)
+					case "hosting":
+						rc, ok := c.(restClient)
+						if !ok {
+							return nil, errRESTOnly
+						}
+						return rc.CheckGeoIPHostingUpdates(ctx.Context)
+					default:
+						return nil, fmt.Errorf("unknown db %q", db)
+					}
+				}),
+			},
+			{
+				Name:  "update",
+				Usage: "Update the database, it's started in the background if it isn't running",
+				Flags: []cli.Flag{managementDBFlag},
 				Action: func(ctx *cli.Context) error {
-					countries, err := client(ctx).GeoNameCountries(ctx.Context, geoNamesFilter(ctx))
+					c, err := newClient(ctx)
 					if err != nil {
 						return err
 					}
-					return print(countries)
+					switch db := ctx.String("db"); db {
+					case "city":
+						err = c.UpdateGeoIPCity(ctx.Context)
+					case "isp":
+						err = c.UpdateGeoIPISP(ctx.Context)
+					case "geonames":
+						err = c.UpdateGeonames(ctx.Context)
+					case "hosting":
+						rc, ok := c.(restClient)
+						if !ok {
+							return errRESTOnly
+						}
+						err = rc.UpdateGeoIPHosting(ctx.Context)
+					default:
+						return fmt.Errorf("unknown db %q", db)
+					}
+					return err
 				},
 			},
+			{
+				Name: "geoname-continent",
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					return c.GeoNameContinents(ctx.Context), nil
+				}),
+			},
+			{
+				Name:  "geoname-country",
+				Flags: commonGeoNamesFlags(),
+				Action: pageAction(func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+					return c.GeoNameCountriesPage(ctx.Context, filter)
+				}),
+			},
 			{
 				Name:  "geoname-subdivision",
 				Flags: commonGeoNamesFlags(),
-				Action: func(ctx *cli.Context) error {
-					subdivisions, err := client(ctx).GeoNameSubdivisions(ctx.Context, geoNamesFilter(ctx))
-					if err != nil {
-						return err
-					}
-					return print(subdivisions)
+				Action: pageAction(func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+					return c.GeoNameSubdivisionsPage(ctx.Context, filter)
+				}),
+			},
+			{
+				Name:  "geoname-subdivision2",
+				Usage: "Second-level subdivisions (counties, districts)",
+				Flags: commonGeoNamesFlags(),
+				Action: pageAction(func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+					return c.GeoNameSubdivisions2Page(ctx.Context, filter)
+				}),
+			},
+			{
+				Name:  "geoname-postal",
+				Usage: "Postal codes, --name-prefix matches the postal code and the place name",
+				Flags: commonGeoNamesFlags(),
+				Action: pageAction(func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+					return c.GeoNamePostalCodesPage(ctx.Context, filter)
+				}),
+			},
+			{
+				Name:  "geoname-nearest",
+				Usage: "Find the cities nearest to the location",
+				Flags: []cli.Flag{
+					&cli.Float64Flag{Name: "lat", Required: true},
+					&cli.Float64Flag{Name: "lon", Required: true},
+					&cli.UintFlag{Name: "limit", Aliases: []string{"l"}},
+					&cli.Float64Flag{Name: "radius-km", Aliases: []string{"r"}},
+					&cli.StringFlag{Name: "lang", Usage: "Language of the names"},
 				},
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					return c.GeoNameNearest(ctx.Context, entity.GeoNameNearestFilter{
+						Latitude:  ctx.Float64("lat"),
+						Longitude: ctx.Float64("lon"),
+						Limit:     uint32(ctx.Uint("limit")),
+						RadiusKm:  ctx.Float64("radius-km"),
+						Lang:      ctx.String("lang"),
+					})
+				}),
+			},
+			{
+				Name:  "geoname",
+				Usage: "The GeoNames entity of any type by id",
+				Flags: hierarchyFlags(),
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					return c.GeoName(ctx.Context, uint32(ctx.Uint("id")), hierarchyFilter(ctx))
+				}),
+			},
+			{
+				Name:  "geoname-children",
+				Usage: "The entities one level down the hierarchy: countries of a continent, subdivisions of a country, etc.",
+				Flags: hierarchyFlags(),
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					return c.GeoNameChildren(ctx.Context, uint32(ctx.Uint("id")), hierarchyFilter(ctx))
+				}),
+			},
+			{
+				Name:  "geoname-ancestors",
+				Usage: "The entities up the hierarchy, the parent first",
+				Flags: hierarchyFlags(),
+				Action: withClient(func(ctx *cli.Context, c client.Client) (interface{}, error) {
+					return c.GeoNameAncestors(ctx.Context, uint32(ctx.Uint("id")), hierarchyFilter(ctx))
+				}),
 			},
 			{
 				Name:  "geoname-city",
 				Flags: commonGeoNamesFlags(),
-				Action: func(ctx *cli.Context) error {
-
-					cities, err := client(ctx).GeoNameCities(ctx.Context, geoNamesFilter(ctx))
-					if err != nil {
-						return err
-					}
-					return print(cities)
-				},
+				Action: pageAction(func(ctx *cli.Context, c client.Client, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+					return c.GeoNameCitiesPage(ctx.Context, filter)
+				}),
 			},
 		},
 	}
@@ -199,3 +527,42 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+var managementDBFlag = &cli.StringFlag{
+	Name:  "db",
+	Usage: "Database: city, isp, hosting or geonames. Hosting requires rest",
+	Value: "city",
+}
+
+func dump(ctx *cli.Context) (err error) {
+	c, err := newRestClient(ctx)
+	if err != nil {
+		return err
+	}
+	var resp *resty.Response
+	switch db := ctx.String("db"); db {
+	case "geonames":
+		if resp, err = c.GeoNameDump(ctx.Context, entity.GeoNameFilter{}); err == nil && resp.IsError() {
+			err = fmt.Errorf("status code: %d, response: %s", resp.StatusCode(), resp.Body())
+		}
+	default:
+		resp, err = c.GeoIPDatabase(ctx.Context, db, ctx.String("format"))
+	}
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := ctx.String("file"); len(path) > 0 {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+	_, err = out.Write(resp.Body())
+	return err
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputTable = "table"
+	outputCSV   = "csv"
+)
+
+var outputFormat string
+
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:        "output",
+		Usage:       "Output format: json, yaml, table or csv. The nested fields of table and csv are the columns of the dotted paths",
+		Value:       outputJSON,
+		Destination: &outputFormat,
+		Aliases:     []string{"o"},
+		Action: func(ctx *cli.Context, value string) error {
+			if !slices.Contains([]string{outputJSON, outputYAML, outputTable, outputCSV}, value) {
+				return fmt.Errorf("unknown output format %q", value)
+			}
+			return nil
+		},
+	}
+}
+
+func print(obj interface{}) error {
+	return write(os.Stdout, obj)
+}
+
+func write(w io.Writer, obj interface{}) error {
+	if outputFormat == outputJSON || len(outputFormat) == 0 {
+		data, err := json.MarshalIndent(obj, "", "	")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	// the entities are converted by their JSON fields
+	value, err := jsonValue(obj)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case outputTable:
+		return writeTable(w, value)
+	default:
+		return writeCSV(w, value)
+	}
+}
+
+func jsonValue(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var res interface{}
+	return res, decoder.Decode(&res)
+}
+
+// rows flattens the value to the rows of the fields, the list items are the rows
+func rows(value interface{}) (columns []string, res []map[string]string) {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	seen := make(map[string]bool)
+	for _, item := range items {
+		row := make(map[string]string)
+		flatten("", item, row)
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+		res = append(res, row)
+	}
+	slices.SortFunc(columns, compareColumns)
+	return columns, res
+}
+
+// compareColumns sorts the columns by name, the address of the batch lookups is the first one
+func compareColumns(a, b string) int {
+	switch {
+	case a == batchAddressField:
+		return -1
+	case b == batchAddressField:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func flatten(path string, value interface{}, res map[string]string) {
+	join := func(key string) string {
+		if len(path) == 0 {
+			return key
+		}
+		return path + "." + key
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			flatten(join(key), v, res)
+		}
+	case []interface{}:
+		for i, v := range value {
+			flatten(join(strconv.Itoa(i)), v, res)
+		}
+	case nil:
+		res[path] = ""
+	default:
+		res[path] = fmt.Sprint(value)
+	}
+}
+
+// writeTable writes the fields and the values of an object, or a row per list item
+func writeTable(w io.Writer, value interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	columns, rows := rows(value)
+	if _, isList := value.([]interface{}); !isList {
+		fmt.Fprintln(tw, "FIELD\tVALUE")
+		for _, column := range columns {
+			fmt.Fprintf(tw, "%s\t%s\n", column, rows[0][column])
+		}
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			values = append(values, row[column])
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, value interface{}) error {
+	csvWriter := csv.NewWriter(w)
+	columns, rows := rows(value)
+	if err := csvWriter.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			values = append(values, row[column])
+		}
+		if err := csvWriter.Write(values); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
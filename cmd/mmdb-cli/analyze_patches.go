@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/maxmind"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/urfave/cli/v2"
+)
+
+func analyzePatchesCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "analyze-patches",
+		Usage:     "report the overlapping networks of the patches and the ones that don't change the upstream db",
+		UsageText: "mmdb-cli analyze-patches --db city GeoLite2-City.mmdb city_patches.tar.gz office.json",
+		Description: `The patches are .json, .csv, .mmdb or .tar.gz archives of them, from the first applied to the last one.
+The patches of an archive are ordered by its manifest.json or by name.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "db",
+				Usage: "the record type of the .json and .csv patches: city, isp or hosting",
+				Value: "city",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "max number of the reported networks, 0 for all",
+				Value: 1000,
+			},
+		},
+		Action: analyzePatches,
+	}
+}
+
+func analyzePatches(ctx *cli.Context) error {
+	paths := ctx.Args().Slice()
+	if len(paths) < 2 {
+		return fmt.Errorf("the upstream db and the patches are required")
+	}
+	readPatches, err := patchReader(ctx.String("db"))
+	if err != nil {
+		return err
+	}
+
+	upstream, err := openDatabase(ctx.Context, paths[0])
+	if err != nil {
+		return err
+	}
+	var patches []*maxmind.DatabasePatch
+	for _, path := range paths[1:] {
+		filePatches, err := readPatches(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		patches = append(patches, filePatches...)
+	}
+
+	analysis, err := maxmind.AnalyzePatches(ctx.Context, upstream, ctx.Int("limit"), patches...)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(analysis)
+}
+
+func patchReader(db string) (func(path string) ([]*maxmind.DatabasePatch, error), error) {
+	switch db {
+	case "city":
+		return readPatches[entity.City], nil
+	case "isp":
+		return readPatches[entity.ISP], nil
+	case "hosting":
+		return readPatches[entity.Hosting], nil
+	default:
+		return nil, fmt.Errorf("unknown db %q", db)
+	}
+}
+
+// readPatches reads the single patch file or the patches of the .tar.gz archive
+func readPatches[T maxmind.PatchEntity](path string) ([]*maxmind.DatabasePatch, error) {
+	if !strings.HasSuffix(path, ".tar.gz") && !strings.HasSuffix(path, ".tgz") {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := maxmind.NewDatabasePatchFromContent[T](filepath.Base(path), content)
+		if err != nil {
+			return nil, err
+		}
+		return []*maxmind.DatabasePatch{patch}, nil
+	}
+
+	dbs, err := maxmind.NewDatabasePatchesFromTarGz[T](source.NewTSUpdatableFile(path, ""))
+	if err != nil {
+		return nil, err
+	}
+	patches := make([]*maxmind.DatabasePatch, 0, len(dbs))
+	for _, db := range dbs {
+		patches = append(patches, db.(*maxmind.DatabasePatch))
+	}
+	return patches, nil
+}
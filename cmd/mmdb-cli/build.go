@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/maxmind"
+	"github.com/maxmind/mmdbwriter"
+	"github.com/urfave/cli/v2"
+)
+
+// recordSchema reads and writes the records of the db schema
+type recordSchema struct {
+	databaseType string
+	readers      map[string]func(r io.Reader) (maxmind.MMDBRecordReader, error) // by the file extension
+	writeCSV     func(ctx context.Context, db maxmind.Database, w io.Writer) error
+	writeNDJSON  func(ctx context.Context, db maxmind.Database, w io.Writer) error
+}
+
+func typedSchema[T maxmind.PatchEntity](databaseType string) recordSchema {
+	return recordSchema{
+		databaseType: databaseType,
+		readers:      schemaReaders[T](maxmind.NewCSVRecordReader[T]),
+		writeCSV: func(ctx context.Context, db maxmind.Database, w io.Writer) error {
+			return maxmind.NewCSVDumper[T](db).WriteCSVTo(ctx, w)
+		},
+		writeNDJSON: maxmind.WriteNDJSON[T],
+	}
+}
+
+func schemaReaders[T maxmind.MMDBEntity](csvReader func(r io.Reader) (maxmind.MMDBRecordReader, error)) map[string]func(r io.Reader) (maxmind.MMDBRecordReader, error) {
+	return map[string]func(r io.Reader) (maxmind.MMDBRecordReader, error){
+		".csv":    csvReader,
+		".json":   maxmind.NewJSONRecordReader[T],
+		".ndjson": maxmind.NewNDJSONRecordReader[T],
+		".jsonl":  maxmind.NewNDJSONRecordReader[T],
+	}
+}
+
+func schema(name string) (recordSchema, error) {
+	switch name {
+	case "city":
+		return typedSchema[entity.City]("GeoIP2-City"), nil
+	case "isp":
+		return typedSchema[entity.ISP]("GeoIP2-ISP"), nil
+	case "hosting":
+		return typedSchema[entity.Hosting]("Hosting"), nil
+	case "generic":
+		return recordSchema{
+			databaseType: "Generic",
+			readers:      schemaReaders[maxmind.GenericRecord](maxmind.NewGenericCSVRecordReader),
+			writeCSV:     maxmind.WriteGenericCSV,
+			writeNDJSON:  maxmind.WriteNDJSON[map[string]interface{}],
+		}, nil
+	default:
+		return recordSchema{}, fmt.Errorf("unknown schema %q", name)
+	}
+}
+
+var schemaFlag = &cli.StringFlag{
+	Name:  "schema",
+	Usage: "the record schema: city, isp, hosting or generic",
+	Value: "city",
+}
+
+func buildCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "build",
+		Usage:     "build the MMDB from CSV, NDJSON or JSON records",
+		UsageText: "mmdb-cli build --schema isp --description \"in-house ISP\" -o isp.mmdb networks.csv",
+		Description: `The inputs are read by the file extension:
+.csv is the rows of the network and the record columns in the order of the CSV dump with the optional header, or with the header of the field paths for the generic schema;
+.ndjson and .jsonl are the JSON lines of the records with the network field;
+.json is the patch format, the map of the networks to the records.
+The smaller networks win regardless of the order, the records with "merge" are merged onto the enclosing ones.`,
+		Flags: []cli.Flag{
+			schemaFlag,
+			&cli.StringFlag{
+				Name:     "o",
+				Aliases:  []string{"output"},
+				Usage:    "the MMDB file",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "the database type of the metadata, by the schema if it's empty",
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "the English description of the metadata, the database type if it's empty",
+			},
+			&cli.StringSliceFlag{
+				Name:  "language",
+				Usage: "the languages of the record names",
+			},
+			&cli.IntFlag{
+				Name:  "ip-version",
+				Usage: "4 or 6",
+				Value: 6,
+			},
+			&cli.IntFlag{
+				Name:  "record-size",
+				Usage: "24, 28 or 32",
+				Value: 28,
+			},
+			&cli.BoolFlag{
+				Name:  "include-reserved",
+				Usage: "allow the private and the reserved networks",
+				Value: true,
+			},
+		},
+		Action: build,
+	}
+}
+
+func build(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("the input files are required")
+	}
+	s, err := schema(ctx.String("schema"))
+	if err != nil {
+		return err
+	}
+
+	var readers []maxmind.MMDBRecordReader
+	for _, path := range ctx.Args().Slice() {
+		newReader, ok := s.readers[filepath.Ext(path)]
+		if !ok {
+			return fmt.Errorf("%s: unknown format", path)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		reader, err := newReader(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		readers = append(readers, reader)
+	}
+
+	options := mmdbwriter.Options{
+		DatabaseType:            ctx.String("type"),
+		Languages:               ctx.StringSlice("language"),
+		IPVersion:               ctx.Int("ip-version"),
+		RecordSize:              ctx.Int("record-size"),
+		IncludeReservedNetworks: ctx.Bool("include-reserved"),
+	}
+	if len(options.DatabaseType) == 0 {
+		options.DatabaseType = s.databaseType
+	}
+	// the Verify of maxminddb requires a description
+	options.Description = map[string]string{"en": options.DatabaseType}
+	if description := ctx.String("description"); len(description) > 0 {
+		options.Description["en"] = description
+	}
+
+	var buf bytes.Buffer
+	count, err := maxmind.WriteMMDB(&buf, options, readers...)
+	if err != nil {
+		return err
+	}
+	output := ctx.String("o")
+	if err := os.WriteFile(output, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("%d records written to %s\n", count, output)
+	return nil
+}
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "dump the MMDB to CSV or NDJSON, build reads them back",
+		UsageText: "mmdb-cli export --schema city --format ndjson -o city.ndjson GeoLite2-City.mmdb",
+		Description: `The CSV of the city, isp and hosting schemas is the CSV dump of geos, the generic CSV columns are the paths of the record values.
+The NDJSON lines are the records with the network field.`,
+		Flags: []cli.Flag{
+			schemaFlag,
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "csv or ndjson",
+				Value: "csv",
+			},
+			&cli.StringFlag{
+				Name:     "o",
+				Aliases:  []string{"output"},
+				Usage:    "the output file",
+				Required: true,
+			},
+		},
+		Action: export,
+	}
+}
+
+func export(ctx *cli.Context) (err error) {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("the MMDB file is required")
+	}
+	s, err := schema(ctx.String("schema"))
+	if err != nil {
+		return err
+	}
+	write := s.writeCSV
+	switch format := ctx.String("format"); format {
+	case "csv":
+	case "ndjson":
+		write = s.writeNDJSON
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	db, err := openDatabase(ctx.Context, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(ctx.String("o"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	w := bufio.NewWriter(out)
+	if err := write(ctx.Context, db, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
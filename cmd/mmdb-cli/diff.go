@@ -0,0 +1,488 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/urfave/cli/v2"
+)
+
+type diffChange string
+
+const (
+	diffAdded   diffChange = "added"
+	diffRemoved diffChange = "removed"
+	diffChanged diffChange = "changed"
+)
+
+// countryField is the field of the country filter and the summary
+const countryField = "country.iso_code"
+
+type fieldDiff struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type networkDiff struct {
+	Network netip.Prefix `json:"network"`
+	Change  diffChange   `json:"change"`
+	Country string       `json:"country,omitempty"` // the new country, the old one for the removed networks
+	// Fields are the changed fields, of the added and removed networks with the field filter only
+	Fields []fieldDiff `json:"fields,omitempty"`
+	old    string      // the old country
+}
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "report the added, removed and changed networks of the new db",
+		UsageText: "mmdb-cli diff [--country BY] [--field city] [--format text|csv|ndjson] old.mmdb new.mmdb",
+		Description: `The networks are the common parts of the networks of both dbs, the fields are the paths of the record values, e.g. country.iso_code or city.names.en.
+The summary of the networks and the addresses, also lost and gained by each country, is printed at the end, to stderr for CSV and NDJSON.`,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "country",
+				Usage: "report the networks of the countries only, old or new, by ISO code",
+			},
+			&cli.StringSliceFlag{
+				Name:  "field",
+				Usage: "report the changes of the fields and their nested fields only",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "text, csv or ndjson",
+				Value: "text",
+			},
+		},
+		Action: diff,
+	}
+}
+
+func diff(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("the old and the new dbs are required")
+	}
+	filter := diffFilter{countries: ctx.StringSlice("country"), fields: ctx.StringSlice("field")}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var write func(d *networkDiff) error
+	summaryOut := io.Writer(os.Stderr)
+	switch format := ctx.String("format"); format {
+	case "text":
+		write = func(d *networkDiff) error { return writeTextDiff(out, d) }
+		summaryOut = out
+	case "csv":
+		w := csv.NewWriter(out)
+		defer w.Flush()
+		if err := w.Write([]string{"network", "change", "country", "field", "old", "new"}); err != nil {
+			return err
+		}
+		write = func(d *networkDiff) error { return writeCSVDiff(w, d) }
+	case "ndjson":
+		encoder := json.NewEncoder(out)
+		write = func(d *networkDiff) error { return encoder.Encode(d) }
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	var networks [2]*maxminddb.Networks
+	for i, path := range ctx.Args().Slice() {
+		db, err := openDatabase(ctx.Context, path)
+		if err != nil {
+			return err
+		}
+		if networks[i], err = db.Networks(ctx.Context, maxminddb.SkipAliasedNetworks); err != nil {
+			return err
+		}
+	}
+
+	summary := newDiffSummary()
+	if err := diffDatabases(networks[0], networks[1], filter, summary, write); err != nil {
+		return err
+	}
+	out.Flush()
+	return summary.write(summaryOut)
+}
+
+// diffDatabases writes the differences of the networks of the dbs by the fewest networks and adds them to the summary
+func diffDatabases(oldNetworks, newNetworks *maxminddb.Networks, filter diffFilter, summary *diffSummary, write func(d *networkDiff) error) error {
+	return diffNetworks(oldNetworks, newNetworks, func(first, last netip.Addr, old, new map[string]interface{}) error {
+		d := filter.diff(old, new)
+		if d == nil {
+			return nil
+		}
+		for _, prefix := range rangePrefixes(first, last) {
+			d.Network = prefix
+			summary.add(d)
+			if err := write(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type diffFilter struct {
+	countries []string
+	fields    []string
+}
+
+// diff returns nil if the records are the same or the difference is filtered out
+func (f diffFilter) diff(old, new map[string]interface{}) *networkDiff {
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	oldFields, newFields := flattenRecord(old), flattenRecord(new)
+	res := &networkDiff{Change: diffChanged, Country: fieldString(newFields, countryField), old: fieldString(oldFields, countryField)}
+	switch {
+	case old == nil:
+		res.Change = diffAdded
+	case new == nil:
+		res.Change, res.Country = diffRemoved, res.old
+	}
+
+	if len(f.countries) > 0 && !slices.ContainsFunc(f.countries, func(country string) bool {
+		return strings.EqualFold(country, res.Country) || strings.EqualFold(country, res.old)
+	}) {
+		return nil
+	}
+
+	if res.Change == diffChanged || len(f.fields) > 0 {
+		res.Fields = diffFields(oldFields, newFields, f.fields)
+		if len(res.Fields) == 0 {
+			return nil
+		}
+	}
+	return res
+}
+
+// diffFields returns the differences of the fields sorted by path, the filter is the fields with their nested ones
+func diffFields(old, new map[string]interface{}, filter []string) []fieldDiff {
+	var res []fieldDiff
+	add := func(field string) {
+		if len(filter) > 0 && !slices.ContainsFunc(filter, func(f string) bool {
+			return field == f || strings.HasPrefix(field, f+".")
+		}) {
+			return
+		}
+		if oldValue, newValue := old[field], new[field]; !reflect.DeepEqual(oldValue, newValue) {
+			res = append(res, fieldDiff{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	for field := range old {
+		add(field)
+	}
+	for field := range new {
+		if _, ok := old[field]; !ok {
+			add(field)
+		}
+	}
+	slices.SortFunc(res, func(a, b fieldDiff) int { return strings.Compare(a.Field, b.Field) })
+	return res
+}
+
+// flattenRecord maps the paths of the record values to the values, the path of a slice element has its index
+func flattenRecord(record map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+	var flatten func(path string, value interface{})
+	flatten = func(path string, value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, v := range value {
+				flatten(joinFieldPath(path, key), v)
+			}
+		case []interface{}:
+			for i, v := range value {
+				flatten(joinFieldPath(path, strconv.Itoa(i)), v)
+			}
+		default:
+			res[path] = value
+		}
+	}
+	if record != nil {
+		flatten("", record)
+	}
+	return res
+}
+
+func fieldString(fields map[string]interface{}, field string) string {
+	value, _ := fields[field].(string)
+	return value
+}
+
+func joinFieldPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// diffRecord is a network of the db as the range of the addresses
+type diffRecord struct {
+	first, last netip.Addr
+	data        map[string]interface{}
+}
+
+func nextDiffRecord(networks *maxminddb.Networks) (*diffRecord, error) {
+	if !networks.Next() {
+		return nil, networks.Err()
+	}
+	var res diffRecord
+	network, err := networks.Network(&res.data)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ipNetPrefix(network)
+	res.first, res.last = prefix.Addr(), lastAddr(prefix)
+	return &res, nil
+}
+
+// diffNetworks walks the networks of both dbs in the order of the addresses and calls the function
+// for each range of the addresses that has the same records in each db, the missing record is nil
+func diffNetworks(oldNetworks, newNetworks *maxminddb.Networks, f func(first, last netip.Addr, old, new map[string]interface{}) error) error {
+	old, err := nextDiffRecord(oldNetworks)
+	if err != nil {
+		return err
+	}
+	new, err := nextDiffRecord(newNetworks)
+	if err != nil {
+		return err
+	}
+
+	// advance drops the range of the record up to the address, the next record is read if the range is over
+	advance := func(rec *diffRecord, networks *maxminddb.Networks, last netip.Addr) (*diffRecord, error) {
+		if rec.last == last {
+			return nextDiffRecord(networks)
+		}
+		rec.first = last.Next()
+		return rec, nil
+	}
+
+	for old != nil || new != nil {
+		var err error
+		switch {
+		case new == nil || old != nil && old.first.Less(new.first):
+			last := old.last
+			if new != nil && new.first.Compare(last) <= 0 {
+				last = new.first.Prev()
+			}
+			if err := f(old.first, last, old.data, nil); err != nil {
+				return err
+			}
+			old, err = advance(old, oldNetworks, last)
+		case old == nil || new.first.Less(old.first):
+			last := new.last
+			if old != nil && old.first.Compare(last) <= 0 {
+				last = old.first.Prev()
+			}
+			if err := f(new.first, last, nil, new.data); err != nil {
+				return err
+			}
+			new, err = advance(new, newNetworks, last)
+		default:
+			last := old.last
+			if new.last.Less(last) {
+				last = new.last
+			}
+			if err := f(old.first, last, old.data, new.data); err != nil {
+				return err
+			}
+			if old, err = advance(old, oldNetworks, last); err != nil {
+				return err
+			}
+			new, err = advance(new, newNetworks, last)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ipNetPrefix converts the network, the IPv4 networks are 4 bytes
+func ipNetPrefix(network *net.IPNet) netip.Prefix {
+	addr, _ := netip.AddrFromSlice(network.IP)
+	ones, _ := network.Mask.Size()
+	if addr.Is4In6() {
+		addr, ones = addr.Unmap(), max(ones-96, 0)
+	}
+	return netip.PrefixFrom(addr, ones)
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+// rangePrefixes returns the fewest networks of the range
+func rangePrefixes(first, last netip.Addr) []netip.Prefix {
+	var res []netip.Prefix
+	for {
+		prefix := netip.PrefixFrom(first, first.BitLen())
+		for bits := first.BitLen() - 1; bits >= 0; bits-- {
+			candidate := netip.PrefixFrom(first, bits).Masked()
+			if candidate.Addr() != first || lastAddr(candidate).Compare(last) > 0 {
+				break
+			}
+			prefix = candidate
+		}
+		res = append(res, prefix)
+		if end := lastAddr(prefix); end != last {
+			first = end.Next()
+			continue
+		}
+		return res
+	}
+}
+
+func prefixSize(prefix netip.Prefix) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(prefix.Addr().BitLen()-prefix.Bits()))
+}
+
+func formatFieldValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func writeTextDiff(w io.Writer, d *networkDiff) error {
+	sign := map[diffChange]string{diffAdded: "+", diffRemoved: "-", diffChanged: "~"}[d.Change]
+	if _, err := fmt.Fprintf(w, "%s %s %s\n", sign, d.Network, d.Country); err != nil {
+		return err
+	}
+	for _, field := range d.Fields {
+		if _, err := fmt.Fprintf(w, "    %s: %q -> %q\n", field.Field, formatFieldValue(field.Old), formatFieldValue(field.New)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVDiff(w *csv.Writer, d *networkDiff) error {
+	if len(d.Fields) == 0 {
+		return w.Write([]string{d.Network.String(), string(d.Change), d.Country, "", "", ""})
+	}
+	for _, field := range d.Fields {
+		row := []string{d.Network.String(), string(d.Change), d.Country, field.Field, formatFieldValue(field.Old), formatFieldValue(field.New)}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type diffSummary struct {
+	networks  map[diffChange]int
+	addresses map[diffChange]*big.Int
+	// lost and gained are the addresses of the countries, the networks without a country are ""
+	lost, gained map[string]*big.Int
+}
+
+func newDiffSummary() *diffSummary {
+	return &diffSummary{
+		networks:  make(map[diffChange]int),
+		addresses: make(map[diffChange]*big.Int),
+		lost:      make(map[string]*big.Int),
+		gained:    make(map[string]*big.Int),
+	}
+}
+
+func addAddresses[K comparable](m map[K]*big.Int, key K, size *big.Int) {
+	if m[key] == nil {
+		m[key] = new(big.Int)
+	}
+	m[key].Add(m[key], size)
+}
+
+func (s *diffSummary) add(d *networkDiff) {
+	size := prefixSize(d.Network)
+	s.networks[d.Change]++
+	addAddresses(s.addresses, d.Change, size)
+	switch {
+	case d.Change == diffAdded:
+		addAddresses(s.gained, d.Country, size)
+	case d.Change == diffRemoved:
+		addAddresses(s.lost, d.Country, size)
+	case d.Country != d.old:
+		addAddresses(s.lost, d.old, size)
+		addAddresses(s.gained, d.Country, size)
+	}
+}
+
+func (s *diffSummary) write(w io.Writer) error {
+	fmt.Fprintln(w, "summary:")
+	for _, change := range []diffChange{diffAdded, diffRemoved, diffChanged} {
+		addresses := s.addresses[change]
+		if addresses == nil {
+			addresses = new(big.Int)
+		}
+		fmt.Fprintf(w, "  %s: %d networks, %s addresses\n", change, s.networks[change], addresses)
+	}
+
+	countries := make(map[string]bool)
+	for country := range s.lost {
+		countries[country] = true
+	}
+	for country := range s.gained {
+		countries[country] = true
+	}
+	if len(countries) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "  addresses by country (lost, gained):")
+	moved := func(country string) *big.Int {
+		res := new(big.Int)
+		if lost := s.lost[country]; lost != nil {
+			res.Add(res, lost)
+		}
+		if gained := s.gained[country]; gained != nil {
+			res.Add(res, gained)
+		}
+		return res
+	}
+	sorted := make([]string, 0, len(countries))
+	for country := range countries {
+		sorted = append(sorted, country)
+	}
+	slices.SortFunc(sorted, func(a, b string) int {
+		if c := moved(b).Cmp(moved(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	for _, country := range sorted {
+		lost, gained := s.lost[country], s.gained[country]
+		if lost == nil {
+			lost = new(big.Int)
+		}
+		if gained == nil {
+			gained = new(big.Int)
+		}
+		if len(country) == 0 {
+			country = "unknown"
+		}
+		if _, err := fmt.Fprintf(w, "    %s: -%s +%s\n", country, lost, gained); err != nil {
+			return err
+		}
+	}
+	return nil
+}
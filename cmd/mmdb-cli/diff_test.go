@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecord(country, city string) mmdbtype.Map {
+	record := mmdbtype.Map{"country": mmdbtype.Map{"iso_code": mmdbtype.String(country)}}
+	if len(city) > 0 {
+		record["city"] = mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String(city)}}
+	}
+	return record
+}
+
+func newTestNetworks(t *testing.T, records map[string]mmdbtype.Map) *maxminddb.Networks {
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "test", IncludeReservedNetworks: true})
+	require.NoError(t, err)
+	for network, record := range records {
+		_, ipNet, err := net.ParseCIDR(network)
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(ipNet, record))
+	}
+	var buf bytes.Buffer
+	_, err = tree.WriteTo(&buf)
+	require.NoError(t, err)
+	db, err := maxminddb.FromBytes(buf.Bytes())
+	require.NoError(t, err)
+	return db.Networks(maxminddb.SkipAliasedNetworks)
+}
+
+func newTestDiff(t *testing.T) (old, new *maxminddb.Networks) {
+	old = newTestNetworks(t, map[string]mmdbtype.Map{
+		"1.0.0.0/24":     testRecord("BY", "Minsk"),
+		"2.0.0.0/16":     testRecord("PL", ""),
+		"3.0.0.0/24":     testRecord("DE", ""),
+		"5.0.0.0/24":     testRecord("BY", "Minsk"),
+		"6.0.0.0/24":     testRecord("DE", ""),
+		"2001:db8::/32":  testRecord("US", ""),
+		"2001:db9::/126": testRecord("US", ""),
+	})
+	new = newTestNetworks(t, map[string]mmdbtype.Map{
+		"1.0.0.0/24":         testRecord("BY", "Minsk"), // the same
+		"2.0.0.0/17":         testRecord("PL", ""),
+		"2.0.128.0/17":       testRecord("UA", ""),      // a part moved to another country
+		"4.0.0.0/23":         testRecord("FR", ""),      // added
+		"5.0.0.0/24":         testRecord("BY", "Mensk"), // a field changed
+		"6.0.0.64/26":        testRecord("DE", ""),      // a part is left
+		"2001:db8::/33":      testRecord("US", ""),
+		"2001:db8:8000::/33": testRecord("CA", ""),
+		"2001:db9::1/128":    testRecord("US", ""),
+	})
+	return old, new
+}
+
+func runTestDiff(t *testing.T, filter diffFilter) ([]networkDiff, *diffSummary) {
+	old, new := newTestDiff(t)
+	summary := newDiffSummary()
+	var res []networkDiff
+	require.NoError(t, diffDatabases(old, new, filter, summary, func(d *networkDiff) error {
+		res = append(res, *d)
+		return nil
+	}))
+	return res, summary
+}
+
+func TestDiffDatabases(t *testing.T) {
+	diffs, _ := runTestDiff(t, diffFilter{})
+	type change struct {
+		network string
+		change  diffChange
+		country string
+	}
+	var changes []change
+	for _, d := range diffs {
+		changes = append(changes, change{d.Network.String(), d.Change, d.Country})
+	}
+	assert.Equal(t, []change{
+		{"2.0.128.0/17", diffChanged, "UA"},
+		{"3.0.0.0/24", diffRemoved, "DE"},
+		{"4.0.0.0/23", diffAdded, "FR"},
+		{"5.0.0.0/24", diffChanged, "BY"},
+		{"6.0.0.0/26", diffRemoved, "DE"},
+		{"6.0.0.128/25", diffRemoved, "DE"},
+		{"2001:db8:8000::/33", diffChanged, "CA"},
+		{"2001:db9::/128", diffRemoved, "US"},
+		{"2001:db9::2/127", diffRemoved, "US"},
+	}, changes)
+
+	assert.Equal(t, []fieldDiff{{Field: "country.iso_code", Old: "PL", New: "UA"}}, diffs[0].Fields)
+	assert.Equal(t, []fieldDiff{{Field: "city.names.en", Old: "Minsk", New: "Mensk"}}, diffs[3].Fields)
+	assert.Empty(t, diffs[1].Fields, "the fields of the removed networks are reported with the field filter only")
+}
+
+func TestDiffDatabasesFilter(t *testing.T) {
+	networks := func(diffs []networkDiff) (res []string) {
+		for _, d := range diffs {
+			res = append(res, d.Network.String())
+		}
+		return res
+	}
+
+	diffs, _ := runTestDiff(t, diffFilter{countries: []string{"pl"}})
+	assert.Equal(t, []string{"2.0.128.0/17"}, networks(diffs), "the old country matches too, case-insensitively")
+
+	diffs, _ = runTestDiff(t, diffFilter{countries: []string{"DE"}})
+	assert.Equal(t, []string{"3.0.0.0/24", "6.0.0.0/26", "6.0.0.128/25"}, networks(diffs))
+
+	diffs, _ = runTestDiff(t, diffFilter{fields: []string{"city"}})
+	require.Equal(t, []string{"5.0.0.0/24"}, networks(diffs), "the networks without the changes of the nested fields are skipped")
+
+	diffs, _ = runTestDiff(t, diffFilter{countries: []string{"FR"}, fields: []string{"country"}})
+	require.Len(t, diffs, 1)
+	assert.Equal(t, []fieldDiff{{Field: "country.iso_code", New: "FR"}}, diffs[0].Fields, "the fields of the added network with the field filter")
+}
+
+func TestDiffSummary(t *testing.T) {
+	_, summary := runTestDiff(t, diffFilter{})
+	var buf bytes.Buffer
+	require.NoError(t, summary.write(&buf))
+	assert.Equal(t, `summary:
+  added: 1 networks, 512 addresses
+  removed: 5 networks, 451 addresses
+  changed: 3 networks, 39614081257132168796772008192 addresses
+  addresses by country (lost, gained):
+    US: -39614081257132168796771975171 +0
+    CA: -0 +39614081257132168796771975168
+    PL: -32768 +0
+    UA: -0 +32768
+    FR: -0 +512
+    DE: -448 +0
+`, buf.String())
+}
+
+func TestRangePrefixes(t *testing.T) {
+	tests := []struct {
+		first, last string
+		want        []string
+	}{
+		{"1.0.0.0", "1.0.0.255", []string{"1.0.0.0/24"}},
+		{"1.0.0.1", "1.0.0.6", []string{"1.0.0.1/32", "1.0.0.2/31", "1.0.0.4/31", "1.0.0.6/32"}},
+		{"1.0.0.0", "1.0.1.127", []string{"1.0.0.0/24", "1.0.1.0/25"}},
+		{"0.0.0.0", "255.255.255.255", []string{"0.0.0.0/0"}},
+		{"2001:db8::1", "2001:db8::2", []string{"2001:db8::1/128", "2001:db8::2/128"}},
+		{"2001:db8::", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", []string{"2001:db8::/32"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.first+"-"+tt.last, func(t *testing.T) {
+			var got []string
+			for _, prefix := range rangePrefixes(netip.MustParseAddr(tt.first), netip.MustParseAddr(tt.last)) {
+				got = append(got, prefix.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
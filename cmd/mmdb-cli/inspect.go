@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/storage/maxmind"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/urfave/cli/v2"
+)
+
+const inspectDescription = "Several dbs are merged like the merge command does, the later ones are on top."
+
+// openReader opens the dbs merged into one
+func openReader(ctx context.Context, paths []string) (*maxminddb.Reader, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("the db is required")
+	}
+	dbs := make([]maxmind.Database, 0, len(paths))
+	for _, path := range paths {
+		db, err := openDatabase(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		dbs = append(dbs, db)
+	}
+	raw, err := maxmind.NewMultiMaxMindDB(dbs...).RawData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(raw)
+	if err != nil {
+		return nil, err
+	}
+	return maxminddb.FromBytes(content)
+}
+
+// recordResult is the record of the lookup or the range commands
+type recordResult struct {
+	IP      string                 `json:"ip,omitempty"`
+	Network string                 `json:"network,omitempty"`
+	Record  map[string]interface{} `json:"record,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+func writeRecordResult(w io.Writer, res *recordResult, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(w).Encode(res)
+	}
+	header := strings.TrimSpace(res.IP + " " + res.Network)
+	if len(res.Error) > 0 {
+		_, err := fmt.Fprintf(w, "%s: %s\n", header, res.Error)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s:\n", header); err != nil {
+		return err
+	}
+	for _, key := range slices.Sorted(maps.Keys(res.Record)) {
+		if _, err := fmt.Fprintf(w, "  %s: %v\n", key, res.Record[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var jsonFlag = &cli.BoolFlag{
+	Name:  "json",
+	Usage: "print JSON lines",
+}
+
+func lookupCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lookup",
+		Usage:     "print the record of the IP, or of the IPs of stdin line by line",
+		UsageText: "mmdb-cli lookup [--json] db.mmdb... [ip]",
+		Description: `The IPs are read from stdin if the last argument isn't an IP, the IPs without a record are reported and skipped then.
+` + inspectDescription,
+		Flags:  []cli.Flag{jsonFlag},
+		Action: lookup,
+	}
+}
+
+func lookup(ctx *cli.Context) error {
+	paths := ctx.Args().Slice()
+	var ip net.IP
+	if len(paths) > 1 {
+		if ip = net.ParseIP(paths[len(paths)-1]); ip != nil {
+			paths = paths[:len(paths)-1]
+		}
+	}
+	reader, err := openReader(ctx.Context, paths)
+	if err != nil {
+		return err
+	}
+	asJSON := ctx.Bool("json")
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	if ip != nil {
+		res := lookupRecord(reader, ip)
+		if len(res.Error) > 0 {
+			return errors.New(res.Error)
+		}
+		if asJSON {
+			return json.NewEncoder(out).Encode(res.Record)
+		}
+		for _, key := range slices.Sorted(maps.Keys(res.Record)) {
+			if _, err := fmt.Fprintf(out, "%s: %v\n", key, res.Record[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		res := &recordResult{IP: line, Error: "invalid IP"}
+		if ip := net.ParseIP(line); ip != nil {
+			res = lookupRecord(reader, ip)
+		}
+		if err := writeRecordResult(out, res, asJSON); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func lookupRecord(reader *maxminddb.Reader, ip net.IP) *recordResult {
+	res := &recordResult{IP: ip.String()}
+	network, ok, err := reader.LookupNetwork(ip, &res.Record)
+	switch {
+	case err != nil:
+		res.Error = err.Error()
+	case !ok || len(res.Record) == 0:
+		res.Error = "no result found"
+	default:
+		res.Network = network.String()
+	}
+	return res
+}
+
+func rangeCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "range",
+		Usage:       "print the networks within the prefix, or the one that contains it",
+		UsageText:   "mmdb-cli range [--json] db.mmdb... 1.2.3.0/24",
+		Description: inspectDescription,
+		Flags:       []cli.Flag{jsonFlag},
+		Action:      rangeNetworks,
+	}
+}
+
+func rangeNetworks(ctx *cli.Context) error {
+	paths := ctx.Args().Slice()
+	if len(paths) < 2 {
+		return fmt.Errorf("the db and the prefix are required")
+	}
+	_, prefix, err := net.ParseCIDR(paths[len(paths)-1])
+	if err != nil {
+		return err
+	}
+	reader, err := openReader(ctx.Context, paths[:len(paths)-1])
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	networks := reader.NetworksWithin(prefix, maxminddb.SkipAliasedNetworks)
+	for networks.Next() {
+		var res recordResult
+		network, err := networks.Network(&res.Record)
+		if err != nil {
+			return err
+		}
+		res.Network = network.String()
+		if err := writeRecordResult(out, &res, ctx.Bool("json")); err != nil {
+			return err
+		}
+	}
+	return networks.Err()
+}
+
+func verifyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "check the db structure and the metadata",
+		UsageText: `mmdb-cli verify GeoLite2-City.mmdb
+mmdb-cli verify --merged GeoLite2-City.mmdb patch.mmdb`,
+		Description: "Each db is verified. " + inspectDescription,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "merged",
+				Usage: "verify the merged db of several ones too",
+			},
+		},
+		Action: verify,
+	}
+}
+
+func verify(ctx *cli.Context) error {
+	paths := ctx.Args().Slice()
+	if len(paths) == 0 {
+		return fmt.Errorf("the db is required")
+	}
+	failed := 0
+	check := func(name string, paths []string) {
+		err := verifyReader(ctx.Context, paths)
+		if err == nil {
+			fmt.Printf("%s: ok\n", name)
+			return
+		}
+		failed++
+		fmt.Printf("%s: %s\n", name, strings.ReplaceAll(err.Error(), "\n", "\n  "))
+	}
+	for _, path := range paths {
+		check(path, []string{path})
+	}
+	if ctx.Bool("merged") && len(paths) > 1 {
+		check("merged", paths)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of the dbs are invalid", failed)
+	}
+	return nil
+}
+
+func verifyReader(ctx context.Context, paths []string) error {
+	reader, err := openReader(ctx, paths)
+	if err != nil {
+		return err
+	}
+	if len(paths) > 1 {
+		// the metadata of the merged db isn't the metadata of the inputs
+		return reader.Verify()
+	}
+	return errors.Join(reader.Verify(), verifyMetadata(&reader.Metadata))
+}
+
+// verifyMetadata checks the metadata values that Verify accepts but the readers of geos don't expect
+func verifyMetadata(meta *maxminddb.Metadata) error {
+	var errs []error
+	if meta.BinaryFormatMajorVersion != 2 {
+		errs = append(errs, fmt.Errorf("unsupported binary format version %d", meta.BinaryFormatMajorVersion))
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		errs = append(errs, fmt.Errorf("invalid IP version %d", meta.IPVersion))
+	}
+	if !slices.Contains([]uint{24, 28, 32}, meta.RecordSize) {
+		errs = append(errs, fmt.Errorf("invalid record size %d", meta.RecordSize))
+	}
+	if len(meta.DatabaseType) == 0 {
+		errs = append(errs, fmt.Errorf("the database type is empty"))
+	}
+	if meta.NodeCount == 0 {
+		errs = append(errs, fmt.Errorf("the db has no networks"))
+	}
+	switch buildTime := time.Unix(int64(meta.BuildEpoch), 0); {
+	case meta.BuildEpoch == 0:
+		errs = append(errs, fmt.Errorf("the build epoch is empty"))
+	case buildTime.After(time.Now()):
+		errs = append(errs, fmt.Errorf("the build time %s is in the future", buildTime.Format(time.RFC3339)))
+	}
+	return errors.Join(errs...)
+}
+
+func statsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stats",
+		Usage: "print the network count, the IPv4 and IPv6 coverage, the addresses by country and the record sizes",
+		UsageText: `mmdb-cli stats GeoLite2-City.mmdb
+mmdb-cli stats GeoLite2-City.mmdb patch.mmdb`,
+		Description: `The country is country.iso_code. The record size is the size of the encoded record, without the data shared with the other records.
+` + inspectDescription,
+		Action: stats,
+	}
+}
+
+// dbStats are the stats of the networks of an IP version
+type dbStats struct {
+	networks  int
+	addresses *big.Int
+}
+
+func (s *dbStats) add(size *big.Int) {
+	s.networks++
+	s.addresses.Add(s.addresses, size)
+}
+
+func stats(ctx *cli.Context) error {
+	reader, err := openReader(ctx.Context, ctx.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	ipv4 := &dbStats{addresses: new(big.Int)}
+	ipv6 := &dbStats{addresses: new(big.Int)}
+	countries := make(map[string]*[2]dbStats)
+	// recordSizes are the network counts by the record size rounded up to the power of 2
+	recordSizes := make(map[int64]int)
+	var totalRecordSize, maxRecordSize int64
+
+	networks := reader.Networks(maxminddb.SkipAliasedNetworks)
+	for networks.Next() {
+		var record map[string]interface{}
+		network, err := networks.Network(&record)
+		if err != nil {
+			return err
+		}
+		prefix := ipNetPrefix(network)
+		size := prefixSize(prefix)
+
+		country := fieldString(flattenRecord(record), countryField)
+		if countries[country] == nil {
+			countries[country] = &[2]dbStats{{addresses: new(big.Int)}, {addresses: new(big.Int)}}
+		}
+		if prefix.Addr().Is4() {
+			ipv4.add(size)
+			countries[country][0].add(size)
+		} else {
+			ipv6.add(size)
+			countries[country][1].add(size)
+		}
+
+		recordSize, err := encodedSize(maxmind.GenericRecord(record).ToMMDBType())
+		if err != nil {
+			return err
+		}
+		bucket := int64(1)
+		for bucket < recordSize {
+			bucket *= 2
+		}
+		recordSizes[bucket]++
+		totalRecordSize += recordSize
+		maxRecordSize = max(maxRecordSize, recordSize)
+	}
+	if err := networks.Err(); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	total := ipv4.networks + ipv6.networks
+	fmt.Fprintf(out, "networks: %d (IPv4 %d, IPv6 %d)\n", total, ipv4.networks, ipv6.networks)
+	fmt.Fprintf(out, "IPv4 coverage: %s addresses (%s)\n", ipv4.addresses, coverage(ipv4.addresses, 32))
+	fmt.Fprintf(out, "IPv6 coverage: %s addresses (%s)\n", ipv6.addresses, coverage(ipv6.addresses, 128))
+
+	fmt.Fprintln(out, "addresses by country (IPv4 networks, addresses; IPv6 networks, addresses):")
+	sorted := slices.Collect(maps.Keys(countries))
+	slices.SortFunc(sorted, func(a, b string) int {
+		if c := countries[b][0].addresses.Cmp(countries[a][0].addresses); c != 0 {
+			return c
+		}
+		if c := countries[b][1].addresses.Cmp(countries[a][1].addresses); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	for _, country := range sorted {
+		s := countries[country]
+		if len(country) == 0 {
+			country = "-"
+		}
+		fmt.Fprintf(out, "  %s: %d, %s; %d, %s\n", country, s[0].networks, s[0].addresses, s[1].networks, s[1].addresses)
+	}
+
+	fmt.Fprintln(out, "record size, bytes (networks):")
+	for _, bucket := range slices.Sorted(maps.Keys(recordSizes)) {
+		fmt.Fprintf(out, "  <= %d: %d\n", bucket, recordSizes[bucket])
+	}
+	if total > 0 {
+		fmt.Fprintf(out, "  average: %d, max: %d\n", totalRecordSize/int64(total), maxRecordSize)
+	}
+	return nil
+}
+
+// coverage is the percentage of the address space of the bits
+func coverage(addresses *big.Int, bits uint) string {
+	space := new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), bits))
+	percent := new(big.Float).Quo(new(big.Float).SetInt(addresses), space)
+	return percent.Mul(percent, big.NewFloat(100)).Text('f', 4) + "%"
+}
+
+// sizeWriter counts the bytes written by mmdbtype
+type sizeWriter struct {
+	size int64
+}
+
+func (w *sizeWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+	return len(p), nil
+}
+
+func (w *sizeWriter) WriteByte(byte) error {
+	w.size++
+	return nil
+}
+
+func (w *sizeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *sizeWriter) WriteOrWritePointer(value mmdbtype.DataType) (int64, error) {
+	return value.WriteTo(w)
+}
+
+func encodedSize(value mmdbtype.DataType) (int64, error) {
+	var w sizeWriter
+	if _, err := value.WriteTo(&w); err != nil {
+		return 0, err
+	}
+	return w.size, nil
+}
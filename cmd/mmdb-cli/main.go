@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net"
 	"os"
 	"time"
 
@@ -80,32 +79,14 @@ func main() {
 					return nil
 				},
 			},
-			{
-				Name: "lookup",
-				Action: func(ctx *cli.Context) error {
-					dbPath := ctx.Args().Get(0)
-					ip := ctx.Args().Get(1)
-					if ip == "" {
-						return fmt.Errorf("ip is required")
-					}
-					db, err := openDatabase(ctx.Context, dbPath)
-					if err != nil {
-						return err
-					}
-					var res map[string]interface{}
-					err = db.Lookup(ctx.Context, net.ParseIP(ip), &res)
-					if err != nil {
-						return err
-					}
-					if len(res) == 0 {
-						return fmt.Errorf("no result found")
-					}
-					for k, v := range res {
-						fmt.Printf("%s: %v\n", k, v)
-					}
-					return nil
-				},
-			},
+			lookupCommand(),
+			rangeCommand(),
+			verifyCommand(),
+			statsCommand(),
+			analyzePatchesCommand(),
+			diffCommand(),
+			buildCommand(),
+			exportCommand(),
 		},
 	}
 
@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/urfave/cli/v2"
+)
+
+// cityCommands are the non-interactive subcommands of the city command
+func cityCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:      "add",
+			Usage:     "add the record of the GeoNames city to the city patch",
+			UsageText: "patch-gen city add --network 1.2.3.0/24 --geoname-id 625144",
+			Flags: append([]cli.Flag{
+				cityPatchFlag,
+				geonamesPatchFlag,
+				&cli.StringFlag{
+					Name:     "network",
+					Usage:    "CIDR",
+					Required: true,
+				},
+				&cli.Uint64Flag{
+					Name:     "geoname-id",
+					Usage:    "the GeoNames city ID",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "replace",
+					Usage: "replace the record of the network if it's in the patch",
+				},
+			}, recordMetaFlags()...),
+			Action: cityAdd,
+		},
+		{
+			Name:      "import",
+			Usage:     "add the records of the GeoNames cities from CSV or NDJSON to the city patch",
+			UsageText: "patch-gen city import networks.csv",
+			Description: `The CSV has the header: "network" and "geoname_id" are required, "valid_from", "valid_until" and "comment" are optional.
+The NDJSON lines have the same fields, e.g. {"network": "1.2.3.0/24", "geoname_id": 625144, "comment": "office"}.
+The format is detected by the extension: .csv, .ndjson or .jsonl; "-" is CSV from stdin.
+Nothing is written if a record fails.`,
+			Flags: []cli.Flag{
+				cityPatchFlag,
+				geonamesPatchFlag,
+				&cli.BoolFlag{
+					Name:  "replace",
+					Usage: "replace the records of the networks that are in the patch",
+				},
+			},
+			Action: cityImport,
+		},
+		{
+			Name:      "remove",
+			Usage:     "remove the records of the networks from the city patch",
+			UsageText: "patch-gen city remove --network 1.2.3.0/24",
+			Flags: []cli.Flag{
+				cityPatchFlag,
+				&cli.StringSliceFlag{
+					Name:     "network",
+					Usage:    "CIDR, the flag can be repeated",
+					Required: true,
+				},
+			},
+			Action: cityRemove,
+		},
+		{
+			Name:  "list",
+			Usage: "list the records of the city patch",
+			Flags: []cli.Flag{
+				cityPatchFlag,
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "print the records as NDJSON",
+				},
+			},
+			Action: cityList,
+		},
+		lintCommand(),
+	}
+}
+
+// importRecord is the record of the bulk import
+type importRecord struct {
+	Network   string `json:"network"`
+	GeoNameID uint32 `json:"geoname_id"`
+	entity.PatchRecordMeta
+}
+
+func cityAdd(ctx *cli.Context) error {
+	filename := ctx.String("f")
+	currentDB := make(map[string]*entity.CityPatchRecord)
+	if err := readCurrentDB(ctx.Context, filename, &currentDB); err != nil {
+		return err
+	}
+
+	meta, err := recordMeta(ctx)
+	if err != nil {
+		return err
+	}
+	geoNameID, err := parseGeoNameID(ctx.Uint64("geoname-id"))
+	if err != nil {
+		return err
+	}
+	record := importRecord{Network: ctx.String("network"), GeoNameID: geoNameID, PatchRecordMeta: meta}
+
+	source, err := newGeoNameSource(ctx, ctx.String("geonames"))
+	if err != nil {
+		return err
+	}
+	n, err := addCityRecords(ctx.Context, newCityResolver(source), currentDB, []importRecord{record}, ctx.Bool("replace"))
+	if err != nil {
+		return err
+	}
+	if err := writeFile(ctx.Context, filename, currentDB); err != nil {
+		return err
+	}
+	fmt.Printf("%d record added to %s\n", n, filename)
+	return nil
+}
+
+func cityImport(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("the file to import is required")
+	}
+	records, err := readImportRecords(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	filename := ctx.String("f")
+	currentDB := make(map[string]*entity.CityPatchRecord)
+	if err := readCurrentDB(ctx.Context, filename, &currentDB); err != nil {
+		return err
+	}
+
+	source, err := newGeoNameSource(ctx, ctx.String("geonames"))
+	if err != nil {
+		return err
+	}
+	n, err := addCityRecords(ctx.Context, newCityResolver(source), currentDB, records, ctx.Bool("replace"))
+	if err != nil {
+		return err
+	}
+	if err := writeFile(ctx.Context, filename, currentDB); err != nil {
+		return err
+	}
+	fmt.Printf("%d records added to %s\n", n, filename)
+	return nil
+}
+
+// addCityRecords resolves the cities and adds the records to the patch, the patch isn't changed on error
+func addCityRecords(
+	ctx context.Context,
+	resolver *cityResolver,
+	patch map[string]*entity.CityPatchRecord,
+	records []importRecord,
+	replace bool,
+) (int, error) {
+	added := make(map[string]*entity.CityPatchRecord, len(records))
+	for i, record := range records {
+		network, err := parseNetwork(record.Network)
+		if err != nil {
+			return 0, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if _, ok := added[network]; ok {
+			return 0, fmt.Errorf("record %d: duplicate network %s", i+1, network)
+		}
+		if _, ok := patch[network]; ok && !replace {
+			return 0, fmt.Errorf("record %d: %s is already in the patch, use --replace", i+1, network)
+		}
+		if err := record.PatchRecordMeta.Validate(); err != nil {
+			return 0, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		city, err := resolver.City(ctx, record.GeoNameID)
+		if err != nil {
+			return 0, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		added[network] = &entity.CityPatchRecord{City: *city, PatchRecordMeta: record.PatchRecordMeta}
+	}
+	for network, record := range added {
+		patch[network] = record
+	}
+	return len(added), nil
+}
+
+func readImportRecords(path string) ([]importRecord, error) {
+	if path == "-" {
+		return readImportCSV(os.Stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch filepath.Ext(path) {
+	case ".csv":
+		return readImportCSV(file)
+	case ".ndjson", ".jsonl":
+		return readImportNDJSON(file)
+	default:
+		return nil, fmt.Errorf("%s: unknown format, .csv, .ndjson or .jsonl expected", path)
+	}
+}
+
+func readImportCSV(r io.Reader) ([]importRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		switch name {
+		case "network", "geoname_id", "valid_from", "valid_until", "comment":
+			columns[name] = i
+		default:
+			return nil, fmt.Errorf("header: unknown column %q", name)
+		}
+	}
+	for _, name := range []string{"network", "geoname_id"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("header: %q column is required", name)
+		}
+	}
+
+	records := make([]importRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		value := func(name string) string {
+			if column, ok := columns[name]; ok {
+				return row[column]
+			}
+			return ""
+		}
+		record := importRecord{Network: value("network")}
+		geoNameID, err := strconv.ParseUint(value("geoname_id"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: geoname_id: %w", i+2, err)
+		}
+		record.GeoNameID = uint32(geoNameID)
+		if record.ValidFrom, err = parseOptionalTime(value("valid_from")); err != nil {
+			return nil, fmt.Errorf("row %d: valid_from: %w", i+2, err)
+		}
+		if record.ValidUntil, err = parseOptionalTime(value("valid_until")); err != nil {
+			return nil, fmt.Errorf("row %d: valid_until: %w", i+2, err)
+		}
+		record.Comment = value("comment")
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func readImportNDJSON(r io.Reader) ([]importRecord, error) {
+	var records []importRecord
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(strings.NewReader(scanner.Text()))
+		dec.DisallowUnknownFields()
+		var record importRecord
+		if err := dec.Decode(&record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+func cityRemove(ctx *cli.Context) error {
+	filename := ctx.String("f")
+	currentDB := make(map[string]*entity.CityPatchRecord)
+	if err := readExistingDB(filename, &currentDB); err != nil {
+		return err
+	}
+
+	var networks []string
+	for _, value := range ctx.StringSlice("network") {
+		network, err := parseNetwork(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := currentDB[network]; !ok {
+			return fmt.Errorf("%s isn't in the patch", network)
+		}
+		networks = append(networks, network)
+	}
+	for _, network := range networks {
+		delete(currentDB, network)
+	}
+	if err := writeFile(ctx.Context, filename, currentDB); err != nil {
+		return err
+	}
+	fmt.Printf("%d records removed from %s\n", len(networks), filename)
+	return nil
+}
+
+func cityList(ctx *cli.Context) error {
+	currentDB := make(map[string]*entity.CityPatchRecord)
+	if err := readExistingDB(ctx.String("f"), &currentDB); err != nil {
+		return err
+	}
+	networks := sortedNetworks(currentDB)
+
+	if ctx.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+		for _, network := range networks {
+			record := struct {
+				Network string `json:"network"`
+				*entity.CityPatchRecord
+			}{network, currentDB[network]}
+			if err := enc.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NETWORK\tCITY\tCOUNTRY\tVALID FROM\tVALID UNTIL\tCOMMENT")
+	for _, network := range networks {
+		record := currentDB[network]
+		fmt.Fprintf(w, "%s\t%s (%d)\t%s\t%s\t%s\t%s\n",
+			network,
+			record.City.City.Names["en"], record.City.City.GeoNameID,
+			record.Country.IsoCode,
+			formatOptionalTime(record.ValidFrom),
+			formatOptionalTime(record.ValidUntil),
+			record.Comment,
+		)
+	}
+	return w.Flush()
+}
+
+// readExistingDB is readCurrentDB that doesn't create the file
+func readExistingDB[T any](filename string, out T) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// parseNetwork returns the network in the canonical form, the form of the patch keys
+func parseNetwork(s string) (string, error) {
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return "", err
+	}
+	return network.String(), nil
+}
+
+func parseGeoNameID(id uint64) (uint32, error) {
+	if id == 0 || id > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("invalid geoname ID %d", id)
+	}
+	return uint32(id), nil
+}
+
+func parseOptionalTime(s string) (*time.Time, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// sortedNetworks sorts the networks of the patch, see compareNetworks
+func sortedNetworks[T any](patch map[string]T) []string {
+	networks := make([]string, 0, len(patch))
+	for network := range patch {
+		networks = append(networks, network)
+	}
+	slices.SortFunc(networks, compareNetworks)
+	return networks
+}
+
+// compareNetworks orders the networks by the address, then by the size, the invalid ones are the last
+func compareNetworks(a, b string) int {
+	aPrefix, aErr := netip.ParsePrefix(a)
+	bPrefix, bErr := netip.ParsePrefix(b)
+	switch {
+	case aErr != nil && bErr != nil:
+		return strings.Compare(a, b)
+	case aErr != nil:
+		return 1
+	case bErr != nil:
+		return -1
+	}
+	if c := aPrefix.Addr().Compare(bPrefix.Addr()); c != 0 {
+		return c
+	}
+	return aPrefix.Bits() - bPrefix.Bits()
+}
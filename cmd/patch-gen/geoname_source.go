@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/bldsoft/geos/pkg/client"
+	rest_client "github.com/bldsoft/geos/pkg/client/rest"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/geonames"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// geoNameSource resolves the GeoNames entities: the local dump with the patch or a running geos
+type geoNameSource interface {
+	geonames.Storage
+	GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error)
+}
+
+// newGeoNameSource uses the geos of the --geos flag if it's set, otherwise the local dump patched by the custom file
+func newGeoNameSource(ctx *cli.Context, customFilePath string) (geoNameSource, error) {
+	addr := ctx.String("geos")
+	if len(addr) == 0 {
+		return geonamesStorage(ctx.Context, ctx.String("geonames-dir"), customFilePath), nil
+	}
+	var opts []client.Opt
+	if apiKey := ctx.String("api-key"); len(apiKey) > 0 {
+		opts = append(opts, client.WithApiKey(apiKey))
+	}
+	geos, err := client.NewClientWithOpt([]string{addr}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("geos %s: %w", addr, err)
+	}
+	return geoNameClientSource{geos}, nil
+}
+
+// geoNameClientSource is the geoNameSource of a running geos, its GeoNames are already patched
+type geoNameClientSource struct {
+	client client.GeoNameClient
+}
+
+func (s geoNameClientSource) Continents(ctx context.Context) []*entity.GeoNameContinent {
+	return s.client.GeoNameContinents(ctx)
+}
+
+func (s geoNameClientSource) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+	return s.client.GeoNameCountries(ctx, filter)
+}
+
+func (s geoNameClientSource) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+	return s.client.GeoNameSubdivisions(ctx, filter)
+}
+
+func (s geoNameClientSource) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+	return s.client.GeoNameCities(ctx, filter)
+}
+
+func (s geoNameClientSource) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return s.client.GeoName(ctx, geoNameID, filter)
+}
+
+// isNotFound checks the not found errors of the local storage and the clients
+func isNotFound(err error) bool {
+	var respErr *rest_client.RespError
+	return errors.Is(err, utils.ErrNotFound) ||
+		errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound ||
+		status.Code(err) == codes.NotFound
+}
+
+// cityResolver builds the city records by the GeoNames city IDs, the records are cached for the bulk import
+type cityResolver struct {
+	source geoNameSource
+	cities map[uint32]*entity.City
+}
+
+func newCityResolver(source geoNameSource) *cityResolver {
+	return &cityResolver{source: source, cities: make(map[uint32]*entity.City)}
+}
+
+func (r *cityResolver) City(ctx context.Context, geoNameID uint32) (*entity.City, error) {
+	if city, ok := r.cities[geoNameID]; ok {
+		return city, nil
+	}
+
+	cities, err := r.source.Cities(ctx, entity.GeoNameFilter{GeoNameIDs: []uint32{geoNameID}, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(cities) == 0 {
+		return nil, fmt.Errorf("city %d: %w", geoNameID, utils.ErrNotFound)
+	}
+	city := cities[0]
+
+	countries, err := r.source.Countries(ctx, entity.GeoNameFilter{CountryCodes: []string{city.GetCountryCode()}})
+	if err != nil {
+		return nil, err
+	}
+	var country *entity.GeoNameCountry
+	for _, c := range countries {
+		if c.GetCountryCode() == city.GetCountryCode() {
+			country = c
+		}
+	}
+	if country == nil {
+		return nil, fmt.Errorf("city %d: country %s: %w", geoNameID, city.GetCountryCode(), utils.ErrNotFound)
+	}
+
+	continent := getContinent(ctx, r.source, country)
+	if continent == nil {
+		return nil, fmt.Errorf("city %d: continent %s: %w", geoNameID, country.Continent, utils.ErrNotFound)
+	}
+
+	subdivisions, err := getSubdivisions(ctx, r.source, city)
+	if err != nil {
+		return nil, err
+	}
+
+	res := buildDBCity(continent, country, subdivisions, city)
+	r.cities[geoNameID] = res
+	return res, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/urfave/cli/v2"
+)
+
+type lintLevel string
+
+const (
+	lintError   lintLevel = "error"
+	lintWarning lintLevel = "warning"
+)
+
+type lintIssue struct {
+	level   lintLevel
+	network string
+	message string
+}
+
+func lintCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lint",
+		Usage: "check the city patch",
+		Description: `Errors: invalid networks and validity periods, the GeoNames IDs that aren't in GeoNames, invalid coordinates.
+Warnings: overlapping networks (the smaller one wins), not canonical networks, expired records.
+The exit code is 1 if there are errors, or warnings with --strict.`,
+		Flags: []cli.Flag{
+			cityPatchFlag,
+			geonamesPatchFlag,
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "fail on warnings",
+			},
+		},
+		Action: cityLint,
+	}
+}
+
+func cityLint(ctx *cli.Context) error {
+	filename := ctx.String("f")
+	currentDB := make(map[string]*entity.CityPatchRecord)
+	if err := readExistingDB(filename, &currentDB); err != nil {
+		return err
+	}
+	source, err := newGeoNameSource(ctx, ctx.String("geonames"))
+	if err != nil {
+		return err
+	}
+
+	issues, err := lintCityPatch(ctx.Context, source, currentDB)
+	if err != nil {
+		return err
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, issue := range issues {
+		fmt.Printf("%s %s: %s\n", issue.level, issue.network, issue.message)
+		if issue.level == lintError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+	fmt.Printf("%s: %d records, %d errors, %d warnings\n", filename, len(currentDB), errorCount, warningCount)
+	if errorCount > 0 || ctx.Bool("strict") && warningCount > 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func lintCityPatch(ctx context.Context, source geoNameSource, patch map[string]*entity.CityPatchRecord) ([]lintIssue, error) {
+	var issues []lintIssue
+	add := func(level lintLevel, network, format string, args ...any) {
+		issues = append(issues, lintIssue{level, network, fmt.Sprintf(format, args...)})
+	}
+
+	checker := &geoNameChecker{source: source, cache: make(map[geoNameKey]bool)}
+	now := time.Now()
+	var prefixes []netip.Prefix
+	for _, network := range sortedNetworks(patch) {
+		record := patch[network]
+
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			add(lintError, network, "invalid network: %v", err)
+		} else {
+			if prefix.Masked() != prefix {
+				add(lintWarning, network, "not canonical, %s is used", prefix.Masked())
+			}
+			prefixes = append(prefixes, prefix.Masked())
+		}
+
+		if err := record.PatchRecordMeta.Validate(); err != nil {
+			add(lintError, network, "%v", err)
+		} else if record.ValidUntil != nil && !now.Before(*record.ValidUntil) {
+			add(lintWarning, network, "expired at %s", record.ValidUntil.Format(time.RFC3339))
+		}
+
+		if lat := record.Location.Latitude; lat < -90 || lat > 90 {
+			add(lintError, network, "invalid latitude %v", lat)
+		}
+		if lon := record.Location.Longitude; lon < -180 || lon > 180 {
+			add(lintError, network, "invalid longitude %v", lon)
+		}
+
+		ids := []geoNameRef{
+			{"city", record.City.City.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeCity}},
+			{"country", record.Country.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeCountry}},
+			{"registered country", record.RegisteredCountry.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeCountry}},
+			{"represented country", record.RepresentedCountry.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeCountry}},
+			{"continent", record.Continent.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeContinent}},
+		}
+		for _, subdivision := range record.Subdivisions {
+			ids = append(ids, geoNameRef{"subdivision", subdivision.GeoNameID, []entity.GeoNameType{entity.GeoNameTypeSubdivision, entity.GeoNameTypeSubdivision2}})
+		}
+		for _, id := range ids {
+			if id.geoNameID == 0 {
+				continue
+			}
+			known, err := checker.known(ctx, uint32(id.geoNameID), id.types...)
+			if err != nil {
+				return nil, err
+			}
+			if !known {
+				add(lintError, network, "the %s %d isn't in GeoNames", id.field, id.geoNameID)
+			}
+		}
+	}
+
+	// the prefixes are sorted by the address, then by the size: a network overlaps the enclosing ones on the stack
+	var enclosing []netip.Prefix
+	for _, prefix := range prefixes {
+		for len(enclosing) > 0 && !enclosing[len(enclosing)-1].Contains(prefix.Addr()) {
+			enclosing = enclosing[:len(enclosing)-1]
+		}
+		if len(enclosing) > 0 {
+			add(lintWarning, prefix.String(), "overlaps %s", enclosing[len(enclosing)-1])
+		}
+		enclosing = append(enclosing, prefix)
+	}
+	slices.SortStableFunc(issues, func(a, b lintIssue) int {
+		return compareNetworks(a.network, b.network)
+	})
+	return issues, nil
+}
+
+// geoNameRef is a GeoNames ID of the record field, the types are the acceptable ones
+type geoNameRef struct {
+	field     string
+	geoNameID uint
+	types     []entity.GeoNameType
+}
+
+type geoNameKey struct {
+	geoNameID uint32
+	typ       entity.GeoNameType
+}
+
+// geoNameChecker looks up each GeoNames entity once
+type geoNameChecker struct {
+	source geoNameSource
+	cache  map[geoNameKey]bool
+}
+
+// known reports whether the ID is in GeoNames as one of the types
+func (c *geoNameChecker) known(ctx context.Context, geoNameID uint32, types ...entity.GeoNameType) (bool, error) {
+	for _, typ := range types {
+		key := geoNameKey{geoNameID, typ}
+		known, ok := c.cache[key]
+		if !ok {
+			_, err := c.source.GeoName(ctx, geoNameID, entity.GeoNameHierarchyFilter{Type: typ})
+			if err != nil && !isNotFound(err) {
+				return false, err
+			}
+			known = err == nil
+			c.cache[key] = known
+		}
+		if known {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/storage/geonames"
@@ -20,8 +21,6 @@ import (
 	"github.com/manifoldco/promptui"
 )
 
-const FirstCustomGeonameID = 1_000_000_000
-
 var ErrGeonameIDAlreadyInUse = fmt.Errorf("geoname already in use")
 
 var selectTemplates = &promptui.SelectTemplates{
@@ -35,29 +34,47 @@ func main() {
 		Name:                 "patch-gen",
 		Usage:                "GEOS DB patch generator",
 		EnableBashCompletion: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "geonames-dir",
+				Usage: "the directory of the local GeoNames dump, it's downloaded if missing",
+				Value: "/tmp/",
+			},
+			&cli.StringFlag{
+				Name:  "geos",
+				Usage: "the address of a running geos to resolve GeoNames instead of the local dump",
+			},
+			&cli.StringFlag{
+				Name:    "api-key",
+				Usage:   "the API key of the geos",
+				EnvVars: []string{"GEOS_API_KEY"},
+			},
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "city",
 				Usage: "add record to custom city db file",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:  "f",
-						Value: "city_custom.json",
-					},
-					&cli.StringFlag{
-						Name:    "geonames",
-						Value:   "geonames_custom.json",
-						Aliases: []string{"geonames-patch"},
-					},
-				},
+				Flags: append([]cli.Flag{
+					cityPatchFlag,
+					geonamesPatchFlag,
+				}, recordMetaFlags()...),
+				Subcommands: cityCommands(),
 				Action: func(ctx *cli.Context) error {
 					filename := ctx.String("f")
-					currentDB := make(map[string]*entity.City)
+					currentDB := make(map[string]*entity.CityPatchRecord)
 					if err := readCurrentDB(ctx.Context, filename, &currentDB); err != nil {
 						return err
 					}
 
-					geonameStorage := geonamesStorage(ctx.Context, ctx.String("geonames"))
+					meta, err := recordMeta(ctx)
+					if err != nil {
+						return err
+					}
+
+					geonameStorage, err := newGeoNameSource(ctx, ctx.String("geonames"))
+					if err != nil {
+						return err
+					}
 
 					network, err := promptNetwork(ctx.Context)
 					if err != nil {
@@ -71,9 +88,14 @@ func main() {
 						return err
 					}
 
+					record := &entity.CityPatchRecord{
+						City:            *dbCity,
+						PatchRecordMeta: meta,
+					}
+
 					// log
-					maxmindRecord := map[string]*entity.City{
-						network: dbCity,
+					maxmindRecord := map[string]*entity.CityPatchRecord{
+						network: record,
 					}
 					data, err := json.MarshalIndent(maxmindRecord, "", "    ")
 					if err != nil {
@@ -86,7 +108,7 @@ func main() {
 						return nil
 					}
 
-					currentDB[network] = dbCity
+					currentDB[network] = record
 					return writeFile(ctx.Context, filename, currentDB)
 				},
 			},
@@ -110,7 +132,10 @@ func main() {
 						return err
 					}
 
-					geonameStorage := geonamesStorage(ctx.Context, filename)
+					geonameStorage, err := newGeoNameSource(ctx, filename)
+					if err != nil {
+						return err
+					}
 
 					geoname := ctx.Args().First()
 					geonameID := ctx.Uint64("id")
@@ -150,6 +175,7 @@ func main() {
 					return writeFile(ctx.Context, filename, records)
 				},
 			},
+			packCommand(),
 		},
 	}
 
@@ -158,8 +184,49 @@ func main() {
 	}
 }
 
-func geonamesStorage(ctx context.Context, customFilePath string) geonames.Storage {
-	origStorage := source.NewGeoNamesSource("/tmp/")
+var cityPatchFlag = &cli.StringFlag{
+	Name:  "f",
+	Usage: "the city patch file",
+	Value: "city_custom.json",
+}
+
+var geonamesPatchFlag = &cli.StringFlag{
+	Name:    "geonames",
+	Usage:   "the GeoNames patch file, it's used with the local dump only",
+	Value:   "geonames_custom.json",
+	Aliases: []string{"geonames-patch"},
+}
+
+func recordMetaFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.TimestampFlag{
+			Name:   "valid-from",
+			Usage:  "the record is ignored before the time, RFC 3339",
+			Layout: time.RFC3339,
+		},
+		&cli.TimestampFlag{
+			Name:   "valid-until",
+			Usage:  "the record is ignored since the time, RFC 3339",
+			Layout: time.RFC3339,
+		},
+		&cli.StringFlag{
+			Name:  "comment",
+			Usage: "the comment of the record",
+		},
+	}
+}
+
+func recordMeta(ctx *cli.Context) (entity.PatchRecordMeta, error) {
+	meta := entity.PatchRecordMeta{
+		ValidFrom:  ctx.Timestamp("valid-from"),
+		ValidUntil: ctx.Timestamp("valid-until"),
+		Comment:    ctx.String("comment"),
+	}
+	return meta, meta.Validate()
+}
+
+func geonamesStorage(ctx context.Context, dumpDir, customFilePath string) *geonames.PatchedStorage {
+	origStorage := source.NewGeoNamesSource(dumpDir)
 	originalStorage := geonames.NewStorage(ctx, origStorage, true)
 	geonameStorage := geonames.NewPatchedStorage(originalStorage)
 
@@ -207,7 +274,7 @@ func isGeoNameIDAlradyInUse(ctx context.Context, storage geonames.Storage, id ui
 }
 
 func generateGeonameID(records []geonames.CustomGeonamesRecord) uint64 {
-	geonameID := uint64(FirstCustomGeonameID)
+	geonameID := uint64(entity.FirstCustomGeonameID)
 	for _, rec := range records {
 		geonameID = max(geonameID, uint64(rec.City.GeoNameID)+1)
 	}
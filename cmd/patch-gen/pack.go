@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/geonames"
+	"github.com/bldsoft/geos/pkg/storage/maxmind"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func packCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "pack",
+		Usage:     "check the patch files and pack them to the .tar.gz archive for the patches source",
+		UsageText: "patch-gen pack --db city -o city_patches.tar.gz city_custom.json office.csv",
+		Description: `The GeoIP patches are .json, .csv or .mmdb, the GeoNames patches are .json.
+The files are stored by their base names, so the names must be unique.
+The GeoIP patches are applied in the order of the names, the record of the later file wins.
+With --manifest the order is the order of the arguments, it's written to manifest.json of the archive.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "o",
+				Aliases: []string{"output"},
+				Usage:   "the archive",
+				Value:   "patches.tar.gz",
+			},
+			&cli.StringFlag{
+				Name:  "db",
+				Usage: "the database of the patches: city, isp, hosting or geonames",
+				Value: "city",
+			},
+			&cli.BoolFlag{
+				Name:  "manifest",
+				Usage: "apply the GeoIP patches in the order of the arguments",
+			},
+		},
+		Action: pack,
+	}
+}
+
+func pack(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("the patch files are required")
+	}
+	check, err := patchChecker(ctx.String("db"))
+	if err != nil {
+		return err
+	}
+
+	withManifest := ctx.Bool("manifest")
+	if withManifest && ctx.String("db") == "geonames" {
+		return fmt.Errorf("the manifest is for the GeoIP patches only")
+	}
+
+	files := make(map[string][]byte, ctx.NArg())
+	var manifest maxmind.PatchManifest
+	for _, path := range ctx.Args().Slice() {
+		name := filepath.Base(path)
+		if _, ok := files[name]; ok {
+			return fmt.Errorf("%s: duplicate file name %s", path, name)
+		}
+		if name == maxmind.PatchManifestName {
+			return fmt.Errorf("%s: %s is reserved for the manifest, use --manifest", path, name)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := check(name, content); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		files[name] = content
+		manifest.Files = append(manifest.Files, name)
+	}
+	if withManifest {
+		content, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		files[maxmind.PatchManifestName] = content
+	}
+
+	var buf bytes.Buffer
+	if err := utils.PackTarGz(&buf, files); err != nil {
+		return err
+	}
+	output := ctx.String("o")
+	if err := os.WriteFile(output, buf.Bytes(), 0666); err != nil {
+		return err
+	}
+	fmt.Printf("%d files packed to %s\n", len(manifest.Files), output)
+	return nil
+}
+
+// patchChecker reads the patch the way geos does
+func patchChecker(db string) (func(name string, content []byte) error, error) {
+	switch db {
+	case "city":
+		return checkGeoIPPatch[entity.City], nil
+	case "isp":
+		return checkGeoIPPatch[entity.ISP], nil
+	case "hosting":
+		return checkGeoIPPatch[entity.Hosting], nil
+	case "geonames":
+		return func(name string, content []byte) error {
+			if filepath.Ext(name) != ".json" {
+				return utils.ErrUnknownFormat
+			}
+			var records []geonames.CustomGeonamesRecord
+			return json.Unmarshal(content, &records)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown db %q", db)
+	}
+}
+
+func checkGeoIPPatch[T maxmind.PatchEntity](name string, content []byte) error {
+	_, err := maxmind.NewDatabasePatchFromContent[T](name, content)
+	return err
+}
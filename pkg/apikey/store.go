@@ -0,0 +1,225 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/bldsoft/gost/log"
+)
+
+// LegacyKeyID is the ID of the key set by API_KEY
+const LegacyKeyID = "default"
+
+type Config struct {
+	Key          string // single key with all scopes
+	Keys         string // JSON array of keys
+	FilePath     string // JSON file with the array of keys, it's reloaded on change
+	ReloadPeriod time.Duration
+}
+
+type usage struct {
+	requests atomic.Uint64
+	denied   atomic.Uint64
+	lastUsed atomic.Int64
+}
+
+type keySet struct {
+	keys   []*entity.ApiKey
+	byHash map[[sha256.Size]byte]*entity.ApiKey
+}
+
+// Store holds API keys loaded from the env and from the keys file.
+// The request counters are kept by key ID, so they survive key reloads.
+type Store struct {
+	config  Config
+	keys    atomic.Pointer[keySet]
+	usage   sync.Map // key ID -> *usage
+	modTime time.Time
+}
+
+func NewStore(config Config) (*Store, error) {
+	s := &Store{config: config}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Enabled reports whether at least one key is configured.
+func (s *Store) Enabled() bool {
+	return len(s.keys.Load().keys) != 0
+}
+
+func (s *Store) load() error {
+	var keys []*entity.ApiKey
+	if len(s.config.Key) != 0 {
+		keys = append(keys, &entity.ApiKey{
+			ID:     LegacyKeyID,
+			Key:    s.config.Key,
+			Scopes: entity.ApiKeyScopes,
+		})
+	}
+
+	if len(s.config.Keys) != 0 {
+		var envKeys []*entity.ApiKey
+		if err := json.Unmarshal([]byte(s.config.Keys), &envKeys); err != nil {
+			return fmt.Errorf("API_KEYS: %w", err)
+		}
+		keys = append(keys, envKeys...)
+	}
+
+	if len(s.config.FilePath) != 0 {
+		info, err := os.Stat(s.config.FilePath)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(s.config.FilePath)
+		if err != nil {
+			return err
+		}
+		var fileKeys []*entity.ApiKey
+		if err := json.Unmarshal(data, &fileKeys); err != nil {
+			return fmt.Errorf("%s: %w", s.config.FilePath, err)
+		}
+		keys = append(keys, fileKeys...)
+		s.modTime = info.ModTime()
+	}
+
+	set, err := newKeySet(keys)
+	if err != nil {
+		return err
+	}
+	s.keys.Store(set)
+	return nil
+}
+
+func newKeySet(keys []*entity.ApiKey) (*keySet, error) {
+	set := &keySet{
+		keys:   keys,
+		byHash: make(map[[sha256.Size]byte]*entity.ApiKey, len(keys)),
+	}
+	ids := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if len(key.ID) == 0 || len(key.Key) == 0 {
+			return nil, fmt.Errorf("api key: id and key are required")
+		}
+		if _, ok := ids[key.ID]; ok {
+			return nil, fmt.Errorf("api key %s: duplicate id", key.ID)
+		}
+		ids[key.ID] = struct{}{}
+
+		for _, scope := range key.Scopes {
+			if !slices.Contains(entity.ApiKeyScopes, scope) {
+				return nil, fmt.Errorf("api key %s: unknown scope %q", key.ID, scope)
+			}
+		}
+		for scope := range key.RateLimits {
+			if !slices.Contains(entity.ApiKeyScopes, scope) {
+				return nil, fmt.Errorf("api key %s: rate limit: unknown scope %q", key.ID, scope)
+			}
+		}
+
+		hash := sha256.Sum256([]byte(key.Key))
+		if _, ok := set.byHash[hash]; ok {
+			return nil, fmt.Errorf("api key %s: duplicate key", key.ID)
+		}
+		set.byHash[hash] = key
+	}
+	return set, nil
+}
+
+// Run reloads the keys file when it's modified.
+func (s *Store) Run(ctx context.Context) error {
+	if len(s.config.FilePath) == 0 || s.config.ReloadPeriod <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.config.ReloadPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			info, err := os.Stat(s.config.FilePath)
+			if err != nil {
+				log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "failed to check api keys file")
+				continue
+			}
+			if info.ModTime().Equal(s.modTime) {
+				continue
+			}
+			if err := s.load(); err != nil {
+				log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "failed to reload api keys, the previous ones are kept")
+				continue
+			}
+			log.FromContext(ctx).Infof("api keys reloaded")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Authorize checks that the key exists, isn't expired and has the scope.
+func (s *Store) Authorize(key string, scope entity.ApiKeyScope) (*entity.ApiKey, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("api key is required: %w", utils.ErrUnauthorized)
+	}
+	apiKey := s.keys.Load().byHash[sha256.Sum256([]byte(key))]
+	if apiKey == nil {
+		return nil, fmt.Errorf("unknown api key: %w", utils.ErrUnauthorized)
+	}
+
+	u := s.usageOf(apiKey.ID)
+	now := time.Now()
+	switch {
+	case apiKey.Expired(now):
+		u.denied.Add(1)
+		return nil, fmt.Errorf("api key %s has expired: %w", apiKey.ID, utils.ErrUnauthorized)
+	case !apiKey.HasScope(scope):
+		u.denied.Add(1)
+		return nil, fmt.Errorf("api key %s: scope %s: %w", apiKey.ID, scope, utils.ErrForbidden)
+	}
+	u.requests.Add(1)
+	u.lastUsed.Store(now.UnixNano())
+	return apiKey, nil
+}
+
+func (s *Store) usageOf(id string) *usage {
+	u, _ := s.usage.LoadOrStore(id, &usage{})
+	return u.(*usage)
+}
+
+func (s *Store) Usage() []entity.ApiKeyUsage {
+	keys := s.keys.Load().keys
+	res := make([]entity.ApiKeyUsage, 0, len(keys))
+	for _, key := range keys {
+		u := s.usageOf(key.ID)
+		keyUsage := entity.ApiKeyUsage{
+			ID:         key.ID,
+			Name:       key.Name,
+			Scopes:     key.Scopes,
+			ExpiresAt:  key.ExpiresAt,
+			RateLimits: key.RateLimits,
+			Requests:   u.requests.Load(),
+			Denied:     u.denied.Load(),
+		}
+		if lastUsed := u.lastUsed.Load(); lastUsed != 0 {
+			t := time.Unix(0, lastUsed)
+			keyUsage.LastUsedAt = &t
+		}
+		res = append(res, keyUsage)
+	}
+	slices.SortFunc(res, func(a, b entity.ApiKeyUsage) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return res
+}
@@ -2,22 +2,78 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"time"
 
 	grpc_client "github.com/bldsoft/geos/pkg/client/grpc"
 	rest_client "github.com/bldsoft/geos/pkg/client/rest"
+	"google.golang.org/grpc"
 )
 
-type Opt func(Client)
+type options struct {
+	apiKey string
+	tls    *tls.Config
+}
+
+type Opt func(*options)
 
 func WithApiKey(apiKey string) Opt {
-	return func(c Client) {
-		restClient, ok := c.(*rest_client.Client)
-		if ok {
-			restClient.SetApiKey(apiKey)
-		}
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithTLS makes the clients connect over TLS. For mTLS, set the client certificate in the config.
+func WithTLS(config *tls.Config) Opt {
+	return func(o *options) {
+		o.tls = config
+	}
+}
+
+func newOptions(opts []Opt) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// TLSEnabled reports whether the options contain WithTLS
+func TLSEnabled(opts ...Opt) bool {
+	return newOptions(opts).tls != nil
+}
+
+func NewGrpcClient(addr string, opts ...Opt) (*grpc_client.Client, error) {
+	o := newOptions(opts)
+	var dialOpts []grpc.DialOption
+	if o.tls != nil {
+		dialOpts = append(dialOpts, grpc_client.WithTLS(o.tls))
 	}
+	client, err := grpc_client.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.apiKey) != 0 {
+		client.SetApiKey(o.apiKey)
+	}
+	return client, nil
+}
+
+func NewRestClient(addr string, opts ...Opt) (*rest_client.Client, error) {
+	o := newOptions(opts)
+	var restOpts []rest_client.Option
+	if o.tls != nil {
+		restOpts = append(restOpts, rest_client.WithTLS(o.tls))
+	}
+	client, err := rest_client.NewClient(addr, restOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.apiKey) != 0 {
+		client.SetApiKey(o.apiKey)
+	}
+	return client, nil
 }
 
 func NewClientWithOpt(addrs []string, opts ...Opt) (Client, error) {
@@ -25,10 +81,7 @@ func NewClientWithOpt(addrs []string, opts ...Opt) (Client, error) {
 	var multiErr error
 
 	for _, addr := range addrs {
-		if client, err := newClient(addr, ""); err == nil {
-			for _, opt := range opts {
-				opt(client)
-			}
+		if client, err := newClient(addr, opts); err == nil {
 			res.Clients = append(res.Clients, client)
 		} else {
 			multiErr = errors.Join(multiErr, err)
@@ -46,12 +99,12 @@ func NewClient(addrs ...string) (Client, error) {
 	return NewClientWithOpt(addrs)
 }
 
-func newClient(addr string, apiKey string) (Client, error) {
+func newClient(addr string, opts []Opt) (Client, error) {
 	grpcClient := func(addr string) (Client, error) {
-		return grpc_client.NewClient(addr)
+		return NewGrpcClient(addr, opts...)
 	}
 	restClient := func(addr string) (Client, error) {
-		return rest_client.NewClient(addr)
+		return NewRestClient(addr, opts...)
 	}
 
 	var multiErr error
@@ -4,7 +4,6 @@ import (
 	"errors"
 
 	"github.com/bldsoft/geos/pkg/client"
-	grpc_client "github.com/bldsoft/geos/pkg/client/grpc"
 	"github.com/bldsoft/geos/pkg/config"
 	"github.com/bldsoft/geos/pkg/microservice"
 	"github.com/bldsoft/gost/discovery"
@@ -12,19 +11,27 @@ import (
 
 var ErrServiceNotFound = errors.New("service not found")
 var ErrGRPCDisabled = errors.New("grpc is disabled")
+var ErrTLSRequired = errors.New("the service requires tls, use client.WithTLS")
+var ErrTLSDisabled = errors.New("tls is disabled")
 
-func NewClient(d discovery.Discovery) client.Client {
-	return &client.MultiClient{Clients: []client.Client{NewGrpcClient(d), NewRestClient(d)}}
+// NewClient creates a client that uses gRPC if it's enabled, otherwise REST.
+// The API key isn't published to discovery, use client.WithApiKey to set it.
+// With client.WithTLS, the REST client uses the HTTPS address of the service.
+func NewClient(d discovery.Discovery, opts ...client.Opt) client.Client {
+	return &client.MultiClient{Clients: []client.Client{NewGrpcClient(d, opts...), NewRestClient(d, opts...)}}
 }
 
-func NewGrpcClient(d discovery.Discovery) client.Client {
+func NewGrpcClient(d discovery.Discovery, opts ...client.Opt) client.Client {
 	return &discoveredClient{
 		clientLoader: newLoader[client.Client](
 			config.ServiceName,
 			d,
 			func(info discovery.ServiceInstanceInfo) (client.Client, error) {
 				if grpcAddr := info.Meta[microservice.GrpcAddressMetaKey]; grpcAddr != "" {
-					return grpc_client.NewClient(grpcAddr)
+					if info.Meta[microservice.GrpcTLSMetaKey] == "true" && !client.TLSEnabled(opts...) {
+						return nil, ErrTLSRequired
+					}
+					return client.NewGrpcClient(grpcAddr, opts...)
 				}
 				return nil, ErrGRPCDisabled
 			},
@@ -61,6 +61,13 @@ func (c *discoveredClient) City(ctx context.Context, address string, includeISP
 		})
 }
 
+func (c *discoveredClient) CityEnriched(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error) {
+	return doWithClientLoader[client.Client, *entity.City](c.clientLoader, true,
+		func(client client.Client) (res *entity.City, err error) {
+			return client.CityEnriched(ctx, address, includeISP, enrich...)
+		})
+}
+
 func (c *discoveredClient) CityLite(ctx context.Context, address, lang string) (*entity.CityLite, error) {
 	return doWithClientLoader[client.Client, *entity.CityLite](c.clientLoader, true,
 		func(client client.Client) (res *entity.CityLite, err error) {
@@ -75,6 +82,13 @@ func (c *discoveredClient) GeoNameCountries(ctx context.Context, filter entity.G
 		})
 }
 
+func (c *discoveredClient) GeoNameCountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+	return doWithClientLoader[client.Client, *entity.GeoNamePage[*entity.GeoNameCountry]](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNamePage[*entity.GeoNameCountry], err error) {
+			return client.GeoNameCountriesPage(ctx, filter)
+		})
+}
+
 func (c *discoveredClient) GeoNameSubdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
 	return doWithClientLoader[client.Client, []*entity.GeoNameAdminSubdivision](c.clientLoader, true,
 		func(client client.Client) (res []*entity.GeoNameAdminSubdivision, err error) {
@@ -82,6 +96,27 @@ func (c *discoveredClient) GeoNameSubdivisions(ctx context.Context, filter entit
 		})
 }
 
+func (c *discoveredClient) GeoNameSubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+	return doWithClientLoader[client.Client, *entity.GeoNamePage[*entity.GeoNameAdminSubdivision]](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNamePage[*entity.GeoNameAdminSubdivision], err error) {
+			return client.GeoNameSubdivisionsPage(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNameSubdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+	return doWithClientLoader[client.Client, []*entity.GeoNameAdminSubdivision2](c.clientLoader, true,
+		func(client client.Client) (res []*entity.GeoNameAdminSubdivision2, err error) {
+			return client.GeoNameSubdivisions2(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNameSubdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return doWithClientLoader[client.Client, *entity.GeoNamePage[*entity.GeoNameAdminSubdivision2]](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], err error) {
+			return client.GeoNameSubdivisions2Page(ctx, filter)
+		})
+}
+
 func (c *discoveredClient) GeoNameCities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
 	return doWithClientLoader[client.Client, []*entity.GeoName](c.clientLoader, true,
 		func(client client.Client) (res []*entity.GeoName, err error) {
@@ -89,6 +124,55 @@ func (c *discoveredClient) GeoNameCities(ctx context.Context, filter entity.GeoN
 		})
 }
 
+func (c *discoveredClient) GeoNameCitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+	return doWithClientLoader[client.Client, *entity.GeoNamePage[*entity.GeoName]](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNamePage[*entity.GeoName], err error) {
+			return client.GeoNameCitiesPage(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNamePostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+	return doWithClientLoader[client.Client, []*entity.GeoNamePostalCode](c.clientLoader, true,
+		func(client client.Client) (res []*entity.GeoNamePostalCode, err error) {
+			return client.GeoNamePostalCodes(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNamePostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return doWithClientLoader[client.Client, *entity.GeoNamePage[*entity.GeoNamePostalCode]](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNamePage[*entity.GeoNamePostalCode], err error) {
+			return client.GeoNamePostalCodesPage(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNameNearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	return doWithClientLoader[client.Client, []*entity.GeoNameNearestCity](c.clientLoader, true,
+		func(client client.Client) (res []*entity.GeoNameNearestCity, err error) {
+			return client.GeoNameNearest(ctx, filter)
+		})
+}
+
+func (c *discoveredClient) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return doWithClientLoader[client.Client, *entity.GeoNameItem](c.clientLoader, true,
+		func(client client.Client) (res *entity.GeoNameItem, err error) {
+			return client.GeoName(ctx, geoNameID, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNameChildren(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return doWithClientLoader[client.Client, []*entity.GeoNameItem](c.clientLoader, true,
+		func(client client.Client) (res []*entity.GeoNameItem, err error) {
+			return client.GeoNameChildren(ctx, geoNameID, filter)
+		})
+}
+
+func (c *discoveredClient) GeoNameAncestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return doWithClientLoader[client.Client, []*entity.GeoNameItem](c.clientLoader, true,
+		func(client client.Client) (res []*entity.GeoNameItem, err error) {
+			return client.GeoNameAncestors(ctx, geoNameID, filter)
+		})
+}
+
 func (c *discoveredClient) CheckGeoIPCityUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error) {
 	return doWithClientLoader(c.clientLoader, true,
 		func(client client.Client) (res entity.DBUpdate[entity.PatchedMMDBVersion], err error) {
@@ -16,16 +16,18 @@ type restClient struct {
 	*discoveredClient
 }
 
-func NewRestClient(d discovery.Discovery) *restClient {
+func NewRestClient(d discovery.Discovery, opts ...client.Opt) *restClient {
 	c := &discoveredClient{clientLoader: newLoader[client.Client](
 		config.ServiceName,
 		d,
 		func(serviceInfo discovery.ServiceInstanceInfo) (client.Client, error) {
-			c, err := rest_client.NewClient(string(serviceInfo.Address))
-			if err != nil {
-				return nil, err
+			addr := string(serviceInfo.Address)
+			if client.TLSEnabled(opts...) {
+				if addr = serviceInfo.Meta[microservice.RestTLSAddressMetaKey]; addr == "" {
+					return nil, ErrTLSDisabled
+				}
 			}
-			return c.SetApiKey(serviceInfo.Meta[microservice.APIKeyMetaKey]), nil
+			return client.NewRestClient(addr, opts...)
 		},
 	)}
 	return &restClient{c}
@@ -44,3 +46,32 @@ func (c *restClient) GeoNameDump(ctx context.Context, filter entity.GeoNameFilte
 			return client.(*rest_client.Client).GeoNameDump(ctx, filter)
 		})
 }
+
+func (c *restClient) GeoIPDatabase(ctx context.Context, db, format string) (*resty.Response, error) {
+	return doWithClientLoader[client.Client, *resty.Response](c.clientLoader, true,
+		func(client client.Client) (res *resty.Response, err error) {
+			return client.(*rest_client.Client).GeoIPDatabase(ctx, db, format)
+		})
+}
+
+func (c *restClient) GeoIPMetaData(ctx context.Context, db string) (*entity.MetaData, error) {
+	return doWithClientLoader[client.Client, *entity.MetaData](c.clientLoader, true,
+		func(client client.Client) (res *entity.MetaData, err error) {
+			return client.(*rest_client.Client).GeoIPMetaData(ctx, db)
+		})
+}
+
+func (c *restClient) CheckGeoIPHostingUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error) {
+	return doWithClientLoader(c.clientLoader, true,
+		func(client client.Client) (res entity.DBUpdate[entity.PatchedMMDBVersion], err error) {
+			return client.(*rest_client.Client).CheckGeoIPHostingUpdates(ctx)
+		})
+}
+
+func (c *restClient) UpdateGeoIPHosting(ctx context.Context) error {
+	_, err := doWithClientLoader(c.clientLoader, true,
+		func(client client.Client) (any, error) {
+			return nil, client.(*rest_client.Client).UpdateGeoIPHosting(ctx)
+		})
+	return err
+}
@@ -2,14 +2,18 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
+	"strconv"
 
 	mapping "github.com/bldsoft/geos/pkg/controller/grpc"
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/microservice/middleware"
 	"github.com/bldsoft/geos/pkg/storage/geonames"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 
@@ -20,10 +24,18 @@ type Client struct {
 	conn          *grpc.ClientConn
 	geoIpClient   pb.GeoIpServiceClient
 	geoNameClient pb.GeoNameServiceClient
+	apiKey        string
 }
 
+// WithTLS makes the client connect over TLS. For mTLS, set the client certificate in the config.
+func WithTLS(config *tls.Config) grpc.DialOption {
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// NewClient creates a plaintext client unless the transport credentials are passed, e.g. with WithTLS
 func NewClient(addr string, opts ...grpc.DialOption) (*Client, error) {
-	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// the later options override the earlier ones
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
 	conn, err := grpc.Dial(addr, opts...)
 	if err != nil {
 		return nil, err
@@ -35,6 +47,15 @@ func NewClient(addr string, opts ...grpc.DialOption) (*Client, error) {
 	}, nil
 }
 
+func (c *Client) SetApiKey(apiKey string) *Client {
+	c.apiKey = apiKey
+	return c
+}
+
+func (c *Client) APIKey() string {
+	return c.apiKey
+}
+
 func (c *Client) prepareContext(ctx context.Context) context.Context {
 	if reqID := middleware.GetReqID(ctx); reqID != "" {
 		ctx = metadata.AppendToOutgoingContext(ctx, middleware.RequestIDHeader, reqID)
@@ -42,6 +63,9 @@ func (c *Client) prepareContext(ctx context.Context) context.Context {
 	if realIP := middleware.GetRealIP(ctx); realIP != "" {
 		ctx = metadata.AppendToOutgoingContext(ctx, middleware.RealIPHeader, realIP)
 	}
+	if c.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, middleware.ApiKeyHeader, c.apiKey)
+	}
 	return ctx
 }
 
@@ -55,8 +79,16 @@ func (c *Client) Country(ctx context.Context, address string) (*entity.Country,
 }
 
 func (c *Client) City(ctx context.Context, address string, includeISP bool) (*entity.City, error) {
+	return c.CityEnriched(ctx, address, includeISP)
+}
+
+func (c *Client) CityEnriched(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error) {
 	ctx = c.prepareContext(ctx)
-	city, err := c.geoIpClient.City(ctx, &pb.CityRequest{Address: address, Isp: &includeISP})
+	req := &pb.CityRequest{Address: address, Isp: &includeISP}
+	for _, e := range enrich {
+		req.Enrich = append(req.Enrich, string(e))
+	}
+	city, err := c.geoIpClient.City(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -89,35 +121,141 @@ func recvAll[R, T any](stream interface {
 	return res, nil
 }
 
+// recvPage receives the page items and reads the rest of the page from the header metadata
+func recvPage[R, T any](stream interface {
+	Recv() (*R, error)
+	Header() (metadata.MD, error)
+}, convert func(*R) *T) (*entity.GeoNamePage[*T], error) {
+	items, err := recvAll(stream, convert)
+	if err != nil {
+		return nil, err
+	}
+	header, err := stream.Header()
+	if err != nil {
+		return nil, err
+	}
+	page := &entity.GeoNamePage[*T]{Items: items, Total: len(items)} // the servers without paging don't send the total count
+	if values := header.Get(entity.GeoNameTotalCountHeader); len(values) != 0 {
+		if page.Total, err = strconv.Atoi(values[0]); err != nil {
+			return nil, fmt.Errorf("%s metadata: %w", entity.GeoNameTotalCountHeader, err)
+		}
+	}
+	if values := header.Get(entity.GeoNameNextCursorHeader); len(values) != 0 {
+		page.NextCursor = values[0]
+	}
+	return page, nil
+}
+
+func pageItems[T any](page *entity.GeoNamePage[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
 func (c *Client) GeoNameContinents(ctx context.Context) []*entity.GeoNameContinent {
 	return geonames.GeoNameContinents()
 }
 
 func (c *Client) GeoNameCountries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+	return pageItems(c.GeoNameCountriesPage(ctx, filter))
+}
+
+func (c *Client) GeoNameCountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
 	ctx = c.prepareContext(ctx)
 	countryClient, err := c.geoNameClient.Country(ctx, mapping.FilterToPbGeoNameRequest(filter))
 	if err != nil {
 		return nil, err
 	}
-	return recvAll[pb.GeoNameCountryResponse](countryClient, mapping.PbToGeoNameCountry)
+	return recvPage[pb.GeoNameCountryResponse](countryClient, mapping.PbToGeoNameCountry)
 }
 
 func (c *Client) GeoNameSubdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+	return pageItems(c.GeoNameSubdivisionsPage(ctx, filter))
+}
+
+func (c *Client) GeoNameSubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
 	ctx = c.prepareContext(ctx)
 	subdivisionClient, err := c.geoNameClient.Subdivision(ctx, mapping.FilterToPbGeoNameRequest(filter))
 	if err != nil {
 		return nil, err
 	}
-	return recvAll[pb.GeoNameSubdivisionResponse](subdivisionClient, mapping.PbToGeoNameSubdivision)
+	return recvPage[pb.GeoNameSubdivisionResponse](subdivisionClient, mapping.PbToGeoNameSubdivision)
+}
+
+func (c *Client) GeoNameSubdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+	return pageItems(c.GeoNameSubdivisions2Page(ctx, filter))
+}
+
+func (c *Client) GeoNameSubdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	ctx = c.prepareContext(ctx)
+	subdivisionClient, err := c.geoNameClient.Subdivision2(ctx, mapping.FilterToPbGeoNameRequest(filter))
+	if err != nil {
+		return nil, err
+	}
+	return recvPage[pb.GeoNameSubdivision2Response](subdivisionClient, mapping.PbToGeoNameSubdivision2)
 }
 
 func (c *Client) GeoNameCities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+	return pageItems(c.GeoNameCitiesPage(ctx, filter))
+}
+
+func (c *Client) GeoNameCitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
 	ctx = c.prepareContext(ctx)
 	cityClient, err := c.geoNameClient.City(ctx, mapping.FilterToPbGeoNameRequest(filter))
 	if err != nil {
 		return nil, err
 	}
-	return recvAll[pb.GeoNameCityResponse](cityClient, mapping.PbToGeoNameCity)
+	return recvPage[pb.GeoNameCityResponse](cityClient, mapping.PbToGeoNameCity)
+}
+
+func (c *Client) GeoNamePostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+	return pageItems(c.GeoNamePostalCodesPage(ctx, filter))
+}
+
+func (c *Client) GeoNamePostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	ctx = c.prepareContext(ctx)
+	postalCodeClient, err := c.geoNameClient.PostalCode(ctx, mapping.FilterToPbGeoNameRequest(filter))
+	if err != nil {
+		return nil, err
+	}
+	return recvPage[pb.GeoNamePostalCodeResponse](postalCodeClient, mapping.PbToGeoNamePostalCode)
+}
+
+func (c *Client) GeoNameNearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	ctx = c.prepareContext(ctx)
+	nearestClient, err := c.geoNameClient.Nearest(ctx, mapping.NearestFilterToPbGeoNameNearestRequest(filter))
+	if err != nil {
+		return nil, err
+	}
+	return recvAll[pb.GeoNameNearestResponse](nearestClient, mapping.PbToGeoNameNearestCity)
+}
+
+func (c *Client) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	ctx = c.prepareContext(ctx)
+	item, err := c.geoNameClient.GeoName(ctx, mapping.HierarchyFilterToPbGeoNameHierarchyRequest(geoNameID, filter))
+	if err != nil {
+		return nil, err
+	}
+	return mapping.PbToGeoNameItem(item), nil
+}
+
+func (c *Client) GeoNameChildren(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	ctx = c.prepareContext(ctx)
+	childrenClient, err := c.geoNameClient.Children(ctx, mapping.HierarchyFilterToPbGeoNameHierarchyRequest(geoNameID, filter))
+	if err != nil {
+		return nil, err
+	}
+	return recvAll[pb.GeoNameItemResponse](childrenClient, mapping.PbToGeoNameItem)
+}
+
+func (c *Client) GeoNameAncestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	ctx = c.prepareContext(ctx)
+	ancestorsClient, err := c.geoNameClient.Ancestors(ctx, mapping.HierarchyFilterToPbGeoNameHierarchyRequest(geoNameID, filter))
+	if err != nil {
+		return nil, err
+	}
+	return recvAll[pb.GeoNameItemResponse](ancestorsClient, mapping.PbToGeoNameItem)
 }
 
 func (c *Client) Close() {
@@ -12,14 +12,35 @@ type GeoIPClient interface {
 	CityLite(ctx context.Context, address, lang string) (*entity.CityLite, error)
 }
 
+// GeoIPEnrichedClient isn't a part of GeoIPClient not to break its implementations
+type GeoIPEnrichedClient interface {
+	CityEnriched(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error)
+}
+
 type GeoNameClient interface {
 	GeoNameContinents(ctx context.Context) []*entity.GeoNameContinent
 	GeoNameCountries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error)
 	GeoNameSubdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error)
+	GeoNameSubdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error)
 	GeoNameCities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error)
+	GeoNamePostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error)
+	GeoNameNearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error)
+	GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error)
+	GeoNameChildren(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
+	GeoNameAncestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
 	Hosting(ctx context.Context, address string) (*entity.Hosting, error)
 }
 
+// GeoNamePageClient isn't a part of GeoNameClient not to break its implementations.
+// The next page is requested with GeoNameFilter.Cursor set to the NextCursor of the page.
+type GeoNamePageClient interface {
+	GeoNameCountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error)
+	GeoNameSubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error)
+	GeoNameSubdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error)
+	GeoNameCitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error)
+	GeoNamePostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error)
+}
+
 type ManagementClient interface {
 	CheckGeoIPCityUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error)
 	CheckGeoIPISPUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error)
@@ -31,6 +52,8 @@ type ManagementClient interface {
 
 type Client interface {
 	GeoIPClient
+	GeoIPEnrichedClient
 	GeoNameClient
+	GeoNamePageClient
 	ManagementClient
 }
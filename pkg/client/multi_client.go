@@ -41,6 +41,12 @@ func (c *MultiClient) City(ctx context.Context, address string, includeISP bool)
 	})
 }
 
+func (c *MultiClient) CityEnriched(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.City, error) {
+		return client.CityEnriched(ctx, address, includeISP, enrich...)
+	})
+}
+
 func (c *MultiClient) CityLite(ctx context.Context, address, lang string) (*entity.CityLite, error) {
 	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.CityLite, error) {
 		return client.CityLite(ctx, address, lang)
@@ -71,17 +77,82 @@ func (c *MultiClient) GeoNameCountries(ctx context.Context, filter entity.GeoNam
 		return client.GeoNameCountries(ctx, filter)
 	})
 }
+
+func (c *MultiClient) GeoNameCountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+		return client.GeoNameCountriesPage(ctx, filter)
+	})
+}
 func (c *MultiClient) GeoNameSubdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
 	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNameAdminSubdivision, error) {
 		return client.GeoNameSubdivisions(ctx, filter)
 	})
 }
+
+func (c *MultiClient) GeoNameSubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+		return client.GeoNameSubdivisionsPage(ctx, filter)
+	})
+}
+func (c *MultiClient) GeoNameSubdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNameAdminSubdivision2, error) {
+		return client.GeoNameSubdivisions2(ctx, filter)
+	})
+}
+
+func (c *MultiClient) GeoNameSubdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+		return client.GeoNameSubdivisions2Page(ctx, filter)
+	})
+}
 func (c *MultiClient) GeoNameCities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
 	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoName, error) {
 		return client.GeoNameCities(ctx, filter)
 	})
 }
 
+func (c *MultiClient) GeoNameCitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNamePage[*entity.GeoName], error) {
+		return client.GeoNameCitiesPage(ctx, filter)
+	})
+}
+
+func (c *MultiClient) GeoNamePostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNamePostalCode, error) {
+		return client.GeoNamePostalCodes(ctx, filter)
+	})
+}
+
+func (c *MultiClient) GeoNamePostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+		return client.GeoNamePostalCodesPage(ctx, filter)
+	})
+}
+
+func (c *MultiClient) GeoNameNearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNameNearestCity, error) {
+		return client.GeoNameNearest(ctx, filter)
+	})
+}
+
+func (c *MultiClient) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (*entity.GeoNameItem, error) {
+		return client.GeoName(ctx, geoNameID, filter)
+	})
+}
+
+func (c *MultiClient) GeoNameChildren(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNameItem, error) {
+		return client.GeoNameChildren(ctx, geoNameID, filter)
+	})
+}
+
+func (c *MultiClient) GeoNameAncestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return getManyFromAny(ctx, c.Clients, func(ctx context.Context, client Client) ([]*entity.GeoNameItem, error) {
+		return client.GeoNameAncestors(ctx, geoNameID, filter)
+	})
+}
+
 func (c *MultiClient) CheckGeoIPCityUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedMMDBVersion], error) {
 	return getFromAny(ctx, c.Clients, func(ctx context.Context, client Client) (entity.DBUpdate[entity.PatchedMMDBVersion], error) {
 		return client.CheckGeoIPCityUpdates(ctx)
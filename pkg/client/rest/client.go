@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/bldsoft/geos/pkg/entity"
@@ -28,13 +30,37 @@ func (e *RespError) Error() string {
 	return fmt.Sprintf("status code: %d, response: %s", e.StatusCode, e.Response)
 }
 
-func NewClient(addr string) (*Client, error) {
-	return NewWithClient(addr, http.DefaultClient)
+// Option configures the HTTP client created by NewClient
+type Option func(*http.Client)
+
+// WithTLS sets the TLS config of the client. For mTLS, set the client certificate in the config.
+func WithTLS(config *tls.Config) Option {
+	return func(c *http.Client) {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = config
+		c.Transport = transport
+	}
+}
+
+func NewClient(addr string, opts ...Option) (*Client, error) {
+	if len(opts) == 0 {
+		return NewWithClient(addr, http.DefaultClient)
+	}
+	client := &http.Client{}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return NewWithClient(addr, client)
 }
 
+// NewWithClient creates a client with the given HTTP client. The address without a scheme is http, or https if the client has a TLS config.
 func NewWithClient(addr string, client *http.Client) (*Client, error) {
 	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
-		addr = "http://" + addr
+		scheme := "http://"
+		if transport, ok := client.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+			scheme = "https://"
+		}
+		addr = scheme + addr
 	}
 	baseURL, err := url.JoinPath(addr, microservice.BaseApiPath)
 	if err != nil {
@@ -45,9 +71,10 @@ func NewWithClient(addr string, client *http.Client) (*Client, error) {
 	}, nil
 }
 
-// only for management endpoints (dump, update, etc.)
+// the key is required for management endpoints (dump, update, etc.), for lookups it's optional
 func (c *Client) SetApiKey(apiKey string) *Client {
 	c.apiKey = apiKey
+	c.client.SetHeader(microservice.APIKey, apiKey)
 	return c
 }
 
@@ -94,7 +121,15 @@ func (c *Client) Country(ctx context.Context, address string) (*entity.Country,
 }
 
 func (c *Client) City(ctx context.Context, address string, includeISP bool) (*entity.City, error) {
-	return get[*entity.City](ctx, c.client, fmt.Sprintf("city/%s?isp=%v", address, includeISP), nil)
+	return c.CityEnriched(ctx, address, includeISP)
+}
+
+func (c *Client) CityEnriched(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error) {
+	path := fmt.Sprintf("city/%s?isp=%v", address, includeISP)
+	for _, e := range enrich {
+		path += "&enrich=" + url.QueryEscape(string(e))
+	}
+	return get[*entity.City](ctx, c.client, path, nil)
 }
 
 func (c *Client) CityLite(ctx context.Context, address, lang string) (*entity.CityLite, error) {
@@ -109,13 +144,29 @@ func (c *Client) GeoIPDump(ctx context.Context) (*resty.Response, error) {
 	return c.client.R().SetHeader(microservice.APIKey, c.APIKey()).Get("/dump")
 }
 
-func getManyWithBody[T any](ctx context.Context, client *resty.Client, path string, body any) ([]*T, error) {
+// GeoIPDatabase downloads the db: city, isp or hosting, in the format: mmdb or csv
+func (c *Client) GeoIPDatabase(ctx context.Context, db, format string) (*resty.Response, error) {
+	resp, err := c.requestWithApiKey(ctx).Get(fmt.Sprintf("dump/%s/%s", db, format))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() >= 400 {
+		return nil, &RespError{StatusCode: resp.StatusCode(), Response: string(resp.Body())}
+	}
+	return resp, nil
+}
+
+func (c *Client) GeoIPMetaData(ctx context.Context, db string) (*entity.MetaData, error) {
+	return getRequest[*entity.MetaData](c.requestWithApiKey(ctx), fmt.Sprintf("dump/%s/metadata", db))
+}
+
+// getPageWithBody reads the page items from the body and the rest of the page from the headers
+func getPageWithBody[T any](ctx context.Context, client *resty.Client, path string, body any) (*entity.GeoNamePage[*T], error) {
 	request := client.R().SetContext(ctx)
 	if body != nil {
 		request = request.SetBody(body)
 	}
 
-	var obj []*T
 	resp, err := request.Post(path)
 	if err != nil {
 		return nil, err
@@ -125,23 +176,107 @@ func getManyWithBody[T any](ctx context.Context, client *resty.Client, path stri
 		return nil, &RespError{StatusCode: resp.StatusCode(), Response: string(resp.Body())}
 	}
 
-	err = json.Unmarshal(resp.Body(), &obj)
+	page := &entity.GeoNamePage[*T]{NextCursor: resp.Header().Get(entity.GeoNameNextCursorHeader)}
+	if err = json.Unmarshal(resp.Body(), &page.Items); err != nil {
+		return nil, err
+	}
+	page.Total = len(page.Items) // the servers without paging don't send the total count
+	if total := resp.Header().Get(entity.GeoNameTotalCountHeader); len(total) != 0 {
+		if page.Total, err = strconv.Atoi(total); err != nil {
+			return nil, fmt.Errorf("%s header: %w", entity.GeoNameTotalCountHeader, err)
+		}
+	}
+	return page, nil
+}
+
+func pageItems[T any](page *entity.GeoNamePage[T], err error) ([]T, error) {
 	if err != nil {
 		return nil, err
 	}
-	return obj, nil
+	return page.Items, nil
 }
 
 func (c *Client) GeoNameCountries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
-	return getManyWithBody[entity.GeoNameCountry](ctx, c.client, "geoname/country", filter)
+	return pageItems(c.GeoNameCountriesPage(ctx, filter))
+}
+
+func (c *Client) GeoNameCountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+	return getPageWithBody[entity.GeoNameCountry](ctx, c.client, "geoname/country", filter)
 }
 
 func (c *Client) GeoNameSubdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
-	return getManyWithBody[entity.GeoNameAdminSubdivision](ctx, c.client, "geoname/subdivision", filter)
+	return pageItems(c.GeoNameSubdivisionsPage(ctx, filter))
+}
+
+func (c *Client) GeoNameSubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+	return getPageWithBody[entity.GeoNameAdminSubdivision](ctx, c.client, "geoname/subdivision", filter)
+}
+
+func (c *Client) GeoNameSubdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+	return pageItems(c.GeoNameSubdivisions2Page(ctx, filter))
+}
+
+func (c *Client) GeoNameSubdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return getPageWithBody[entity.GeoNameAdminSubdivision2](ctx, c.client, "geoname/subdivision2", filter)
 }
 
 func (c *Client) GeoNameCities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
-	return getManyWithBody[entity.GeoName](ctx, c.client, "geoname/city", filter)
+	return pageItems(c.GeoNameCitiesPage(ctx, filter))
+}
+
+func (c *Client) GeoNameCitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+	return getPageWithBody[entity.GeoName](ctx, c.client, "geoname/city", filter)
+}
+
+func (c *Client) GeoNamePostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+	return pageItems(c.GeoNamePostalCodesPage(ctx, filter))
+}
+
+func (c *Client) GeoNamePostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return getPageWithBody[entity.GeoNamePostalCode](ctx, c.client, "geoname/postal", filter)
+}
+
+func (c *Client) GeoNameNearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	query := url.Values{
+		"lat": {strconv.FormatFloat(filter.Latitude, 'f', -1, 64)},
+		"lon": {strconv.FormatFloat(filter.Longitude, 'f', -1, 64)},
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(filter.Limit), 10))
+	}
+	if filter.RadiusKm != 0 {
+		query.Set("radius_km", strconv.FormatFloat(filter.RadiusKm, 'f', -1, 64))
+	}
+	if len(filter.Lang) != 0 {
+		query.Set("lang", filter.Lang)
+	}
+	return get[[]*entity.GeoNameNearestCity](ctx, c.client, "geoname/nearest", query)
+}
+
+func hierarchyQuery(filter entity.GeoNameHierarchyFilter) url.Values {
+	query := url.Values{}
+	if len(filter.Type) != 0 {
+		query.Set("type", string(filter.Type))
+	}
+	if len(filter.Lang) != 0 {
+		query.Set("lang", filter.Lang)
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.FormatUint(uint64(filter.Limit), 10))
+	}
+	return query
+}
+
+func (c *Client) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return get[*entity.GeoNameItem](ctx, c.client, fmt.Sprintf("geoname/%d", geoNameID), hierarchyQuery(filter))
+}
+
+func (c *Client) GeoNameChildren(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return get[[]*entity.GeoNameItem](ctx, c.client, fmt.Sprintf("geoname/%d/children", geoNameID), hierarchyQuery(filter))
+}
+
+func (c *Client) GeoNameAncestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return get[[]*entity.GeoNameItem](ctx, c.client, fmt.Sprintf("geoname/%d/ancestors", geoNameID), hierarchyQuery(filter))
 }
 
 func (c *Client) GeoNameDump(ctx context.Context, filter entity.GeoNameFilter) (*resty.Response, error) {
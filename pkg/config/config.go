@@ -3,7 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/bldsoft/geos/pkg/ratelimit"
+	"github.com/bldsoft/geos/pkg/resolver"
+	"github.com/bldsoft/geos/pkg/tlsconfig"
 	"github.com/bldsoft/gost/clickhouse"
 	"github.com/bldsoft/gost/config"
 	"github.com/bldsoft/gost/discovery/common"
@@ -22,8 +26,14 @@ type Config struct {
 	Clickhouse clickhouse.Config `mapstructure:"CLICKHOUSE"`
 	LogExport  clickhouse.LogExporterConfig
 
-	GRPCServiceBindAddress config.Address `mapstructure:"GRPC_SERVICE_BIND_ADDRESS" description:"Service configuration related to what address bind to and port to listen"`
-	GRPCServiceAddress     config.Address `mapstructure:"GRPC_SERVICE_ADDRESS" description:"GRPC public address"`
+	GRPCServiceBindAddress config.Address         `mapstructure:"GRPC_SERVICE_BIND_ADDRESS" description:"Service configuration related to what address bind to and port to listen"`
+	GRPCServiceAddress     config.Address         `mapstructure:"GRPC_SERVICE_ADDRESS" description:"GRPC public address"`
+	GRPCTLS                tlsconfig.ServerConfig `mapstructure:"GRPC_TLS"`
+
+	RestTLSBindAddress config.Address         `mapstructure:"REST_TLS_BIND_ADDRESS" description:"Address of the HTTPS REST server. It's started if REST_TLS_CERT_FILE is set"`
+	RestTLSAddress     config.Address         `mapstructure:"REST_TLS_ADDRESS" description:"HTTPS REST public address"`
+	RestTLS            tlsconfig.ServerConfig `mapstructure:"REST_TLS"`
+	RestTLSOnly        bool                   `mapstructure:"REST_TLS_ONLY" description:"Serve the REST API only over HTTPS: the plaintext server answers 403 to everything except /ping and the discovery. Requires REST_TLS_CERT_FILE"`
 
 	GeoDbSource               string `mapstructure:"GEOIP_DB_SOURCE" description:"Source to download GeoLite2 or GeoIP2 city database from"`
 	GeoDbPatchesSource        string `mapstructure:"GEOIP_DB_PATCHES_SOURCE" description:"Source for downloading patches for city database (in .tar.gz)"`
@@ -32,6 +42,7 @@ type Config struct {
 	GeoDbHostingSource        string `mapstructure:"GEOIP_DB_HOSTING_SOURCE" description:"Source to download hosting database from"`
 	GeoDbHostingPatchesSource string `mapstructure:"GEOIP_DB_HOSTING_PATCHES_SOURCE" description:"Source for downloading custom hosting database patches (in .tar.gz)"`
 	AutoUpdatePeriodSec       int    `mapstructure:"AUTO_UPDATE_PERIOD_SEC" description:"Amount of seconds to wait before trying to automatically update from the source"`
+	ValidateAfterUpdate       bool   `mapstructure:"VALIDATE_AFTER_UPDATE" description:"Check the consistency of the city database and GeoNames (with the patches) after each update and log the result. The report is available at /dump/validate"`
 
 	GeoDbPath        string `mapstructure:"GEOIP_DB_PATH" description:"Path to GeoLite2 or GeoIP2 city database"`
 	GeoDbISPPath     string `mapstructure:"GEOIP_DB_ISP_PATH" description:"Path to GeoIP2 ISP database"`
@@ -39,16 +50,64 @@ type Config struct {
 
 	Discovery common.Config `mapstructure:"DISCOVERY"`
 
-	GeoNameDumpDirPath   string `mapstructure:"GEONAME_DUMP_DIR" description:"The path to the directory where the GeoNames dumps are located (countryInfo.txt, admin1CodesASKII.txt, cities5000.zip). If variable isn't set, GeoNames api will be disabled. The dumps will be loaded when service starts, if something is missing"`
-	GeoNamePatchesSource string `mapstructure:"GEONAME_PATCHES_SOURCE" description:"Source for downloading custom GeoNames patches (in .tar.gz)"`
-	GeoIPCsvDumpDirPath  string `mapstructure:"GEOIP_DUMP_DIR" description:"The path to the directory where the csv ip database is located. If the variable is set and the csv file is missing, the service will generate it from the mmdb when it starts."`
-	ApiKey               string `mapstructure:"API_KEY" description:"API key for dumps used for importing into other databases"`
+	DNS resolver.Config `mapstructure:"DNS"`
+
+	TrustedProxies string `mapstructure:"TRUSTED_PROXIES" description:"Comma separated list of trusted proxy networks (CIDR or IP). Forwarded, X-Forwarded-For and X-Real-IP headers are accepted only from these addresses. Only loopback is trusted by default, add the networks of the proxies in front of the service, e.g. 10.0.0.0/8"`
+
+	GeoNameDumpDirPath    string `mapstructure:"GEONAME_DUMP_DIR" description:"The path to the directory where the GeoNames dumps are located (countryInfo.txt, admin1CodesASCII.txt, the city dumps). If variable isn't set, GeoNames api will be disabled. The dumps will be loaded when service starts, if something is missing"`
+	GeoNamePatchesSource  string `mapstructure:"GEONAME_PATCHES_SOURCE" description:"Source for downloading custom GeoNames patches (in .tar.gz)"`
+	GeoNameAlternateNames bool   `mapstructure:"GEONAME_ALTERNATE_NAMES" description:"Load alternateNamesV2.zip to translate the GeoNames names (lang parameter). The dump is large, it takes a while to load"`
+	GeoNameAdmin2Codes    bool   `mapstructure:"GEONAME_ADMIN2_CODES" description:"Load admin2Codes.txt: the second-level administrative divisions (/geoname/subdivision2) and the cities' subdivision2 names"`
+	GeoNamePostalCodes    string `mapstructure:"GEONAME_POSTAL_CODES" description:"Comma separated list of the countries (ISO codes) to load the GeoNames postal codes for (/geoname/postal), allCountries for all of them. The postal codes aren't loaded if it's empty"`
+	GeoNameCityDumps      string `mapstructure:"GEONAME_CITY_DUMPS" description:"Comma separated list of the GeoNames dumps to load the cities from: cities15000, cities5000, cities1000, cities500 or allCountries. The cities of several dumps are merged"`
+	GeoNameFeatureClasses string `mapstructure:"GEONAME_FEATURE_CLASSES" description:"Comma separated list of the feature classes of the cities to load, all if empty. The city dumps contain only populated places (P), it's useful for allCountries"`
+	GeoNameCityAltNames   bool   `mapstructure:"GEONAME_CITY_ALT_NAMES" description:"Keep the alternatenames column of the city dumps: the cities are found by these names and they're returned in the alternateNames field. It's most of the memory of the cities"`
+	GeoNameLanguages      string `mapstructure:"GEONAME_LANGUAGES" description:"Comma separated list of languages of the alternate names to load. All the languages are loaded if it's empty"`
+	GeoIPCsvDumpDirPath   string `mapstructure:"GEOIP_DUMP_DIR" description:"The path to the directory where the csv ip database is located. If the variable is set and the csv file is missing, the service will generate it from the mmdb when it starts."`
+	ApiKey                string `mapstructure:"API_KEY" description:"Single API key with all scopes. Use API_KEYS or API_KEYS_FILE for per-client keys. The keys aren't published to the discovery metadata, the discovered clients set them with client.WithApiKey"`
+
+	ApiKeys                string `mapstructure:"API_KEYS" description:"JSON array of API keys with the fields id, name, key, scopes (lookup, dump, update, geonames-dump), expiresAt (RFC 3339) and rateLimits (scope -> {rps, burst}, overrides RATE_LIMIT_*)"`
+	ApiKeysFilePath        string `mapstructure:"API_KEYS_FILE" description:"Path to the JSON file with API keys in the API_KEYS format. The file is reloaded on change"`
+	ApiKeysReloadPeriodSec int    `mapstructure:"API_KEYS_RELOAD_PERIOD_SEC" description:"Amount of seconds between checks of the API keys file for changes"`
+	LookupApiKeyRequired   bool   `mapstructure:"LOOKUP_API_KEY_REQUIRED" description:"Require an API key with the lookup scope for geo IP and GeoNames lookups. A key sent with a lookup is checked anyway: an unknown or expired key is rejected with 401"`
+
+	RateLimit ratelimit.Config `mapstructure:"RATE_LIMIT"`
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) != 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (c *Config) GeoNameLanguageList() []string {
+	return splitList(c.GeoNameLanguages)
+}
+
+func (c *Config) GeoNamePostalCodeCountries() []string {
+	return splitList(c.GeoNamePostalCodes)
+}
+
+func (c *Config) GeoNameCityDumpList() []string {
+	return splitList(c.GeoNameCityDumps)
+}
+
+func (c *Config) GeoNameFeatureClassList() []string {
+	return splitList(c.GeoNameFeatureClasses)
 }
 
 func (c *Config) NeedGrpc() bool {
 	return len(c.GRPCServiceBindAddress) > 0
 }
 
+func (c *Config) NeedRestTLS() bool {
+	return len(c.RestTLSBindAddress) > 0 && c.RestTLS.Enabled()
+}
+
 // SetDefaults ...
 func (c *Config) SetDefaults() {
 	c.Server.ServiceName = ServiceName
@@ -56,12 +115,26 @@ func (c *Config) SetDefaults() {
 	c.Server.ServiceBindPort = 8505
 	c.GRPCServiceBindAddress = "0.0.0.0:8506"
 	c.GRPCServiceAddress = c.GRPCServiceBindAddress
+	c.GRPCTLS.SetDefaults()
+	c.RestTLSBindAddress = "0.0.0.0:8507"
+	c.RestTLSAddress = c.RestTLSBindAddress
+	c.RestTLS.SetDefaults()
 	c.Log.Color = false
 	c.GeoDbPath = "../../db.mmdb"
-	c.ApiKey = "Dfga4pBfeRsMnxesWmY8eNBCW2Zf46kL"
+	c.ApiKeysReloadPeriodSec = 10
+	c.ValidateAfterUpdate = true
 
 	c.Clickhouse.Dsn = ""
 	c.GeoNameDumpDirPath = "/data/geoname"
+	c.GeoNameLanguages = "de,en,es,fr,ja,pt-BR,ru,zh-CN"
+	c.GeoNameAdmin2Codes = true
+	c.GeoNameCityDumps = "cities500"
+	c.GeoNameFeatureClasses = "P"
+	c.GeoNameCityAltNames = true
+
+	c.DNS.SetDefaults()
+	c.TrustedProxies = "127.0.0.0/8,::1/128"
+	c.RateLimit.SetDefaults()
 }
 
 // Validate ...
@@ -73,5 +146,17 @@ func (c *Config) Validate() error {
 	if _, err := os.Stat(c.GeoDbISPPath); err != nil && len(c.GeoDbISPSource) == 0 {
 		return fmt.Errorf("GEOIP_DB_ISP_PATH %s: %w", c.GeoDbISPPath, err)
 	}
-	return nil
+	if err := c.GRPCTLS.Validate(); err != nil {
+		return fmt.Errorf("GRPC_TLS: %w", err)
+	}
+	if err := c.RestTLS.Validate(); err != nil {
+		return fmt.Errorf("REST_TLS: %w", err)
+	}
+	if c.RestTLSOnly && !c.NeedRestTLS() {
+		return fmt.Errorf("REST_TLS_ONLY requires REST_TLS_CERT_FILE and REST_TLS_BIND_ADDRESS")
+	}
+	if err := c.DNS.Validate(); err != nil {
+		return err
+	}
+	return c.RateLimit.Validate()
 }
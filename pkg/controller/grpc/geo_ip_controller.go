@@ -5,6 +5,7 @@ import (
 
 	"github.com/bldsoft/geos/pkg/controller"
 	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
+	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/gost/log"
 )
 
@@ -29,10 +30,14 @@ func (c *GeoIpController) Country(ctx context.Context, req *pb.CountryRequest) (
 }
 
 func (c *GeoIpController) City(ctx context.Context, req *pb.CityRequest) (*pb.CityResponse, error) {
-	city, err := c.service.City(ctx, req.Address, req.GetIsp())
+	enrich := make([]entity.CityEnrichment, 0, len(req.Enrich))
+	for _, e := range req.Enrich {
+		enrich = append(enrich, entity.CityEnrichment(e))
+	}
+	city, err := c.service.City(ctx, req.Address, req.GetIsp(), enrich...)
 	if err != nil {
 		log.FromContext(ctx).Error(err.Error())
-		return nil, err
+		return nil, grpcError(err)
 	}
 	return CityToPb(city), nil
 }
@@ -116,6 +116,7 @@ func PbToCity(cityPb *pb.CityResponse) *entity.City {
 	city.Traits.IsSatelliteProvider = cityPb.Traits.IsSatelliteProvider
 
 	city.ISP = PbToISP(cityPb.Isp)
+	city.GeoNames = PbToCityGeoNames(cityPb.GeoNames)
 	return &city
 }
 
@@ -172,7 +173,8 @@ func CityToPb(city *entity.City) *pb.CityResponse {
 			IsAnonymousProxy:    city.Traits.IsAnonymousProxy,
 			IsSatelliteProvider: city.Traits.IsSatelliteProvider,
 		},
-		Isp: ISPToPb(city.ISP),
+		Isp:      ISPToPb(city.ISP),
+		GeoNames: CityGeoNamesToPb(city.GeoNames),
 	}
 }
 
@@ -234,3 +236,43 @@ func PbToISP(isp *pb.ISP) *entity.ISP {
 		AutonomousSystemNumber:       uint(isp.AutonomousSystemNumber),
 	}
 }
+
+func CityGeoNamesToPb(geoNames *entity.CityGeoNames) *pb.CityGeoNames {
+	if geoNames == nil {
+		return nil
+	}
+	res := &pb.CityGeoNames{}
+	if geoNames.Continent != nil {
+		res.Continent = GeoNameContinentToPb(geoNames.Continent)
+	}
+	if geoNames.Country != nil {
+		res.Country = GeoNameCountryToPb(geoNames.Country)
+	}
+	for _, subdivision := range geoNames.Subdivisions {
+		res.Subdivisions = append(res.Subdivisions, GeoNameItemToPb(subdivision))
+	}
+	if geoNames.City != nil {
+		res.City = GeoNameCityToPb(geoNames.City)
+	}
+	return res
+}
+
+func PbToCityGeoNames(geoNames *pb.CityGeoNames) *entity.CityGeoNames {
+	if geoNames == nil {
+		return nil
+	}
+	res := &entity.CityGeoNames{}
+	if geoNames.Continent != nil {
+		res.Continent = PbToGeoNameContinent(geoNames.Continent)
+	}
+	if geoNames.Country != nil {
+		res.Country = PbToGeoNameCountry(geoNames.Country)
+	}
+	for _, subdivision := range geoNames.Subdivisions {
+		res.Subdivisions = append(res.Subdivisions, PbToGeoNameItem(subdivision))
+	}
+	if geoNames.City != nil {
+		res.City = PbToGeoNameCity(geoNames.City)
+	}
+	return res
+}
@@ -1,10 +1,18 @@
 package grpc
 
 import (
+	"context"
+	"errors"
+	"strconv"
+
 	"github.com/bldsoft/geos/pkg/controller"
 	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
 	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
 	"github.com/bldsoft/gost/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 //go:generate protoc -I=../../.. --go_out=proto --go-grpc_out=proto api/grpc/geoname.proto
@@ -29,9 +37,21 @@ func sendToStream[T any, P any](objects []*T, convert func(*T) *P, stream interf
 	return nil
 }
 
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, utils.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, utils.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, utils.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return err
+}
+
 func (c *GeoNameController) Continent(in *pb.GeoNameRequest, stream pb.GeoNameService_ContinentServer) error {
 	ctx := stream.Context()
-	continents := c.service.Continents(ctx)
+	continents := c.service.Continents(ctx, in.GetLang())
 	return sendToStream[entity.GeoNameContinent, pb.GeoNameContinentResponse](continents, GeoNameContinentToPb, stream)
 }
 
@@ -40,9 +60,9 @@ func (c *GeoNameController) Country(in *pb.GeoNameRequest, stream pb.GeoNameServ
 	countries, err := c.service.Countries(ctx, PbGeoNameRequestToFilter(in))
 	if err != nil {
 		log.FromContext(ctx).Error(err.Error())
-		return err
+		return grpcError(err)
 	}
-	return sendToStream[entity.GeoNameCountry, pb.GeoNameCountryResponse](countries, GeoNameCountryToPb, stream)
+	return sendPageToStream[entity.GeoNameCountry, pb.GeoNameCountryResponse](countries, GeoNameCountryToPb, stream)
 }
 
 func (c *GeoNameController) Subdivision(in *pb.GeoNameRequest, stream pb.GeoNameService_SubdivisionServer) error {
@@ -50,9 +70,19 @@ func (c *GeoNameController) Subdivision(in *pb.GeoNameRequest, stream pb.GeoName
 	subdivisions, err := c.service.Subdivisions(ctx, PbGeoNameRequestToFilter(in))
 	if err != nil {
 		log.FromContext(ctx).Error(err.Error())
-		return err
+		return grpcError(err)
+	}
+	return sendPageToStream[entity.GeoNameAdminSubdivision, pb.GeoNameSubdivisionResponse](subdivisions, GeoNameSubdivisionToPb, stream)
+}
+
+func (c *GeoNameController) Subdivision2(in *pb.GeoNameRequest, stream pb.GeoNameService_Subdivision2Server) error {
+	ctx := stream.Context()
+	subdivisions, err := c.service.Subdivisions2(ctx, PbGeoNameRequestToFilter(in))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
 	}
-	return sendToStream[entity.GeoNameAdminSubdivision, pb.GeoNameSubdivisionResponse](subdivisions, GeoNameSubdivisionToPb, stream)
+	return sendPageToStream[entity.GeoNameAdminSubdivision2, pb.GeoNameSubdivision2Response](subdivisions, GeoNameSubdivision2ToPb, stream)
 }
 
 func (c *GeoNameController) City(in *pb.GeoNameRequest, stream pb.GeoNameService_CityServer) error {
@@ -60,7 +90,74 @@ func (c *GeoNameController) City(in *pb.GeoNameRequest, stream pb.GeoNameService
 	cities, err := c.service.Cities(ctx, PbGeoNameRequestToFilter(in))
 	if err != nil {
 		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
+	}
+	return sendPageToStream[entity.GeoName, pb.GeoNameCityResponse](cities, GeoNameCityToPb, stream)
+}
+
+func (c *GeoNameController) PostalCode(in *pb.GeoNameRequest, stream pb.GeoNameService_PostalCodeServer) error {
+	ctx := stream.Context()
+	postalCodes, err := c.service.PostalCodes(ctx, PbGeoNameRequestToFilter(in))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
+	}
+	return sendPageToStream[entity.GeoNamePostalCode, pb.GeoNamePostalCodeResponse](postalCodes, GeoNamePostalCodeToPb, stream)
+}
+
+// sendPageToStream sends the total count and the next cursor in the header metadata, then the entities
+func sendPageToStream[T any, P any](page *entity.GeoNamePage[*T], convert func(*T) *P, stream interface {
+	Send(*P) error
+	SetHeader(metadata.MD) error
+}) error {
+	header := metadata.Pairs(entity.GeoNameTotalCountHeader, strconv.Itoa(page.Total))
+	if len(page.NextCursor) > 0 {
+		header.Set(entity.GeoNameNextCursorHeader, page.NextCursor)
+	}
+	if err := stream.SetHeader(header); err != nil {
 		return err
 	}
-	return sendToStream[entity.GeoName, pb.GeoNameCityResponse](cities, GeoNameCityToPb, stream)
+	return sendToStream(page.Items, convert, stream)
+}
+
+func (c *GeoNameController) Nearest(in *pb.GeoNameNearestRequest, stream pb.GeoNameService_NearestServer) error {
+	ctx := stream.Context()
+	cities, err := c.service.Nearest(ctx, PbGeoNameNearestRequestToFilter(in))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
+	}
+	return sendToStream[entity.GeoNameNearestCity, pb.GeoNameNearestResponse](cities, GeoNameNearestCityToPb, stream)
+}
+
+func (c *GeoNameController) GeoName(ctx context.Context, in *pb.GeoNameHierarchyRequest) (*pb.GeoNameItemResponse, error) {
+	geoNameID, filter := PbGeoNameHierarchyRequestToFilter(in)
+	item, err := c.service.GeoName(ctx, geoNameID, filter)
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	return GeoNameItemToPb(item), nil
+}
+
+func (c *GeoNameController) Children(in *pb.GeoNameHierarchyRequest, stream pb.GeoNameService_ChildrenServer) error {
+	ctx := stream.Context()
+	geoNameID, filter := PbGeoNameHierarchyRequestToFilter(in)
+	children, err := c.service.Children(ctx, geoNameID, filter)
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
+	}
+	return sendToStream[entity.GeoNameItem, pb.GeoNameItemResponse](children, GeoNameItemToPb, stream)
+}
+
+func (c *GeoNameController) Ancestors(in *pb.GeoNameHierarchyRequest, stream pb.GeoNameService_AncestorsServer) error {
+	ctx := stream.Context()
+	geoNameID, filter := PbGeoNameHierarchyRequestToFilter(in)
+	ancestors, err := c.service.Ancestors(ctx, geoNameID, filter)
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return grpcError(err)
+	}
+	return sendToStream[entity.GeoNameItem, pb.GeoNameItemResponse](ancestors, GeoNameItemToPb, stream)
 }
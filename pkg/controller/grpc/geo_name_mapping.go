@@ -47,6 +47,39 @@ func GeoNameSubdivisionToPb(s *entity.GeoNameAdminSubdivision) *pb.GeoNameSubdiv
 	}
 }
 
+func GeoNameSubdivision2ToPb(s *entity.GeoNameAdminSubdivision2) *pb.GeoNameSubdivision2Response {
+	return &pb.GeoNameSubdivision2Response{
+		Code:            s.Code,
+		Name:            s.GetName(),
+		AsciiName:       s.AsciiName,
+		GeoNameId:       uint32(s.GeonameId),
+		ContinentCode:   s.ContinentCode,
+		ContinentName:   s.ContinentName,
+		CountryName:     s.CountryName,
+		SubdivisionName: s.SubdivisionName,
+	}
+}
+
+func GeoNamePostalCodeToPb(p *entity.GeoNamePostalCode) *pb.GeoNamePostalCodeResponse {
+	return &pb.GeoNamePostalCodeResponse{
+		CountryCode:   p.CountryCode,
+		PostalCode:    p.PostalCode,
+		PlaceName:     p.PlaceName,
+		AdminName1:    p.AdminName1,
+		AdminCode1:    p.AdminCode1,
+		AdminName2:    p.AdminName2,
+		AdminCode2:    p.AdminCode2,
+		AdminName3:    p.AdminName3,
+		AdminCode3:    p.AdminCode3,
+		Latitude:      p.Latitude,
+		Longitude:     p.Longitude,
+		Accuracy:      int32(p.Accuracy),
+		ContinentCode: p.ContinentCode,
+		ContinentName: p.ContinentName,
+		CountryName:   p.CountryName,
+	}
+}
+
 func GeoNameCityToPb(c *entity.GeoName) *pb.GeoNameCityResponse {
 	return &pb.GeoNameCityResponse{
 		GeoNameId:             uint32(c.Id),
@@ -66,7 +99,36 @@ func GeoNameCityToPb(c *entity.GeoName) *pb.GeoNameCityResponse {
 		Elevation:             int64(c.Elevation),
 		DigitalElevationModel: int64(c.DigitalElevationModel),
 		TimeZone:              c.Timezone,
+		ContinentCode:         c.ContinentCode,
+		ContinentName:         c.ContinentName,
+		CountryName:           c.CountryName,
+		SubdivisionName:       c.SubdivisionName,
+		Subdivision2Name:      c.Subdivision2Name,
+	}
+}
+
+func GeoNameNearestCityToPb(c *entity.GeoNameNearestCity) *pb.GeoNameNearestResponse {
+	return &pb.GeoNameNearestResponse{
+		City:       GeoNameCityToPb(c.City),
+		DistanceKm: c.DistanceKm,
+	}
+}
+
+func GeoNameItemToPb(i *entity.GeoNameItem) *pb.GeoNameItemResponse {
+	res := &pb.GeoNameItemResponse{Type: string(i.Type)}
+	switch i.Type {
+	case entity.GeoNameTypeContinent:
+		res.Item = &pb.GeoNameItemResponse_Continent{Continent: GeoNameContinentToPb(i.Continent)}
+	case entity.GeoNameTypeCountry:
+		res.Item = &pb.GeoNameItemResponse_Country{Country: GeoNameCountryToPb(i.Country)}
+	case entity.GeoNameTypeSubdivision:
+		res.Item = &pb.GeoNameItemResponse_Subdivision{Subdivision: GeoNameSubdivisionToPb(i.Subdivision)}
+	case entity.GeoNameTypeSubdivision2:
+		res.Item = &pb.GeoNameItemResponse_Subdivision2{Subdivision2: GeoNameSubdivision2ToPb(i.Subdivision2)}
+	case entity.GeoNameTypeCity:
+		res.Item = &pb.GeoNameItemResponse_City{City: GeoNameCityToPb(i.City)}
 	}
+	return res
 }
 
 func PbToGeoNameContinent(c *pb.GeoNameContinentResponse) *entity.GeoNameContinent {
@@ -110,6 +172,41 @@ func PbToGeoNameSubdivision(s *pb.GeoNameSubdivisionResponse) *entity.GeoNameAdm
 	}
 }
 
+func PbToGeoNameSubdivision2(s *pb.GeoNameSubdivision2Response) *entity.GeoNameAdminSubdivision2 {
+	return &entity.GeoNameAdminSubdivision2{
+		AdminSubdivision: &models.AdminSubdivision{
+			Code:      s.Code,
+			Name:      s.Name,
+			AsciiName: s.AsciiName,
+			GeonameId: int(s.GeoNameId),
+		},
+		ContinentCode:   s.ContinentCode,
+		ContinentName:   s.ContinentName,
+		CountryName:     s.CountryName,
+		SubdivisionName: s.SubdivisionName,
+	}
+}
+
+func PbToGeoNamePostalCode(p *pb.GeoNamePostalCodeResponse) *entity.GeoNamePostalCode {
+	return &entity.GeoNamePostalCode{
+		CountryCode:   p.CountryCode,
+		PostalCode:    p.PostalCode,
+		PlaceName:     p.PlaceName,
+		AdminName1:    p.AdminName1,
+		AdminCode1:    p.AdminCode1,
+		AdminName2:    p.AdminName2,
+		AdminCode2:    p.AdminCode2,
+		AdminName3:    p.AdminName3,
+		AdminCode3:    p.AdminCode3,
+		Latitude:      p.Latitude,
+		Longitude:     p.Longitude,
+		Accuracy:      int(p.Accuracy),
+		ContinentCode: p.ContinentCode,
+		ContinentName: p.ContinentName,
+		CountryName:   p.CountryName,
+	}
+}
+
 func PbToGeoNameCity(c *pb.GeoNameCityResponse) *entity.GeoName {
 	return &entity.GeoName{
 		Geoname: &models.Geoname{
@@ -131,15 +228,55 @@ func PbToGeoNameCity(c *pb.GeoNameCityResponse) *entity.GeoName {
 			DigitalElevationModel: int(c.DigitalElevationModel),
 			Timezone:              c.TimeZone,
 		},
+		ContinentCode:    c.ContinentCode,
+		ContinentName:    c.ContinentName,
+		CountryName:      c.CountryName,
+		SubdivisionName:  c.SubdivisionName,
+		Subdivision2Name: c.Subdivision2Name,
+	}
+}
+
+func PbToGeoNameNearestCity(c *pb.GeoNameNearestResponse) *entity.GeoNameNearestCity {
+	return &entity.GeoNameNearestCity{
+		City:       PbToGeoNameCity(c.City),
+		DistanceKm: c.DistanceKm,
 	}
 }
 
+func PbToGeoNameItem(i *pb.GeoNameItemResponse) *entity.GeoNameItem {
+	switch item := i.Item.(type) {
+	case *pb.GeoNameItemResponse_Continent:
+		return entity.NewGeoNameItem(PbToGeoNameContinent(item.Continent))
+	case *pb.GeoNameItemResponse_Country:
+		return entity.NewGeoNameItem(PbToGeoNameCountry(item.Country))
+	case *pb.GeoNameItemResponse_Subdivision:
+		return entity.NewGeoNameItem(PbToGeoNameSubdivision(item.Subdivision))
+	case *pb.GeoNameItemResponse_Subdivision2:
+		return entity.NewGeoNameItem(PbToGeoNameSubdivision2(item.Subdivision2))
+	case *pb.GeoNameItemResponse_City:
+		return entity.NewGeoNameItem(PbToGeoNameCity(item.City))
+	}
+	return &entity.GeoNameItem{Type: entity.GeoNameType(i.Type)}
+}
+
 func PbGeoNameRequestToFilter(r *pb.GeoNameRequest) entity.GeoNameFilter {
 	return entity.GeoNameFilter{
 		CountryCodes: r.CountryCodes,
 		NamePrefix:   r.NamePrefix,
+		MatchMode:    entity.GeoNameMatch(r.Match),
+		Lang:         r.Lang,
 		GeoNameIDs:   r.GeoNameIds,
+		Sort:         entity.GeoNameSort(r.Sort),
+		Offset:       r.Offset,
+		Cursor:       r.Cursor,
 		Limit:        r.Limit,
+
+		ContinentCodes:   r.ContinentCodes,
+		SubdivisionCodes: r.SubdivisionCodes,
+		FeatureCodes:     r.FeatureCodes,
+		TimeZones:        r.TimeZones,
+		MinPopulation:    int(r.MinPopulation),
+		BBox:             r.Bbox,
 	}
 }
 
@@ -147,7 +284,56 @@ func FilterToPbGeoNameRequest(f entity.GeoNameFilter) *pb.GeoNameRequest {
 	return &pb.GeoNameRequest{
 		CountryCodes: f.CountryCodes,
 		NamePrefix:   f.NamePrefix,
+		Match:        string(f.MatchMode),
+		Lang:         f.Lang,
 		GeoNameIds:   f.GeoNameIDs,
+		Sort:         string(f.Sort),
+		Offset:       f.Offset,
+		Cursor:       f.Cursor,
 		Limit:        f.Limit,
+
+		ContinentCodes:   f.ContinentCodes,
+		SubdivisionCodes: f.SubdivisionCodes,
+		FeatureCodes:     f.FeatureCodes,
+		TimeZones:        f.TimeZones,
+		MinPopulation:    int64(f.MinPopulation),
+		Bbox:             f.BBox,
+	}
+}
+
+func PbGeoNameNearestRequestToFilter(r *pb.GeoNameNearestRequest) entity.GeoNameNearestFilter {
+	return entity.GeoNameNearestFilter{
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Limit:     r.Limit,
+		RadiusKm:  r.RadiusKm,
+		Lang:      r.Lang,
+	}
+}
+
+func NearestFilterToPbGeoNameNearestRequest(f entity.GeoNameNearestFilter) *pb.GeoNameNearestRequest {
+	return &pb.GeoNameNearestRequest{
+		Latitude:  f.Latitude,
+		Longitude: f.Longitude,
+		Limit:     f.Limit,
+		RadiusKm:  f.RadiusKm,
+		Lang:      f.Lang,
+	}
+}
+
+func PbGeoNameHierarchyRequestToFilter(r *pb.GeoNameHierarchyRequest) (uint32, entity.GeoNameHierarchyFilter) {
+	return r.GeoNameId, entity.GeoNameHierarchyFilter{
+		Type:  entity.GeoNameType(r.Type),
+		Lang:  r.Lang,
+		Limit: r.Limit,
+	}
+}
+
+func HierarchyFilterToPbGeoNameHierarchyRequest(geoNameID uint32, f entity.GeoNameHierarchyFilter) *pb.GeoNameHierarchyRequest {
+	return &pb.GeoNameHierarchyRequest{
+		GeoNameId: geoNameID,
+		Type:      string(f.Type),
+		Lang:      f.Lang,
+		Limit:     f.Limit,
 	}
 }
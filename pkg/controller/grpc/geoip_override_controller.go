@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	context "context"
+
+	"github.com/bldsoft/geos/pkg/controller"
+	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
+	"github.com/bldsoft/geos/pkg/service"
+	"github.com/bldsoft/gost/log"
+)
+
+//go:generate protoc -I=../../.. --go_out=proto --go-grpc_out=proto api/grpc/geoip_override.proto
+
+type GeoIPOverrideController struct {
+	pb.UnimplementedGeoIPOverrideServiceServer
+	service controller.GeoIPOverrideService
+}
+
+func NewGeoIPOverrideController(service controller.GeoIPOverrideService) *GeoIPOverrideController {
+	return &GeoIPOverrideController{service: service}
+}
+
+func (c *GeoIPOverrideController) List(ctx context.Context, req *pb.OverrideListRequest) (*pb.OverrideListResponse, error) {
+	overrides, err := c.service.Overrides(ctx, service.DBType(req.GetDb()))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	res := &pb.OverrideListResponse{Overrides: make([]*pb.Override, 0, len(overrides))}
+	for _, override := range overrides {
+		res.Overrides = append(res.Overrides, GeoIPOverrideToPb(override))
+	}
+	return res, nil
+}
+
+func (c *GeoIPOverrideController) Get(ctx context.Context, req *pb.OverrideRequest) (*pb.Override, error) {
+	override, err := c.service.Override(ctx, service.DBType(req.GetDb()), req.GetId())
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	return GeoIPOverrideToPb(override), nil
+}
+
+func (c *GeoIPOverrideController) Add(ctx context.Context, req *pb.OverrideAddRequest) (*pb.Override, error) {
+	override, err := c.service.AddOverride(ctx, service.DBType(req.GetDb()), PbToGeoIPOverride(req.GetOverride()))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	return GeoIPOverrideToPb(override), nil
+}
+
+func (c *GeoIPOverrideController) Replace(ctx context.Context, req *pb.OverrideReplaceRequest) (*pb.Override, error) {
+	override, err := c.service.ReplaceOverride(ctx, service.DBType(req.GetDb()), req.GetId(), PbToGeoIPOverride(req.GetOverride()))
+	if err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	return GeoIPOverrideToPb(override), nil
+}
+
+func (c *GeoIPOverrideController) Delete(ctx context.Context, req *pb.OverrideRequest) (*pb.OverrideDeleteResponse, error) {
+	if err := c.service.DeleteOverride(ctx, service.DBType(req.GetDb()), req.GetId()); err != nil {
+		log.FromContext(ctx).Error(err.Error())
+		return nil, grpcError(err)
+	}
+	return &pb.OverrideDeleteResponse{}, nil
+}
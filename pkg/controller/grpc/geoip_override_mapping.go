@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"encoding/json"
+	"time"
+
+	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
+	"github.com/bldsoft/geos/pkg/entity"
+)
+
+func GeoIPOverrideToPb(override *entity.GeoIPOverride) *pb.Override {
+	res := &pb.Override{
+		Id:         override.ID,
+		Network:    override.Network,
+		RecordJson: string(override.Record),
+		Author:     override.Author,
+		Reason:     override.Reason,
+		CreatedAt:  override.CreatedAt.Unix(),
+		UpdatedAt:  override.UpdatedAt.Unix(),
+	}
+	if override.ExpiresAt != nil {
+		expiresAt := override.ExpiresAt.Unix()
+		res.ExpiresAt = &expiresAt
+	}
+	return res
+}
+
+func PbToGeoIPOverride(override *pb.Override) entity.GeoIPOverride {
+	res := entity.GeoIPOverride{
+		ID:      override.GetId(),
+		Network: override.GetNetwork(),
+		Author:  override.GetAuthor(),
+		Reason:  override.GetReason(),
+	}
+	if len(override.GetRecordJson()) > 0 {
+		res.Record = json.RawMessage(override.GetRecordJson())
+	}
+	if override.ExpiresAt != nil {
+		expiresAt := time.Unix(override.GetExpiresAt(), 0)
+		res.ExpiresAt = &expiresAt
+	}
+	return res
+}
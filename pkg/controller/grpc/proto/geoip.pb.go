@@ -72,8 +72,9 @@ type CityRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Isp     *bool  `protobuf:"varint,2,opt,name=isp,proto3,oneof" json:"isp,omitempty"`
+	Address string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Isp     *bool    `protobuf:"varint,2,opt,name=isp,proto3,oneof" json:"isp,omitempty"`
+	Enrich  []string `protobuf:"bytes,3,rep,name=enrich,proto3" json:"enrich,omitempty"` // "geonames" adds the GeoNames entities of the city GeoNameIDs
 }
 
 func (x *CityRequest) Reset() {
@@ -122,6 +123,13 @@ func (x *CityRequest) GetIsp() bool {
 	return false
 }
 
+func (x *CityRequest) GetEnrich() []string {
+	if x != nil {
+		return x.Enrich
+	}
+	return nil
+}
+
 type CityLiteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -271,6 +279,7 @@ type CityResponse struct {
 	Subdivisions       []*Subdivision      `protobuf:"bytes,8,rep,name=subdivisions,proto3" json:"subdivisions,omitempty"`
 	Traits             *Traits             `protobuf:"bytes,9,opt,name=traits,proto3" json:"traits,omitempty"`
 	Isp                *ISP                `protobuf:"bytes,10,opt,name=isp,proto3,oneof" json:"isp,omitempty"`
+	GeoNames           *CityGeoNames       `protobuf:"bytes,11,opt,name=geo_names,json=geoNames,proto3,oneof" json:"geo_names,omitempty"`
 }
 
 func (x *CityResponse) Reset() {
@@ -375,6 +384,84 @@ func (x *CityResponse) GetIsp() *ISP {
 	return nil
 }
 
+func (x *CityResponse) GetGeoNames() *CityGeoNames {
+	if x != nil {
+		return x.GeoNames
+	}
+	return nil
+}
+
+type CityGeoNames struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Continent    *GeoNameContinentResponse `protobuf:"bytes,1,opt,name=continent,proto3,oneof" json:"continent,omitempty"`
+	Country      *GeoNameCountryResponse   `protobuf:"bytes,2,opt,name=country,proto3,oneof" json:"country,omitempty"`
+	Subdivisions []*GeoNameItemResponse    `protobuf:"bytes,3,rep,name=subdivisions,proto3" json:"subdivisions,omitempty"`
+	City         *GeoNameCityResponse      `protobuf:"bytes,4,opt,name=city,proto3,oneof" json:"city,omitempty"`
+}
+
+func (x *CityGeoNames) Reset() {
+	*x = CityGeoNames{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoip_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityGeoNames) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityGeoNames) ProtoMessage() {}
+
+func (x *CityGeoNames) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoip_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityGeoNames.ProtoReflect.Descriptor instead.
+func (*CityGeoNames) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CityGeoNames) GetContinent() *GeoNameContinentResponse {
+	if x != nil {
+		return x.Continent
+	}
+	return nil
+}
+
+func (x *CityGeoNames) GetCountry() *GeoNameCountryResponse {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *CityGeoNames) GetSubdivisions() []*GeoNameItemResponse {
+	if x != nil {
+		return x.Subdivisions
+	}
+	return nil
+}
+
+func (x *CityGeoNames) GetCity() *GeoNameCityResponse {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
 type CityLiteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -388,7 +475,7 @@ type CityLiteResponse struct {
 func (x *CityLiteResponse) Reset() {
 	*x = CityLiteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[5]
+		mi := &file_api_grpc_geoip_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -401,7 +488,7 @@ func (x *CityLiteResponse) String() string {
 func (*CityLiteResponse) ProtoMessage() {}
 
 func (x *CityLiteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[5]
+	mi := &file_api_grpc_geoip_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +501,7 @@ func (x *CityLiteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CityLiteResponse.ProtoReflect.Descriptor instead.
 func (*CityLiteResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{5}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CityLiteResponse) GetCity() *CityLiteResponse_City {
@@ -451,7 +538,7 @@ type Continent struct {
 func (x *Continent) Reset() {
 	*x = Continent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[6]
+		mi := &file_api_grpc_geoip_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -464,7 +551,7 @@ func (x *Continent) String() string {
 func (*Continent) ProtoMessage() {}
 
 func (x *Continent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[6]
+	mi := &file_api_grpc_geoip_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +564,7 @@ func (x *Continent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Continent.ProtoReflect.Descriptor instead.
 func (*Continent) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{6}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Continent) GetCode() string {
@@ -515,7 +602,7 @@ type Country struct {
 func (x *Country) Reset() {
 	*x = Country{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[7]
+		mi := &file_api_grpc_geoip_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -528,7 +615,7 @@ func (x *Country) String() string {
 func (*Country) ProtoMessage() {}
 
 func (x *Country) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[7]
+	mi := &file_api_grpc_geoip_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +628,7 @@ func (x *Country) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Country.ProtoReflect.Descriptor instead.
 func (*Country) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{7}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Country) GetGeoNameId() uint32 {
@@ -587,7 +674,7 @@ type RepresentedCountry struct {
 func (x *RepresentedCountry) Reset() {
 	*x = RepresentedCountry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[8]
+		mi := &file_api_grpc_geoip_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -600,7 +687,7 @@ func (x *RepresentedCountry) String() string {
 func (*RepresentedCountry) ProtoMessage() {}
 
 func (x *RepresentedCountry) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[8]
+	mi := &file_api_grpc_geoip_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,7 +700,7 @@ func (x *RepresentedCountry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepresentedCountry.ProtoReflect.Descriptor instead.
 func (*RepresentedCountry) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{8}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RepresentedCountry) GetGeoNameId() uint32 {
@@ -663,7 +750,7 @@ type Traits struct {
 func (x *Traits) Reset() {
 	*x = Traits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[9]
+		mi := &file_api_grpc_geoip_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -676,7 +763,7 @@ func (x *Traits) String() string {
 func (*Traits) ProtoMessage() {}
 
 func (x *Traits) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[9]
+	mi := &file_api_grpc_geoip_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +776,7 @@ func (x *Traits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Traits.ProtoReflect.Descriptor instead.
 func (*Traits) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{9}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Traits) GetIsAnonymousProxy() bool {
@@ -718,7 +805,7 @@ type City struct {
 func (x *City) Reset() {
 	*x = City{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[10]
+		mi := &file_api_grpc_geoip_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -731,7 +818,7 @@ func (x *City) String() string {
 func (*City) ProtoMessage() {}
 
 func (x *City) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[10]
+	mi := &file_api_grpc_geoip_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,7 +831,7 @@ func (x *City) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use City.ProtoReflect.Descriptor instead.
 func (*City) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{10}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *City) GetGeoNameId() uint32 {
@@ -776,7 +863,7 @@ type Location struct {
 func (x *Location) Reset() {
 	*x = Location{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[11]
+		mi := &file_api_grpc_geoip_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -789,7 +876,7 @@ func (x *Location) String() string {
 func (*Location) ProtoMessage() {}
 
 func (x *Location) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[11]
+	mi := &file_api_grpc_geoip_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,7 +889,7 @@ func (x *Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Location.ProtoReflect.Descriptor instead.
 func (*Location) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{11}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Location) GetAccuracyRadius() uint32 {
@@ -851,7 +938,7 @@ type Postal struct {
 func (x *Postal) Reset() {
 	*x = Postal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[12]
+		mi := &file_api_grpc_geoip_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -864,7 +951,7 @@ func (x *Postal) String() string {
 func (*Postal) ProtoMessage() {}
 
 func (x *Postal) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[12]
+	mi := &file_api_grpc_geoip_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +964,7 @@ func (x *Postal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Postal.ProtoReflect.Descriptor instead.
 func (*Postal) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{12}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Postal) GetCode() string {
@@ -900,7 +987,7 @@ type Subdivision struct {
 func (x *Subdivision) Reset() {
 	*x = Subdivision{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[13]
+		mi := &file_api_grpc_geoip_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -913,7 +1000,7 @@ func (x *Subdivision) String() string {
 func (*Subdivision) ProtoMessage() {}
 
 func (x *Subdivision) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[13]
+	mi := &file_api_grpc_geoip_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +1013,7 @@ func (x *Subdivision) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Subdivision.ProtoReflect.Descriptor instead.
 func (*Subdivision) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{13}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Subdivision) GetGeoNameId() uint32 {
@@ -966,7 +1053,7 @@ type ISP struct {
 func (x *ISP) Reset() {
 	*x = ISP{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[14]
+		mi := &file_api_grpc_geoip_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -979,7 +1066,7 @@ func (x *ISP) String() string {
 func (*ISP) ProtoMessage() {}
 
 func (x *ISP) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[14]
+	mi := &file_api_grpc_geoip_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -992,7 +1079,7 @@ func (x *ISP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ISP.ProtoReflect.Descriptor instead.
 func (*ISP) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{14}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ISP) GetAutonomousSystemOrganization() string {
@@ -1048,7 +1135,7 @@ type CityLiteResponse_City struct {
 func (x *CityLiteResponse_City) Reset() {
 	*x = CityLiteResponse_City{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[15]
+		mi := &file_api_grpc_geoip_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1061,7 +1148,7 @@ func (x *CityLiteResponse_City) String() string {
 func (*CityLiteResponse_City) ProtoMessage() {}
 
 func (x *CityLiteResponse_City) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[15]
+	mi := &file_api_grpc_geoip_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,7 +1161,7 @@ func (x *CityLiteResponse_City) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CityLiteResponse_City.ProtoReflect.Descriptor instead.
 func (*CityLiteResponse_City) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{5, 0}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *CityLiteResponse_City) GetName() string {
@@ -1096,7 +1183,7 @@ type CityLiteResponse_Country struct {
 func (x *CityLiteResponse_Country) Reset() {
 	*x = CityLiteResponse_Country{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[16]
+		mi := &file_api_grpc_geoip_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1109,7 +1196,7 @@ func (x *CityLiteResponse_Country) String() string {
 func (*CityLiteResponse_Country) ProtoMessage() {}
 
 func (x *CityLiteResponse_Country) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[16]
+	mi := &file_api_grpc_geoip_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1122,7 +1209,7 @@ func (x *CityLiteResponse_Country) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CityLiteResponse_Country.ProtoReflect.Descriptor instead.
 func (*CityLiteResponse_Country) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{5, 1}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{6, 1}
 }
 
 func (x *CityLiteResponse_Country) GetIsoCode() string {
@@ -1152,7 +1239,7 @@ type CityLiteResponse_Location struct {
 func (x *CityLiteResponse_Location) Reset() {
 	*x = CityLiteResponse_Location{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoip_proto_msgTypes[17]
+		mi := &file_api_grpc_geoip_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1165,7 +1252,7 @@ func (x *CityLiteResponse_Location) String() string {
 func (*CityLiteResponse_Location) ProtoMessage() {}
 
 func (x *CityLiteResponse_Location) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoip_proto_msgTypes[17]
+	mi := &file_api_grpc_geoip_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1178,7 +1265,7 @@ func (x *CityLiteResponse_Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CityLiteResponse_Location.ProtoReflect.Descriptor instead.
 func (*CityLiteResponse_Location) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{5, 2}
+	return file_api_grpc_geoip_proto_rawDescGZIP(), []int{6, 2}
 }
 
 func (x *CityLiteResponse_Location) GetLatitude() float64 {
@@ -1206,14 +1293,17 @@ var File_api_grpc_geoip_proto protoreflect.FileDescriptor
 
 var file_api_grpc_geoip_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6f, 0x69, 0x70,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x22, 0x2a, 0x0a,
-	0x0e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x46, 0x0a, 0x0b, 0x43, 0x69, 0x74,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x69, 0x73, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48,
-	0x00, 0x52, 0x03, 0x69, 0x73, 0x70, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x69, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x1a, 0x16, 0x61,
+	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2a, 0x0a, 0x0e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x5e, 0x0a, 0x0b, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x69, 0x73,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x03, 0x69, 0x73, 0x70, 0x88, 0x01,
+	0x01, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x65, 0x6e, 0x72, 0x69, 0x63, 0x68, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x69, 0x73,
 	0x70, 0x22, 0x3f, 0x0a, 0x0f, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
@@ -1236,7 +1326,7 @@ var file_api_grpc_geoip_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x06, 0x74,
 	0x72, 0x61, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x65,
 	0x6f, 0x69, 0x70, 0x2e, 0x54, 0x72, 0x61, 0x69, 0x74, 0x73, 0x52, 0x06, 0x74, 0x72, 0x61, 0x69,
-	0x74, 0x73, 0x22, 0xf2, 0x03, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x74, 0x73, 0x22, 0xb7, 0x04, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
 	0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x04,
 	0x63, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e,
@@ -1266,144 +1356,168 @@ var file_api_grpc_geoip_proto_rawDesc = []byte{
 	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x54, 0x72,
 	0x61, 0x69, 0x74, 0x73, 0x52, 0x06, 0x74, 0x72, 0x61, 0x69, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x03,
 	0x69, 0x73, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x65, 0x6f, 0x69,
-	0x70, 0x2e, 0x49, 0x53, 0x50, 0x48, 0x00, 0x52, 0x03, 0x69, 0x73, 0x70, 0x88, 0x01, 0x01, 0x42,
-	0x06, 0x0a, 0x04, 0x5f, 0x69, 0x73, 0x70, 0x22, 0xf6, 0x02, 0x0a, 0x10, 0x43, 0x69, 0x74, 0x79,
-	0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x04,
-	0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f,
-	0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x39,
-	0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3c, 0x0a, 0x08, 0x6c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x65,
-	0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1a, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x1a, 0x38, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x19,
-	0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x61, 0x0a,
-	0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
-	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
-	0x75, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65,
-	0x22, 0xac, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65,
-	0x49, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
-	0x65, 0x6e, 0x74, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0xe0, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x67,
-	0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x14, 0x69,
-	0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x65, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x5f, 0x75, 0x6e,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x49, 0x6e, 0x45,
-	0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08,
-	0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x8a, 0x02, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74,
-	0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x14, 0x69, 0x73, 0x5f,
-	0x69, 0x6e, 0x5f, 0x65, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x49, 0x6e, 0x45, 0x75, 0x72,
-	0x6f, 0x70, 0x65, 0x61, 0x6e, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73,
-	0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73,
-	0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x52, 0x65, 0x70,
-	0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x6a, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x69, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x73, 0x5f,
-	0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x73, 0x41, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f,
-	0x75, 0x73, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x73, 0x5f, 0x73, 0x61,
-	0x74, 0x65, 0x6c, 0x6c, 0x69, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x69, 0x73, 0x53, 0x61, 0x74, 0x65, 0x6c, 0x6c,
-	0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x22, 0x8e, 0x01, 0x0a, 0x04,
-	0x43, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61,
-	0x6d, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79,
-	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d,
-	0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa9, 0x01, 0x0a,
-	0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63,
-	0x75, 0x72, 0x61, 0x63, 0x79, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x52, 0x61, 0x64, 0x69,
-	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6d, 0x65, 0x74, 0x72, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x6d, 0x65, 0x74, 0x72, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x70, 0x2e, 0x49, 0x53, 0x50, 0x48, 0x00, 0x52, 0x03, 0x69, 0x73, 0x70, 0x88, 0x01, 0x01, 0x12,
+	0x35, 0x0a, 0x09, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x47,
+	0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x48, 0x01, 0x52, 0x08, 0x67, 0x65, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x69, 0x73, 0x70, 0x42, 0x0c,
+	0x0a, 0x0a, 0x5f, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0xb0, 0x02, 0x0a,
+	0x0c, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x44, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47,
+	0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x01, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e,
+	0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65,
+	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x02, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x69, 0x74, 0x79, 0x22,
+	0xf6, 0x02, 0x0a, 0x10, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x4c,
+	0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x69, 0x74, 0x79,
+	0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x39, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e,
+	0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x3c, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79,
+	0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x1a, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x38, 0x0a, 0x07, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x61, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
 	0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0x1c, 0x0a, 0x06, 0x50, 0x6f, 0x73, 0x74,
-	0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xb7, 0x01, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x64, 0x69,
-	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f,
-	0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64,
-	0x65, 0x12, 0x33, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69,
-	0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x9b, 0x02, 0x0a, 0x03, 0x49, 0x53, 0x50, 0x12, 0x44, 0x0a, 0x1e, 0x61, 0x75, 0x74, 0x6f,
-	0x6e, 0x6f, 0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6f, 0x72,
-	0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x1c, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f, 0x75, 0x73, 0x53, 0x79, 0x73, 0x74,
-	0x65, 0x6d, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10,
-	0x0a, 0x03, 0x69, 0x73, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x73, 0x70,
-	0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d,
-	0x6f, 0x62, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65,
-	0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
-	0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d,
-	0x6f, 0x62, 0x69, 0x6c, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x64, 0x65,
-	0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f,
-	0x75, 0x73, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f,
-	0x75, 0x73, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x32, 0xb6,
-	0x01, 0x0a, 0x0c, 0x47, 0x65, 0x6f, 0x49, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x38, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x65, 0x6f,
-	0x69, 0x70, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x43, 0x69, 0x74,
-	0x79, 0x12, 0x12, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69,
-	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x43, 0x69,
-	0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43,
-	0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e,
+	0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65,
+	0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x65, 0x6f, 0x69,
+	0x70, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x2e, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a,
+	0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe0, 0x01, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x14, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x65, 0x75, 0x72,
+	0x6f, 0x70, 0x65, 0x61, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x11, 0x69, 0x73, 0x49, 0x6e, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x55,
+	0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x2f, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8a, 0x02, 0x0a, 0x12, 0x52,
+	0x65, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49,
+	0x64, 0x12, 0x2f, 0x0a, 0x14, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x5f, 0x65, 0x75, 0x72, 0x6f, 0x70,
+	0x65, 0x61, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x11, 0x69, 0x73, 0x49, 0x6e, 0x45, 0x75, 0x72, 0x6f, 0x70, 0x65, 0x61, 0x6e, 0x55, 0x6e, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67,
+	0x65, 0x6f, 0x69, 0x70, 0x2e, 0x52, 0x65, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x38, 0x0a,
+	0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x6a, 0x0a, 0x06, 0x54, 0x72, 0x61, 0x69, 0x74,
+	0x73, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x73, 0x5f, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75,
+	0x73, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69,
+	0x73, 0x41, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12,
+	0x32, 0x0a, 0x15, 0x69, 0x73, 0x5f, 0x73, 0x61, 0x74, 0x65, 0x6c, 0x6c, 0x69, 0x74, 0x65, 0x5f,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13,
+	0x69, 0x73, 0x53, 0x61, 0x74, 0x65, 0x6c, 0x6c, 0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x22, 0x8e, 0x01, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0b,
+	0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x05,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x65,
+	0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xa9, 0x01, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x5f, 0x72, 0x61,
+	0x64, 0x69, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x75,
+	0x72, 0x61, 0x63, 0x79, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x6f, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x65, 0x74, 0x72, 0x6f, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65,
+	0x22, 0x1c, 0x0a, 0x06, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xb7,
+	0x01, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
+	0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70,
+	0x2e, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38,
+	0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9b, 0x02, 0x0a, 0x03, 0x49, 0x53, 0x50,
+	0x12, 0x44, 0x0a, 0x1e, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1c, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f,
+	0x6d, 0x6f, 0x75, 0x73, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x73, 0x70, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x69, 0x73, 0x70, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x6f, 0x62, 0x69,
+	0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x6f, 0x62, 0x69,
+	0x6c, 0x65, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x6f, 0x62, 0x69, 0x6c, 0x65, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x18,
+	0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16,
+	0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x6f, 0x75, 0x73, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x32, 0xb6, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x6f, 0x49, 0x70,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x15, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x65, 0x6f, 0x69,
+	0x70, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x12, 0x2e, 0x67, 0x65, 0x6f, 0x69,
+	0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x12, 0x16,
 	0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x69, 0x70, 0x2e, 0x43,
+	0x69, 0x74, 0x79, 0x4c, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1418,67 +1532,77 @@ func file_api_grpc_geoip_proto_rawDescGZIP() []byte {
 	return file_api_grpc_geoip_proto_rawDescData
 }
 
-var file_api_grpc_geoip_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_grpc_geoip_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_api_grpc_geoip_proto_goTypes = []interface{}{
 	(*CountryRequest)(nil),            // 0: geoip.CountryRequest
 	(*CityRequest)(nil),               // 1: geoip.CityRequest
 	(*CityLiteRequest)(nil),           // 2: geoip.CityLiteRequest
 	(*CountryResponse)(nil),           // 3: geoip.CountryResponse
 	(*CityResponse)(nil),              // 4: geoip.CityResponse
-	(*CityLiteResponse)(nil),          // 5: geoip.CityLiteResponse
-	(*Continent)(nil),                 // 6: geoip.Continent
-	(*Country)(nil),                   // 7: geoip.Country
-	(*RepresentedCountry)(nil),        // 8: geoip.RepresentedCountry
-	(*Traits)(nil),                    // 9: geoip.Traits
-	(*City)(nil),                      // 10: geoip.City
-	(*Location)(nil),                  // 11: geoip.Location
-	(*Postal)(nil),                    // 12: geoip.Postal
-	(*Subdivision)(nil),               // 13: geoip.Subdivision
-	(*ISP)(nil),                       // 14: geoip.ISP
-	(*CityLiteResponse_City)(nil),     // 15: geoip.CityLiteResponse.City
-	(*CityLiteResponse_Country)(nil),  // 16: geoip.CityLiteResponse.Country
-	(*CityLiteResponse_Location)(nil), // 17: geoip.CityLiteResponse.Location
-	nil,                               // 18: geoip.Continent.NamesEntry
-	nil,                               // 19: geoip.Country.NamesEntry
-	nil,                               // 20: geoip.RepresentedCountry.NamesEntry
-	nil,                               // 21: geoip.City.NamesEntry
-	nil,                               // 22: geoip.Subdivision.NamesEntry
+	(*CityGeoNames)(nil),              // 5: geoip.CityGeoNames
+	(*CityLiteResponse)(nil),          // 6: geoip.CityLiteResponse
+	(*Continent)(nil),                 // 7: geoip.Continent
+	(*Country)(nil),                   // 8: geoip.Country
+	(*RepresentedCountry)(nil),        // 9: geoip.RepresentedCountry
+	(*Traits)(nil),                    // 10: geoip.Traits
+	(*City)(nil),                      // 11: geoip.City
+	(*Location)(nil),                  // 12: geoip.Location
+	(*Postal)(nil),                    // 13: geoip.Postal
+	(*Subdivision)(nil),               // 14: geoip.Subdivision
+	(*ISP)(nil),                       // 15: geoip.ISP
+	(*CityLiteResponse_City)(nil),     // 16: geoip.CityLiteResponse.City
+	(*CityLiteResponse_Country)(nil),  // 17: geoip.CityLiteResponse.Country
+	(*CityLiteResponse_Location)(nil), // 18: geoip.CityLiteResponse.Location
+	nil,                               // 19: geoip.Continent.NamesEntry
+	nil,                               // 20: geoip.Country.NamesEntry
+	nil,                               // 21: geoip.RepresentedCountry.NamesEntry
+	nil,                               // 22: geoip.City.NamesEntry
+	nil,                               // 23: geoip.Subdivision.NamesEntry
+	(*GeoNameContinentResponse)(nil),  // 24: geoname.GeoNameContinentResponse
+	(*GeoNameCountryResponse)(nil),    // 25: geoname.GeoNameCountryResponse
+	(*GeoNameItemResponse)(nil),       // 26: geoname.GeoNameItemResponse
+	(*GeoNameCityResponse)(nil),       // 27: geoname.GeoNameCityResponse
 }
 var file_api_grpc_geoip_proto_depIdxs = []int32{
-	6,  // 0: geoip.CountryResponse.continent:type_name -> geoip.Continent
-	7,  // 1: geoip.CountryResponse.country:type_name -> geoip.Country
-	7,  // 2: geoip.CountryResponse.registered_country:type_name -> geoip.Country
-	8,  // 3: geoip.CountryResponse.represented_country:type_name -> geoip.RepresentedCountry
-	9,  // 4: geoip.CountryResponse.traits:type_name -> geoip.Traits
-	10, // 5: geoip.CityResponse.city:type_name -> geoip.City
-	6,  // 6: geoip.CityResponse.continent:type_name -> geoip.Continent
-	7,  // 7: geoip.CityResponse.country:type_name -> geoip.Country
-	11, // 8: geoip.CityResponse.location:type_name -> geoip.Location
-	12, // 9: geoip.CityResponse.postal:type_name -> geoip.Postal
-	7,  // 10: geoip.CityResponse.registered_country:type_name -> geoip.Country
-	8,  // 11: geoip.CityResponse.represented_country:type_name -> geoip.RepresentedCountry
-	13, // 12: geoip.CityResponse.subdivisions:type_name -> geoip.Subdivision
-	9,  // 13: geoip.CityResponse.traits:type_name -> geoip.Traits
-	14, // 14: geoip.CityResponse.isp:type_name -> geoip.ISP
-	15, // 15: geoip.CityLiteResponse.city:type_name -> geoip.CityLiteResponse.City
-	16, // 16: geoip.CityLiteResponse.country:type_name -> geoip.CityLiteResponse.Country
-	17, // 17: geoip.CityLiteResponse.location:type_name -> geoip.CityLiteResponse.Location
-	18, // 18: geoip.Continent.names:type_name -> geoip.Continent.NamesEntry
-	19, // 19: geoip.Country.names:type_name -> geoip.Country.NamesEntry
-	20, // 20: geoip.RepresentedCountry.names:type_name -> geoip.RepresentedCountry.NamesEntry
-	21, // 21: geoip.City.names:type_name -> geoip.City.NamesEntry
-	22, // 22: geoip.Subdivision.names:type_name -> geoip.Subdivision.NamesEntry
-	0,  // 23: geoip.GeoIpService.Country:input_type -> geoip.CountryRequest
-	1,  // 24: geoip.GeoIpService.City:input_type -> geoip.CityRequest
-	2,  // 25: geoip.GeoIpService.CityLite:input_type -> geoip.CityLiteRequest
-	3,  // 26: geoip.GeoIpService.Country:output_type -> geoip.CountryResponse
-	4,  // 27: geoip.GeoIpService.City:output_type -> geoip.CityResponse
-	5,  // 28: geoip.GeoIpService.CityLite:output_type -> geoip.CityLiteResponse
-	26, // [26:29] is the sub-list for method output_type
-	23, // [23:26] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	7,  // 0: geoip.CountryResponse.continent:type_name -> geoip.Continent
+	8,  // 1: geoip.CountryResponse.country:type_name -> geoip.Country
+	8,  // 2: geoip.CountryResponse.registered_country:type_name -> geoip.Country
+	9,  // 3: geoip.CountryResponse.represented_country:type_name -> geoip.RepresentedCountry
+	10, // 4: geoip.CountryResponse.traits:type_name -> geoip.Traits
+	11, // 5: geoip.CityResponse.city:type_name -> geoip.City
+	7,  // 6: geoip.CityResponse.continent:type_name -> geoip.Continent
+	8,  // 7: geoip.CityResponse.country:type_name -> geoip.Country
+	12, // 8: geoip.CityResponse.location:type_name -> geoip.Location
+	13, // 9: geoip.CityResponse.postal:type_name -> geoip.Postal
+	8,  // 10: geoip.CityResponse.registered_country:type_name -> geoip.Country
+	9,  // 11: geoip.CityResponse.represented_country:type_name -> geoip.RepresentedCountry
+	14, // 12: geoip.CityResponse.subdivisions:type_name -> geoip.Subdivision
+	10, // 13: geoip.CityResponse.traits:type_name -> geoip.Traits
+	15, // 14: geoip.CityResponse.isp:type_name -> geoip.ISP
+	5,  // 15: geoip.CityResponse.geo_names:type_name -> geoip.CityGeoNames
+	24, // 16: geoip.CityGeoNames.continent:type_name -> geoname.GeoNameContinentResponse
+	25, // 17: geoip.CityGeoNames.country:type_name -> geoname.GeoNameCountryResponse
+	26, // 18: geoip.CityGeoNames.subdivisions:type_name -> geoname.GeoNameItemResponse
+	27, // 19: geoip.CityGeoNames.city:type_name -> geoname.GeoNameCityResponse
+	16, // 20: geoip.CityLiteResponse.city:type_name -> geoip.CityLiteResponse.City
+	17, // 21: geoip.CityLiteResponse.country:type_name -> geoip.CityLiteResponse.Country
+	18, // 22: geoip.CityLiteResponse.location:type_name -> geoip.CityLiteResponse.Location
+	19, // 23: geoip.Continent.names:type_name -> geoip.Continent.NamesEntry
+	20, // 24: geoip.Country.names:type_name -> geoip.Country.NamesEntry
+	21, // 25: geoip.RepresentedCountry.names:type_name -> geoip.RepresentedCountry.NamesEntry
+	22, // 26: geoip.City.names:type_name -> geoip.City.NamesEntry
+	23, // 27: geoip.Subdivision.names:type_name -> geoip.Subdivision.NamesEntry
+	0,  // 28: geoip.GeoIpService.Country:input_type -> geoip.CountryRequest
+	1,  // 29: geoip.GeoIpService.City:input_type -> geoip.CityRequest
+	2,  // 30: geoip.GeoIpService.CityLite:input_type -> geoip.CityLiteRequest
+	3,  // 31: geoip.GeoIpService.Country:output_type -> geoip.CountryResponse
+	4,  // 32: geoip.GeoIpService.City:output_type -> geoip.CityResponse
+	6,  // 33: geoip.GeoIpService.CityLite:output_type -> geoip.CityLiteResponse
+	31, // [31:34] is the sub-list for method output_type
+	28, // [28:31] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_geoip_proto_init() }
@@ -1486,6 +1610,7 @@ func file_api_grpc_geoip_proto_init() {
 	if File_api_grpc_geoip_proto != nil {
 		return
 	}
+	file_api_grpc_geoname_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_api_grpc_geoip_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CountryRequest); i {
@@ -1548,7 +1673,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CityLiteResponse); i {
+			switch v := v.(*CityGeoNames); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,7 +1685,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Continent); i {
+			switch v := v.(*CityLiteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,7 +1697,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Country); i {
+			switch v := v.(*Continent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,7 +1709,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RepresentedCountry); i {
+			switch v := v.(*Country); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,7 +1721,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Traits); i {
+			switch v := v.(*RepresentedCountry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1608,7 +1733,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*City); i {
+			switch v := v.(*Traits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1620,7 +1745,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Location); i {
+			switch v := v.(*City); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1632,7 +1757,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Postal); i {
+			switch v := v.(*Location); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1644,7 +1769,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Subdivision); i {
+			switch v := v.(*Postal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1656,7 +1781,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ISP); i {
+			switch v := v.(*Subdivision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1668,7 +1793,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CityLiteResponse_City); i {
+			switch v := v.(*ISP); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1680,7 +1805,7 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CityLiteResponse_Country); i {
+			switch v := v.(*CityLiteResponse_City); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1692,6 +1817,18 @@ func file_api_grpc_geoip_proto_init() {
 			}
 		}
 		file_api_grpc_geoip_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityLiteResponse_Country); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoip_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CityLiteResponse_Location); i {
 			case 0:
 				return &v.state
@@ -1706,13 +1843,14 @@ func file_api_grpc_geoip_proto_init() {
 	}
 	file_api_grpc_geoip_proto_msgTypes[1].OneofWrappers = []interface{}{}
 	file_api_grpc_geoip_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_api_grpc_geoip_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_grpc_geoip_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.15.8
+// source: api/grpc/geoip_override.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GeoIPOverrideServiceClient is the client API for GeoIPOverrideService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GeoIPOverrideServiceClient interface {
+	List(ctx context.Context, in *OverrideListRequest, opts ...grpc.CallOption) (*OverrideListResponse, error)
+	Get(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*Override, error)
+	Add(ctx context.Context, in *OverrideAddRequest, opts ...grpc.CallOption) (*Override, error)
+	Replace(ctx context.Context, in *OverrideReplaceRequest, opts ...grpc.CallOption) (*Override, error)
+	Delete(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*OverrideDeleteResponse, error)
+}
+
+type geoIPOverrideServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeoIPOverrideServiceClient(cc grpc.ClientConnInterface) GeoIPOverrideServiceClient {
+	return &geoIPOverrideServiceClient{cc}
+}
+
+func (c *geoIPOverrideServiceClient) List(ctx context.Context, in *OverrideListRequest, opts ...grpc.CallOption) (*OverrideListResponse, error) {
+	out := new(OverrideListResponse)
+	err := c.cc.Invoke(ctx, "/geoip.GeoIPOverrideService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoIPOverrideServiceClient) Get(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*Override, error) {
+	out := new(Override)
+	err := c.cc.Invoke(ctx, "/geoip.GeoIPOverrideService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoIPOverrideServiceClient) Add(ctx context.Context, in *OverrideAddRequest, opts ...grpc.CallOption) (*Override, error) {
+	out := new(Override)
+	err := c.cc.Invoke(ctx, "/geoip.GeoIPOverrideService/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoIPOverrideServiceClient) Replace(ctx context.Context, in *OverrideReplaceRequest, opts ...grpc.CallOption) (*Override, error) {
+	out := new(Override)
+	err := c.cc.Invoke(ctx, "/geoip.GeoIPOverrideService/Replace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoIPOverrideServiceClient) Delete(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*OverrideDeleteResponse, error) {
+	out := new(OverrideDeleteResponse)
+	err := c.cc.Invoke(ctx, "/geoip.GeoIPOverrideService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GeoIPOverrideServiceServer is the server API for GeoIPOverrideService service.
+// All implementations must embed UnimplementedGeoIPOverrideServiceServer
+// for forward compatibility
+type GeoIPOverrideServiceServer interface {
+	List(context.Context, *OverrideListRequest) (*OverrideListResponse, error)
+	Get(context.Context, *OverrideRequest) (*Override, error)
+	Add(context.Context, *OverrideAddRequest) (*Override, error)
+	Replace(context.Context, *OverrideReplaceRequest) (*Override, error)
+	Delete(context.Context, *OverrideRequest) (*OverrideDeleteResponse, error)
+	mustEmbedUnimplementedGeoIPOverrideServiceServer()
+}
+
+// UnimplementedGeoIPOverrideServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGeoIPOverrideServiceServer struct {
+}
+
+func (UnimplementedGeoIPOverrideServiceServer) List(context.Context, *OverrideListRequest) (*OverrideListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedGeoIPOverrideServiceServer) Get(context.Context, *OverrideRequest) (*Override, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedGeoIPOverrideServiceServer) Add(context.Context, *OverrideAddRequest) (*Override, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedGeoIPOverrideServiceServer) Replace(context.Context, *OverrideReplaceRequest) (*Override, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (UnimplementedGeoIPOverrideServiceServer) Delete(context.Context, *OverrideRequest) (*OverrideDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedGeoIPOverrideServiceServer) mustEmbedUnimplementedGeoIPOverrideServiceServer() {}
+
+// UnsafeGeoIPOverrideServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeoIPOverrideServiceServer will
+// result in compilation errors.
+type UnsafeGeoIPOverrideServiceServer interface {
+	mustEmbedUnimplementedGeoIPOverrideServiceServer()
+}
+
+func RegisterGeoIPOverrideServiceServer(s grpc.ServiceRegistrar, srv GeoIPOverrideServiceServer) {
+	s.RegisterService(&GeoIPOverrideService_ServiceDesc, srv)
+}
+
+func _GeoIPOverrideService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoIPOverrideServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoip.GeoIPOverrideService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoIPOverrideServiceServer).List(ctx, req.(*OverrideListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoIPOverrideService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoIPOverrideServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoip.GeoIPOverrideService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoIPOverrideServiceServer).Get(ctx, req.(*OverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoIPOverrideService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoIPOverrideServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoip.GeoIPOverrideService/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoIPOverrideServiceServer).Add(ctx, req.(*OverrideAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoIPOverrideService_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideReplaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoIPOverrideServiceServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoip.GeoIPOverrideService/Replace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoIPOverrideServiceServer).Replace(ctx, req.(*OverrideReplaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoIPOverrideService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoIPOverrideServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoip.GeoIPOverrideService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoIPOverrideServiceServer).Delete(ctx, req.(*OverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GeoIPOverrideService_ServiceDesc is the grpc.ServiceDesc for GeoIPOverrideService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GeoIPOverrideService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "geoip.GeoIPOverrideService",
+	HandlerType: (*GeoIPOverrideServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _GeoIPOverrideService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _GeoIPOverrideService_Get_Handler,
+		},
+		{
+			MethodName: "Add",
+			Handler:    _GeoIPOverrideService_Add_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _GeoIPOverrideService_Replace_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _GeoIPOverrideService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/grpc/geoip_override.proto",
+}
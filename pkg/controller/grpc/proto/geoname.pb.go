@@ -25,10 +25,21 @@ type GeoNameRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CountryCodes []string `protobuf:"bytes,1,rep,name=country_codes,json=countryCodes,proto3" json:"country_codes,omitempty"`
-	NamePrefix   string   `protobuf:"bytes,2,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
-	Limit        uint32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	GeoNameIds   []uint32 `protobuf:"varint,4,rep,packed,name=geo_name_ids,json=geoNameIds,proto3" json:"geo_name_ids,omitempty"`
+	CountryCodes     []string  `protobuf:"bytes,1,rep,name=country_codes,json=countryCodes,proto3" json:"country_codes,omitempty"`
+	NamePrefix       string    `protobuf:"bytes,2,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	Limit            uint32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	GeoNameIds       []uint32  `protobuf:"varint,4,rep,packed,name=geo_name_ids,json=geoNameIds,proto3" json:"geo_name_ids,omitempty"`
+	Match            string    `protobuf:"bytes,5,opt,name=match,proto3" json:"match,omitempty"` // prefix (default), exact or fuzzy
+	Lang             string    `protobuf:"bytes,6,opt,name=lang,proto3" json:"lang,omitempty"`   // the language of the names, the names aren't translated if empty
+	Offset           uint32    `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	Sort             string    `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`     // name, population or geonameid
+	Cursor           string    `protobuf:"bytes,9,opt,name=cursor,proto3" json:"cursor,omitempty"` // x-next-cursor header of the previous page, the offset is ignored if it's set
+	ContinentCodes   []string  `protobuf:"bytes,10,rep,name=continent_codes,json=continentCodes,proto3" json:"continent_codes,omitempty"`
+	SubdivisionCodes []string  `protobuf:"bytes,11,rep,name=subdivision_codes,json=subdivisionCodes,proto3" json:"subdivision_codes,omitempty"` // <country code>.<admin1 code>
+	FeatureCodes     []string  `protobuf:"bytes,12,rep,name=feature_codes,json=featureCodes,proto3" json:"feature_codes,omitempty"`
+	TimeZones        []string  `protobuf:"bytes,13,rep,name=time_zones,json=timeZones,proto3" json:"time_zones,omitempty"`
+	MinPopulation    int64     `protobuf:"varint,14,opt,name=min_population,json=minPopulation,proto3" json:"min_population,omitempty"`
+	Bbox             []float64 `protobuf:"fixed64,15,rep,packed,name=bbox,proto3" json:"bbox,omitempty"` // west, south, east, north
 }
 
 func (x *GeoNameRequest) Reset() {
@@ -91,6 +102,233 @@ func (x *GeoNameRequest) GetGeoNameIds() []uint32 {
 	return nil
 }
 
+func (x *GeoNameRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *GeoNameRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GeoNameRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GeoNameRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GeoNameRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GeoNameRequest) GetContinentCodes() []string {
+	if x != nil {
+		return x.ContinentCodes
+	}
+	return nil
+}
+
+func (x *GeoNameRequest) GetSubdivisionCodes() []string {
+	if x != nil {
+		return x.SubdivisionCodes
+	}
+	return nil
+}
+
+func (x *GeoNameRequest) GetFeatureCodes() []string {
+	if x != nil {
+		return x.FeatureCodes
+	}
+	return nil
+}
+
+func (x *GeoNameRequest) GetTimeZones() []string {
+	if x != nil {
+		return x.TimeZones
+	}
+	return nil
+}
+
+func (x *GeoNameRequest) GetMinPopulation() int64 {
+	if x != nil {
+		return x.MinPopulation
+	}
+	return 0
+}
+
+func (x *GeoNameRequest) GetBbox() []float64 {
+	if x != nil {
+		return x.Bbox
+	}
+	return nil
+}
+
+type GeoNameNearestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Limit     uint32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	RadiusKm  float64 `protobuf:"fixed64,4,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Lang      string  `protobuf:"bytes,5,opt,name=lang,proto3" json:"lang,omitempty"`
+}
+
+func (x *GeoNameNearestRequest) Reset() {
+	*x = GeoNameNearestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameNearestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameNearestRequest) ProtoMessage() {}
+
+func (x *GeoNameNearestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameNearestRequest.ProtoReflect.Descriptor instead.
+func (*GeoNameNearestRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GeoNameNearestRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoNameNearestRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GeoNameNearestRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GeoNameNearestRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *GeoNameNearestRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type GeoNameHierarchyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GeoNameId uint32 `protobuf:"varint,1,opt,name=geo_name_id,json=geoNameId,proto3" json:"geo_name_id,omitempty"`
+	Type      string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // continent, country, subdivision, subdivision2 or city, detected if empty
+	Lang      string `protobuf:"bytes,3,opt,name=lang,proto3" json:"lang,omitempty"`
+	Limit     uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // the max number of children
+}
+
+func (x *GeoNameHierarchyRequest) Reset() {
+	*x = GeoNameHierarchyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameHierarchyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameHierarchyRequest) ProtoMessage() {}
+
+func (x *GeoNameHierarchyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameHierarchyRequest.ProtoReflect.Descriptor instead.
+func (*GeoNameHierarchyRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeoNameHierarchyRequest) GetGeoNameId() uint32 {
+	if x != nil {
+		return x.GeoNameId
+	}
+	return 0
+}
+
+func (x *GeoNameHierarchyRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GeoNameHierarchyRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *GeoNameHierarchyRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type GeoNameCountryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -120,7 +358,7 @@ type GeoNameCountryResponse struct {
 func (x *GeoNameCountryResponse) Reset() {
 	*x = GeoNameCountryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoname_proto_msgTypes[1]
+		mi := &file_api_grpc_geoname_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -133,7 +371,7 @@ func (x *GeoNameCountryResponse) String() string {
 func (*GeoNameCountryResponse) ProtoMessage() {}
 
 func (x *GeoNameCountryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoname_proto_msgTypes[1]
+	mi := &file_api_grpc_geoname_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -146,7 +384,7 @@ func (x *GeoNameCountryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeoNameCountryResponse.ProtoReflect.Descriptor instead.
 func (*GeoNameCountryResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{1}
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GeoNameCountryResponse) GetIsoCode() string {
@@ -296,61 +534,323 @@ type GeoNameSubdivisionResponse struct {
 func (x *GeoNameSubdivisionResponse) Reset() {
 	*x = GeoNameSubdivisionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoname_proto_msgTypes[2]
+		mi := &file_api_grpc_geoname_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameSubdivisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameSubdivisionResponse) ProtoMessage() {}
+
+func (x *GeoNameSubdivisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameSubdivisionResponse.ProtoReflect.Descriptor instead.
+func (*GeoNameSubdivisionResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GeoNameSubdivisionResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivisionResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivisionResponse) GetAsciiName() string {
+	if x != nil {
+		return x.AsciiName
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivisionResponse) GetGeoNameId() uint32 {
+	if x != nil {
+		return x.GeoNameId
+	}
+	return 0
+}
+
+type GeoNameSubdivision2Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code            string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AsciiName       string `protobuf:"bytes,3,opt,name=ascii_name,json=asciiName,proto3" json:"ascii_name,omitempty"`
+	GeoNameId       uint32 `protobuf:"varint,4,opt,name=geo_name_id,json=geoNameId,proto3" json:"geo_name_id,omitempty"`
+	ContinentCode   string `protobuf:"bytes,5,opt,name=continent_code,json=continentCode,proto3" json:"continent_code,omitempty"`
+	ContinentName   string `protobuf:"bytes,6,opt,name=continent_name,json=continentName,proto3" json:"continent_name,omitempty"`
+	CountryName     string `protobuf:"bytes,7,opt,name=country_name,json=countryName,proto3" json:"country_name,omitempty"`
+	SubdivisionName string `protobuf:"bytes,8,opt,name=subdivision_name,json=subdivisionName,proto3" json:"subdivision_name,omitempty"`
+}
+
+func (x *GeoNameSubdivision2Response) Reset() {
+	*x = GeoNameSubdivision2Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameSubdivision2Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameSubdivision2Response) ProtoMessage() {}
+
+func (x *GeoNameSubdivision2Response) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameSubdivision2Response.ProtoReflect.Descriptor instead.
+func (*GeoNameSubdivision2Response) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GeoNameSubdivision2Response) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetAsciiName() string {
+	if x != nil {
+		return x.AsciiName
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetGeoNameId() uint32 {
+	if x != nil {
+		return x.GeoNameId
+	}
+	return 0
+}
+
+func (x *GeoNameSubdivision2Response) GetContinentCode() string {
+	if x != nil {
+		return x.ContinentCode
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetContinentName() string {
+	if x != nil {
+		return x.ContinentName
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetCountryName() string {
+	if x != nil {
+		return x.CountryName
+	}
+	return ""
+}
+
+func (x *GeoNameSubdivision2Response) GetSubdivisionName() string {
+	if x != nil {
+		return x.SubdivisionName
+	}
+	return ""
+}
+
+type GeoNamePostalCodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CountryCode   string  `protobuf:"bytes,1,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	PostalCode    string  `protobuf:"bytes,2,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	PlaceName     string  `protobuf:"bytes,3,opt,name=place_name,json=placeName,proto3" json:"place_name,omitempty"`
+	AdminName1    string  `protobuf:"bytes,4,opt,name=admin_name1,json=adminName1,proto3" json:"admin_name1,omitempty"`
+	AdminCode1    string  `protobuf:"bytes,5,opt,name=admin_code1,json=adminCode1,proto3" json:"admin_code1,omitempty"`
+	AdminName2    string  `protobuf:"bytes,6,opt,name=admin_name2,json=adminName2,proto3" json:"admin_name2,omitempty"`
+	AdminCode2    string  `protobuf:"bytes,7,opt,name=admin_code2,json=adminCode2,proto3" json:"admin_code2,omitempty"`
+	AdminName3    string  `protobuf:"bytes,8,opt,name=admin_name3,json=adminName3,proto3" json:"admin_name3,omitempty"`
+	AdminCode3    string  `protobuf:"bytes,9,opt,name=admin_code3,json=adminCode3,proto3" json:"admin_code3,omitempty"`
+	Latitude      float64 `protobuf:"fixed64,10,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64 `protobuf:"fixed64,11,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Accuracy      int32   `protobuf:"varint,12,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	ContinentCode string  `protobuf:"bytes,13,opt,name=continent_code,json=continentCode,proto3" json:"continent_code,omitempty"`
+	ContinentName string  `protobuf:"bytes,14,opt,name=continent_name,json=continentName,proto3" json:"continent_name,omitempty"`
+	CountryName   string  `protobuf:"bytes,15,opt,name=country_name,json=countryName,proto3" json:"country_name,omitempty"`
+}
+
+func (x *GeoNamePostalCodeResponse) Reset() {
+	*x = GeoNamePostalCodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GeoNameSubdivisionResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GeoNamePostalCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNamePostalCodeResponse) ProtoMessage() {}
+
+func (x *GeoNamePostalCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNamePostalCodeResponse.ProtoReflect.Descriptor instead.
+func (*GeoNamePostalCodeResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GeoNamePostalCodeResponse) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetPlaceName() string {
+	if x != nil {
+		return x.PlaceName
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminName1() string {
+	if x != nil {
+		return x.AdminName1
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminCode1() string {
+	if x != nil {
+		return x.AdminCode1
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminName2() string {
+	if x != nil {
+		return x.AdminName2
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminCode2() string {
+	if x != nil {
+		return x.AdminCode2
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminName3() string {
+	if x != nil {
+		return x.AdminName3
+	}
+	return ""
+}
+
+func (x *GeoNamePostalCodeResponse) GetAdminCode3() string {
+	if x != nil {
+		return x.AdminCode3
+	}
+	return ""
 }
 
-func (*GeoNameSubdivisionResponse) ProtoMessage() {}
-
-func (x *GeoNameSubdivisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoname_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *GeoNamePostalCodeResponse) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GeoNameSubdivisionResponse.ProtoReflect.Descriptor instead.
-func (*GeoNameSubdivisionResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{2}
+func (x *GeoNamePostalCodeResponse) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
 }
 
-func (x *GeoNameSubdivisionResponse) GetCode() string {
+func (x *GeoNamePostalCodeResponse) GetAccuracy() int32 {
 	if x != nil {
-		return x.Code
+		return x.Accuracy
 	}
-	return ""
+	return 0
 }
 
-func (x *GeoNameSubdivisionResponse) GetName() string {
+func (x *GeoNamePostalCodeResponse) GetContinentCode() string {
 	if x != nil {
-		return x.Name
+		return x.ContinentCode
 	}
 	return ""
 }
 
-func (x *GeoNameSubdivisionResponse) GetAsciiName() string {
+func (x *GeoNamePostalCodeResponse) GetContinentName() string {
 	if x != nil {
-		return x.AsciiName
+		return x.ContinentName
 	}
 	return ""
 }
 
-func (x *GeoNameSubdivisionResponse) GetGeoNameId() uint32 {
+func (x *GeoNamePostalCodeResponse) GetCountryName() string {
 	if x != nil {
-		return x.GeoNameId
+		return x.CountryName
 	}
-	return 0
+	return ""
 }
 
 type GeoNameContinentResponse struct {
@@ -366,7 +866,7 @@ type GeoNameContinentResponse struct {
 func (x *GeoNameContinentResponse) Reset() {
 	*x = GeoNameContinentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoname_proto_msgTypes[3]
+		mi := &file_api_grpc_geoname_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -379,7 +879,7 @@ func (x *GeoNameContinentResponse) String() string {
 func (*GeoNameContinentResponse) ProtoMessage() {}
 
 func (x *GeoNameContinentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoname_proto_msgTypes[3]
+	mi := &file_api_grpc_geoname_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +892,7 @@ func (x *GeoNameContinentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeoNameContinentResponse.ProtoReflect.Descriptor instead.
 func (*GeoNameContinentResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{3}
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GeoNameContinentResponse) GetCode() string {
@@ -438,12 +938,17 @@ type GeoNameCityResponse struct {
 	Elevation             int64   `protobuf:"varint,15,opt,name=elevation,proto3" json:"elevation,omitempty"`
 	DigitalElevationModel int64   `protobuf:"varint,16,opt,name=digital_elevation_model,json=digitalElevationModel,proto3" json:"digital_elevation_model,omitempty"`
 	TimeZone              string  `protobuf:"bytes,17,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	ContinentCode         string  `protobuf:"bytes,18,opt,name=continent_code,json=continentCode,proto3" json:"continent_code,omitempty"`
+	ContinentName         string  `protobuf:"bytes,19,opt,name=continent_name,json=continentName,proto3" json:"continent_name,omitempty"`
+	CountryName           string  `protobuf:"bytes,20,opt,name=country_name,json=countryName,proto3" json:"country_name,omitempty"`
+	SubdivisionName       string  `protobuf:"bytes,21,opt,name=subdivision_name,json=subdivisionName,proto3" json:"subdivision_name,omitempty"`
+	Subdivision2Name      string  `protobuf:"bytes,22,opt,name=subdivision2_name,json=subdivision2Name,proto3" json:"subdivision2_name,omitempty"`
 }
 
 func (x *GeoNameCityResponse) Reset() {
 	*x = GeoNameCityResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_grpc_geoname_proto_msgTypes[4]
+		mi := &file_api_grpc_geoname_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -456,7 +961,7 @@ func (x *GeoNameCityResponse) String() string {
 func (*GeoNameCityResponse) ProtoMessage() {}
 
 func (x *GeoNameCityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_geoname_proto_msgTypes[4]
+	mi := &file_api_grpc_geoname_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,7 +974,7 @@ func (x *GeoNameCityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeoNameCityResponse.ProtoReflect.Descriptor instead.
 func (*GeoNameCityResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{4}
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GeoNameCityResponse) GetGeoNameId() uint32 {
@@ -591,12 +1096,232 @@ func (x *GeoNameCityResponse) GetTimeZone() string {
 	return ""
 }
 
+func (x *GeoNameCityResponse) GetContinentCode() string {
+	if x != nil {
+		return x.ContinentCode
+	}
+	return ""
+}
+
+func (x *GeoNameCityResponse) GetContinentName() string {
+	if x != nil {
+		return x.ContinentName
+	}
+	return ""
+}
+
+func (x *GeoNameCityResponse) GetCountryName() string {
+	if x != nil {
+		return x.CountryName
+	}
+	return ""
+}
+
+func (x *GeoNameCityResponse) GetSubdivisionName() string {
+	if x != nil {
+		return x.SubdivisionName
+	}
+	return ""
+}
+
+func (x *GeoNameCityResponse) GetSubdivision2Name() string {
+	if x != nil {
+		return x.Subdivision2Name
+	}
+	return ""
+}
+
+type GeoNameNearestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City       *GeoNameCityResponse `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	DistanceKm float64              `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+}
+
+func (x *GeoNameNearestResponse) Reset() {
+	*x = GeoNameNearestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameNearestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameNearestResponse) ProtoMessage() {}
+
+func (x *GeoNameNearestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameNearestResponse.ProtoReflect.Descriptor instead.
+func (*GeoNameNearestResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GeoNameNearestResponse) GetCity() *GeoNameCityResponse {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+func (x *GeoNameNearestResponse) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type GeoNameItemResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Types that are assignable to Item:
+	//	*GeoNameItemResponse_Continent
+	//	*GeoNameItemResponse_Country
+	//	*GeoNameItemResponse_Subdivision
+	//	*GeoNameItemResponse_Subdivision2
+	//	*GeoNameItemResponse_City
+	Item isGeoNameItemResponse_Item `protobuf_oneof:"item"`
+}
+
+func (x *GeoNameItemResponse) Reset() {
+	*x = GeoNameItemResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_grpc_geoname_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeoNameItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoNameItemResponse) ProtoMessage() {}
+
+func (x *GeoNameItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_geoname_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoNameItemResponse.ProtoReflect.Descriptor instead.
+func (*GeoNameItemResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_geoname_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GeoNameItemResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (m *GeoNameItemResponse) GetItem() isGeoNameItemResponse_Item {
+	if m != nil {
+		return m.Item
+	}
+	return nil
+}
+
+func (x *GeoNameItemResponse) GetContinent() *GeoNameContinentResponse {
+	if x, ok := x.GetItem().(*GeoNameItemResponse_Continent); ok {
+		return x.Continent
+	}
+	return nil
+}
+
+func (x *GeoNameItemResponse) GetCountry() *GeoNameCountryResponse {
+	if x, ok := x.GetItem().(*GeoNameItemResponse_Country); ok {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *GeoNameItemResponse) GetSubdivision() *GeoNameSubdivisionResponse {
+	if x, ok := x.GetItem().(*GeoNameItemResponse_Subdivision); ok {
+		return x.Subdivision
+	}
+	return nil
+}
+
+func (x *GeoNameItemResponse) GetSubdivision2() *GeoNameSubdivision2Response {
+	if x, ok := x.GetItem().(*GeoNameItemResponse_Subdivision2); ok {
+		return x.Subdivision2
+	}
+	return nil
+}
+
+func (x *GeoNameItemResponse) GetCity() *GeoNameCityResponse {
+	if x, ok := x.GetItem().(*GeoNameItemResponse_City); ok {
+		return x.City
+	}
+	return nil
+}
+
+type isGeoNameItemResponse_Item interface {
+	isGeoNameItemResponse_Item()
+}
+
+type GeoNameItemResponse_Continent struct {
+	Continent *GeoNameContinentResponse `protobuf:"bytes,2,opt,name=continent,proto3,oneof"`
+}
+
+type GeoNameItemResponse_Country struct {
+	Country *GeoNameCountryResponse `protobuf:"bytes,3,opt,name=country,proto3,oneof"`
+}
+
+type GeoNameItemResponse_Subdivision struct {
+	Subdivision *GeoNameSubdivisionResponse `protobuf:"bytes,4,opt,name=subdivision,proto3,oneof"`
+}
+
+type GeoNameItemResponse_Subdivision2 struct {
+	Subdivision2 *GeoNameSubdivision2Response `protobuf:"bytes,5,opt,name=subdivision2,proto3,oneof"`
+}
+
+type GeoNameItemResponse_City struct {
+	City *GeoNameCityResponse `protobuf:"bytes,6,opt,name=city,proto3,oneof"`
+}
+
+func (*GeoNameItemResponse_Continent) isGeoNameItemResponse_Item() {}
+
+func (*GeoNameItemResponse_Country) isGeoNameItemResponse_Item() {}
+
+func (*GeoNameItemResponse_Subdivision) isGeoNameItemResponse_Item() {}
+
+func (*GeoNameItemResponse_Subdivision2) isGeoNameItemResponse_Item() {}
+
+func (*GeoNameItemResponse_City) isGeoNameItemResponse_Item() {}
+
 var File_api_grpc_geoname_proto protoreflect.FileDescriptor
 
 var file_api_grpc_geoname_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x65, 0x6f, 0x6e, 0x61,
 	0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x8e, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
+	0x65, 0x22, 0xd1, 0x03, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f,
 	0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x75,
 	0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x61, 0x6d,
@@ -605,116 +1330,277 @@ var file_api_grpc_geoname_proto_rawDesc = []byte{
 	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
 	0x12, 0x20, 0x0a, 0x0c, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x73,
 	0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49,
-	0x64, 0x73, 0x22, 0xe1, 0x04, 0x0a, 0x16, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a,
-	0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x6f, 0x33,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x73, 0x6f,
-	0x33, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x6f, 0x5f, 0x6e, 0x75, 0x6d,
-	0x65, 0x72, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x73, 0x6f, 0x4e,
-	0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x70, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x65, 0x61,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61, 0x72, 0x65, 0x61, 0x12, 0x1e, 0x0a, 0x0a,
-	0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
-	0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c,
-	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0d,
-	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x64,
-	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x63, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18,
-	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x12,
-	0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c,
-	0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x6f,
-	0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18,
-	0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64,
-	0x65, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
-	0x67, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75,
-	0x61, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61,
-	0x6d, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75,
-	0x72, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62,
-	0x6f, 0x75, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x71, 0x75, 0x69, 0x76, 0x61, 0x6c, 0x65,
-	0x6e, 0x74, 0x5f, 0x66, 0x69, 0x70, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x13, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x12, 0x65, 0x71, 0x75, 0x69, 0x76, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x46, 0x69,
-	0x70, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x83, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x6f, 0x4e, 0x61,
+	0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x62,
+	0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x66,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69,
+	0x6e, 0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x62, 0x6f, 0x78, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x04, 0x62, 0x62, 0x6f, 0x78, 0x22, 0x98, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d,
+	0x65, 0x4e, 0x65, 0x61, 0x72, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x5f, 0x6b, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x4b, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x61, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67,
+	0x22, 0x77, 0x0a, 0x17, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x65, 0x72, 0x61,
+	0x72, 0x63, 0x68, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x67,
+	0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
+	0x61, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xe1, 0x04, 0x0a, 0x16, 0x47, 0x65,
+	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x43, 0x6f, 0x64, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x73, 0x6f, 0x33, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x69, 0x73, 0x6f, 0x33, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x73, 0x6f, 0x5f, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x73, 0x6f, 0x4e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x69, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x70,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x61, 0x70, 0x69, 0x74, 0x61, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x65, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x61,
+	0x72, 0x65, 0x61, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x6f,
+	0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a,
+	0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x67,
+	0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x65,
+	0x71, 0x75, 0x69, 0x76, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x70, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x65, 0x71, 0x75, 0x69, 0x76,
+	0x61, 0x6c, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x70, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x83, 0x01,
+	0x0a, 0x1a, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x63, 0x69, 0x69, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x63, 0x69, 0x69, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x22, 0xa0, 0x02, 0x0a, 0x1b, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53,
+	0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61,
+	0x73, 0x63, 0x69, 0x69, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x73, 0x63, 0x69, 0x69, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65,
+	0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73,
+	0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x8b, 0x04, 0x0a, 0x19, 0x47, 0x65, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f,
+	0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x31, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x31, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x31, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x32, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x32, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x33, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x33, 0x12, 0x1f, 0x0a, 0x0b,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x33, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x33, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x75, 0x72,
+	0x61, 0x63, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x63, 0x63, 0x75, 0x72,
+	0x61, 0x63, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e,
+	0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x18, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67,
+	0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x87, 0x06, 0x0a, 0x13, 0x47, 0x65, 0x6f,
+	0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x63, 0x69, 0x69, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x63, 0x69, 0x69, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x6c,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x61, 0x6c, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x31, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x31, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x32, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x32,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x33, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x33, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x34, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x34, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17, 0x64, 0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x5f,
+	0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x64, 0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x45, 0x6c,
+	0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e,
+	0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e,
+	0x65, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x75,
+	0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x32, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0x4e, 0x61,
+	0x6d, 0x65, 0x22, 0x6b, 0x0a, 0x16, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x4e, 0x65, 0x61,
+	0x72, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1f,
+	0x0a, 0x0b, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6b, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4b, 0x6d, 0x22,
+	0xfa, 0x02, 0x0a, 0x13, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x63,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x3b,
+	0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x47, 0x0a, 0x0b, 0x73,
+	0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61,
 	0x6d, 0x65, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x61, 0x73, 0x63, 0x69, 0x69, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x61, 0x73, 0x63, 0x69, 0x69, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0b,
-	0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x62, 0x0a, 0x18,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x62, 0x64,
+	0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x32,
+	0x12, 0x32, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65,
+	0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x42, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x32, 0x86, 0x06, 0x0a,
+	0x0e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x49, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x67,
+	0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e,
 	0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64,
-	0x22, 0xbe, 0x04, 0x0a, 0x13, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x67, 0x65, 0x6f, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x67,
-	0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x61, 0x73, 0x63, 0x69, 0x69, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x61, 0x73, 0x63, 0x69, 0x69, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
-	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c,
-	0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69,
-	0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
-	0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
-	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x15, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64,
-	0x6d, 0x69, 0x6e, 0x31, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x31, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x32, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x32, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x33, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x33, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x34, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0d, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0a, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x34, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1e,
-	0x0a, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0a, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
-	0x0a, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x17,
-	0x64, 0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x5f, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x64,
-	0x69, 0x67, 0x69, 0x74, 0x61, 0x6c, 0x45, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
-	0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e,
-	0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e,
-	0x65, 0x32, 0xb2, 0x02, 0x0a, 0x0e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6e,
-	0x74, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e,
-	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x67, 0x65, 0x6f,
-	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x74,
-	0x69, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
-	0x45, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f,
-	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65,
-	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x17,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x07, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e,
+	0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
 	0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d,
-	0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x64, 0x69,
-	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65,
-	0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d,
-	0x65, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x3f, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e,
+	0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f,
+	0x4e, 0x61, 0x6d, 0x65, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0b, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x62, 0x64,
+	0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x4f, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x64, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x32, 0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x53, 0x75, 0x62, 0x64,
+	0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0a, 0x50, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x17, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x67, 0x65, 0x6f, 0x6e,
+	0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x6f, 0x73, 0x74, 0x61,
+	0x6c, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x4c, 0x0a, 0x07, 0x4e, 0x65, 0x61, 0x72, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x4e, 0x65, 0x61, 0x72,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x4e, 0x65, 0x61, 0x72,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x49, 0x0a,
+	0x07, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61,
+	0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x65, 0x72, 0x61, 0x72,
+	0x63, 0x68, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f,
+	0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x08, 0x43, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x12, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47,
+	0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x65, 0x72, 0x61, 0x72, 0x63, 0x68, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65,
+	0x2e, 0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x09, 0x41, 0x6e, 0x63, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x20, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65,
+	0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x48, 0x69, 0x65, 0x72, 0x61, 0x72, 0x63, 0x68, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x65, 0x6f, 0x6e, 0x61, 0x6d, 0x65, 0x2e,
+	0x47, 0x65, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -729,28 +1615,52 @@ func file_api_grpc_geoname_proto_rawDescGZIP() []byte {
 	return file_api_grpc_geoname_proto_rawDescData
 }
 
-var file_api_grpc_geoname_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_grpc_geoname_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_api_grpc_geoname_proto_goTypes = []interface{}{
-	(*GeoNameRequest)(nil),             // 0: geoname.GeoNameRequest
-	(*GeoNameCountryResponse)(nil),     // 1: geoname.GeoNameCountryResponse
-	(*GeoNameSubdivisionResponse)(nil), // 2: geoname.GeoNameSubdivisionResponse
-	(*GeoNameContinentResponse)(nil),   // 3: geoname.GeoNameContinentResponse
-	(*GeoNameCityResponse)(nil),        // 4: geoname.GeoNameCityResponse
+	(*GeoNameRequest)(nil),              // 0: geoname.GeoNameRequest
+	(*GeoNameNearestRequest)(nil),       // 1: geoname.GeoNameNearestRequest
+	(*GeoNameHierarchyRequest)(nil),     // 2: geoname.GeoNameHierarchyRequest
+	(*GeoNameCountryResponse)(nil),      // 3: geoname.GeoNameCountryResponse
+	(*GeoNameSubdivisionResponse)(nil),  // 4: geoname.GeoNameSubdivisionResponse
+	(*GeoNameSubdivision2Response)(nil), // 5: geoname.GeoNameSubdivision2Response
+	(*GeoNamePostalCodeResponse)(nil),   // 6: geoname.GeoNamePostalCodeResponse
+	(*GeoNameContinentResponse)(nil),    // 7: geoname.GeoNameContinentResponse
+	(*GeoNameCityResponse)(nil),         // 8: geoname.GeoNameCityResponse
+	(*GeoNameNearestResponse)(nil),      // 9: geoname.GeoNameNearestResponse
+	(*GeoNameItemResponse)(nil),         // 10: geoname.GeoNameItemResponse
 }
 var file_api_grpc_geoname_proto_depIdxs = []int32{
-	0, // 0: geoname.GeoNameService.Continent:input_type -> geoname.GeoNameRequest
-	0, // 1: geoname.GeoNameService.Country:input_type -> geoname.GeoNameRequest
-	0, // 2: geoname.GeoNameService.City:input_type -> geoname.GeoNameRequest
-	0, // 3: geoname.GeoNameService.Subdivision:input_type -> geoname.GeoNameRequest
-	3, // 4: geoname.GeoNameService.Continent:output_type -> geoname.GeoNameContinentResponse
-	1, // 5: geoname.GeoNameService.Country:output_type -> geoname.GeoNameCountryResponse
-	4, // 6: geoname.GeoNameService.City:output_type -> geoname.GeoNameCityResponse
-	2, // 7: geoname.GeoNameService.Subdivision:output_type -> geoname.GeoNameSubdivisionResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8,  // 0: geoname.GeoNameNearestResponse.city:type_name -> geoname.GeoNameCityResponse
+	7,  // 1: geoname.GeoNameItemResponse.continent:type_name -> geoname.GeoNameContinentResponse
+	3,  // 2: geoname.GeoNameItemResponse.country:type_name -> geoname.GeoNameCountryResponse
+	4,  // 3: geoname.GeoNameItemResponse.subdivision:type_name -> geoname.GeoNameSubdivisionResponse
+	5,  // 4: geoname.GeoNameItemResponse.subdivision2:type_name -> geoname.GeoNameSubdivision2Response
+	8,  // 5: geoname.GeoNameItemResponse.city:type_name -> geoname.GeoNameCityResponse
+	0,  // 6: geoname.GeoNameService.Continent:input_type -> geoname.GeoNameRequest
+	0,  // 7: geoname.GeoNameService.Country:input_type -> geoname.GeoNameRequest
+	0,  // 8: geoname.GeoNameService.City:input_type -> geoname.GeoNameRequest
+	0,  // 9: geoname.GeoNameService.Subdivision:input_type -> geoname.GeoNameRequest
+	0,  // 10: geoname.GeoNameService.Subdivision2:input_type -> geoname.GeoNameRequest
+	0,  // 11: geoname.GeoNameService.PostalCode:input_type -> geoname.GeoNameRequest
+	1,  // 12: geoname.GeoNameService.Nearest:input_type -> geoname.GeoNameNearestRequest
+	2,  // 13: geoname.GeoNameService.GeoName:input_type -> geoname.GeoNameHierarchyRequest
+	2,  // 14: geoname.GeoNameService.Children:input_type -> geoname.GeoNameHierarchyRequest
+	2,  // 15: geoname.GeoNameService.Ancestors:input_type -> geoname.GeoNameHierarchyRequest
+	7,  // 16: geoname.GeoNameService.Continent:output_type -> geoname.GeoNameContinentResponse
+	3,  // 17: geoname.GeoNameService.Country:output_type -> geoname.GeoNameCountryResponse
+	8,  // 18: geoname.GeoNameService.City:output_type -> geoname.GeoNameCityResponse
+	4,  // 19: geoname.GeoNameService.Subdivision:output_type -> geoname.GeoNameSubdivisionResponse
+	5,  // 20: geoname.GeoNameService.Subdivision2:output_type -> geoname.GeoNameSubdivision2Response
+	6,  // 21: geoname.GeoNameService.PostalCode:output_type -> geoname.GeoNamePostalCodeResponse
+	9,  // 22: geoname.GeoNameService.Nearest:output_type -> geoname.GeoNameNearestResponse
+	10, // 23: geoname.GeoNameService.GeoName:output_type -> geoname.GeoNameItemResponse
+	10, // 24: geoname.GeoNameService.Children:output_type -> geoname.GeoNameItemResponse
+	10, // 25: geoname.GeoNameService.Ancestors:output_type -> geoname.GeoNameItemResponse
+	16, // [16:26] is the sub-list for method output_type
+	6,  // [6:16] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_geoname_proto_init() }
@@ -772,7 +1682,7 @@ func file_api_grpc_geoname_proto_init() {
 			}
 		}
 		file_api_grpc_geoname_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeoNameCountryResponse); i {
+			switch v := v.(*GeoNameNearestRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -784,7 +1694,7 @@ func file_api_grpc_geoname_proto_init() {
 			}
 		}
 		file_api_grpc_geoname_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeoNameSubdivisionResponse); i {
+			switch v := v.(*GeoNameHierarchyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -796,7 +1706,7 @@ func file_api_grpc_geoname_proto_init() {
 			}
 		}
 		file_api_grpc_geoname_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GeoNameContinentResponse); i {
+			switch v := v.(*GeoNameCountryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -808,6 +1718,54 @@ func file_api_grpc_geoname_proto_init() {
 			}
 		}
 		file_api_grpc_geoname_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNameSubdivisionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoname_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNameSubdivision2Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoname_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNamePostalCodeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoname_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNameContinentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoname_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GeoNameCityResponse); i {
 			case 0:
 				return &v.state
@@ -819,6 +1777,37 @@ func file_api_grpc_geoname_proto_init() {
 				return nil
 			}
 		}
+		file_api_grpc_geoname_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNameNearestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_grpc_geoname_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GeoNameItemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_grpc_geoname_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*GeoNameItemResponse_Continent)(nil),
+		(*GeoNameItemResponse_Country)(nil),
+		(*GeoNameItemResponse_Subdivision)(nil),
+		(*GeoNameItemResponse_Subdivision2)(nil),
+		(*GeoNameItemResponse_City)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -826,7 +1815,7 @@ func file_api_grpc_geoname_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_grpc_geoname_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
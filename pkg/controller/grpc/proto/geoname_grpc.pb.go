@@ -26,6 +26,12 @@ type GeoNameServiceClient interface {
 	Country(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_CountryClient, error)
 	City(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_CityClient, error)
 	Subdivision(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_SubdivisionClient, error)
+	Subdivision2(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_Subdivision2Client, error)
+	PostalCode(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_PostalCodeClient, error)
+	Nearest(ctx context.Context, in *GeoNameNearestRequest, opts ...grpc.CallOption) (GeoNameService_NearestClient, error)
+	GeoName(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (*GeoNameItemResponse, error)
+	Children(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (GeoNameService_ChildrenClient, error)
+	Ancestors(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (GeoNameService_AncestorsClient, error)
 }
 
 type geoNameServiceClient struct {
@@ -164,6 +170,175 @@ func (x *geoNameServiceSubdivisionClient) Recv() (*GeoNameSubdivisionResponse, e
 	return m, nil
 }
 
+func (c *geoNameServiceClient) Subdivision2(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_Subdivision2Client, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoNameService_ServiceDesc.Streams[4], "/geoname.GeoNameService/Subdivision2", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoNameServiceSubdivision2Client{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoNameService_Subdivision2Client interface {
+	Recv() (*GeoNameSubdivision2Response, error)
+	grpc.ClientStream
+}
+
+type geoNameServiceSubdivision2Client struct {
+	grpc.ClientStream
+}
+
+func (x *geoNameServiceSubdivision2Client) Recv() (*GeoNameSubdivision2Response, error) {
+	m := new(GeoNameSubdivision2Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *geoNameServiceClient) PostalCode(ctx context.Context, in *GeoNameRequest, opts ...grpc.CallOption) (GeoNameService_PostalCodeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoNameService_ServiceDesc.Streams[5], "/geoname.GeoNameService/PostalCode", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoNameServicePostalCodeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoNameService_PostalCodeClient interface {
+	Recv() (*GeoNamePostalCodeResponse, error)
+	grpc.ClientStream
+}
+
+type geoNameServicePostalCodeClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoNameServicePostalCodeClient) Recv() (*GeoNamePostalCodeResponse, error) {
+	m := new(GeoNamePostalCodeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *geoNameServiceClient) Nearest(ctx context.Context, in *GeoNameNearestRequest, opts ...grpc.CallOption) (GeoNameService_NearestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoNameService_ServiceDesc.Streams[6], "/geoname.GeoNameService/Nearest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoNameServiceNearestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoNameService_NearestClient interface {
+	Recv() (*GeoNameNearestResponse, error)
+	grpc.ClientStream
+}
+
+type geoNameServiceNearestClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoNameServiceNearestClient) Recv() (*GeoNameNearestResponse, error) {
+	m := new(GeoNameNearestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *geoNameServiceClient) GeoName(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (*GeoNameItemResponse, error) {
+	out := new(GeoNameItemResponse)
+	err := c.cc.Invoke(ctx, "/geoname.GeoNameService/GeoName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoNameServiceClient) Children(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (GeoNameService_ChildrenClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoNameService_ServiceDesc.Streams[7], "/geoname.GeoNameService/Children", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoNameServiceChildrenClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoNameService_ChildrenClient interface {
+	Recv() (*GeoNameItemResponse, error)
+	grpc.ClientStream
+}
+
+type geoNameServiceChildrenClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoNameServiceChildrenClient) Recv() (*GeoNameItemResponse, error) {
+	m := new(GeoNameItemResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *geoNameServiceClient) Ancestors(ctx context.Context, in *GeoNameHierarchyRequest, opts ...grpc.CallOption) (GeoNameService_AncestorsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GeoNameService_ServiceDesc.Streams[8], "/geoname.GeoNameService/Ancestors", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &geoNameServiceAncestorsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GeoNameService_AncestorsClient interface {
+	Recv() (*GeoNameItemResponse, error)
+	grpc.ClientStream
+}
+
+type geoNameServiceAncestorsClient struct {
+	grpc.ClientStream
+}
+
+func (x *geoNameServiceAncestorsClient) Recv() (*GeoNameItemResponse, error) {
+	m := new(GeoNameItemResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GeoNameServiceServer is the server API for GeoNameService service.
 // All implementations must embed UnimplementedGeoNameServiceServer
 // for forward compatibility
@@ -172,6 +347,12 @@ type GeoNameServiceServer interface {
 	Country(*GeoNameRequest, GeoNameService_CountryServer) error
 	City(*GeoNameRequest, GeoNameService_CityServer) error
 	Subdivision(*GeoNameRequest, GeoNameService_SubdivisionServer) error
+	Subdivision2(*GeoNameRequest, GeoNameService_Subdivision2Server) error
+	PostalCode(*GeoNameRequest, GeoNameService_PostalCodeServer) error
+	Nearest(*GeoNameNearestRequest, GeoNameService_NearestServer) error
+	GeoName(context.Context, *GeoNameHierarchyRequest) (*GeoNameItemResponse, error)
+	Children(*GeoNameHierarchyRequest, GeoNameService_ChildrenServer) error
+	Ancestors(*GeoNameHierarchyRequest, GeoNameService_AncestorsServer) error
 	mustEmbedUnimplementedGeoNameServiceServer()
 }
 
@@ -191,6 +372,24 @@ func (UnimplementedGeoNameServiceServer) City(*GeoNameRequest, GeoNameService_Ci
 func (UnimplementedGeoNameServiceServer) Subdivision(*GeoNameRequest, GeoNameService_SubdivisionServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subdivision not implemented")
 }
+func (UnimplementedGeoNameServiceServer) Subdivision2(*GeoNameRequest, GeoNameService_Subdivision2Server) error {
+	return status.Errorf(codes.Unimplemented, "method Subdivision2 not implemented")
+}
+func (UnimplementedGeoNameServiceServer) PostalCode(*GeoNameRequest, GeoNameService_PostalCodeServer) error {
+	return status.Errorf(codes.Unimplemented, "method PostalCode not implemented")
+}
+func (UnimplementedGeoNameServiceServer) Nearest(*GeoNameNearestRequest, GeoNameService_NearestServer) error {
+	return status.Errorf(codes.Unimplemented, "method Nearest not implemented")
+}
+func (UnimplementedGeoNameServiceServer) GeoName(context.Context, *GeoNameHierarchyRequest) (*GeoNameItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GeoName not implemented")
+}
+func (UnimplementedGeoNameServiceServer) Children(*GeoNameHierarchyRequest, GeoNameService_ChildrenServer) error {
+	return status.Errorf(codes.Unimplemented, "method Children not implemented")
+}
+func (UnimplementedGeoNameServiceServer) Ancestors(*GeoNameHierarchyRequest, GeoNameService_AncestorsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Ancestors not implemented")
+}
 func (UnimplementedGeoNameServiceServer) mustEmbedUnimplementedGeoNameServiceServer() {}
 
 // UnsafeGeoNameServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -288,13 +487,141 @@ func (x *geoNameServiceSubdivisionServer) Send(m *GeoNameSubdivisionResponse) er
 	return x.ServerStream.SendMsg(m)
 }
 
+func _GeoNameService_Subdivision2_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeoNameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoNameServiceServer).Subdivision2(m, &geoNameServiceSubdivision2Server{stream})
+}
+
+type GeoNameService_Subdivision2Server interface {
+	Send(*GeoNameSubdivision2Response) error
+	grpc.ServerStream
+}
+
+type geoNameServiceSubdivision2Server struct {
+	grpc.ServerStream
+}
+
+func (x *geoNameServiceSubdivision2Server) Send(m *GeoNameSubdivision2Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GeoNameService_PostalCode_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeoNameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoNameServiceServer).PostalCode(m, &geoNameServicePostalCodeServer{stream})
+}
+
+type GeoNameService_PostalCodeServer interface {
+	Send(*GeoNamePostalCodeResponse) error
+	grpc.ServerStream
+}
+
+type geoNameServicePostalCodeServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoNameServicePostalCodeServer) Send(m *GeoNamePostalCodeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GeoNameService_Nearest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeoNameNearestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoNameServiceServer).Nearest(m, &geoNameServiceNearestServer{stream})
+}
+
+type GeoNameService_NearestServer interface {
+	Send(*GeoNameNearestResponse) error
+	grpc.ServerStream
+}
+
+type geoNameServiceNearestServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoNameServiceNearestServer) Send(m *GeoNameNearestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GeoNameService_GeoName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoNameHierarchyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoNameServiceServer).GeoName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geoname.GeoNameService/GeoName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoNameServiceServer).GeoName(ctx, req.(*GeoNameHierarchyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoNameService_Children_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeoNameHierarchyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoNameServiceServer).Children(m, &geoNameServiceChildrenServer{stream})
+}
+
+type GeoNameService_ChildrenServer interface {
+	Send(*GeoNameItemResponse) error
+	grpc.ServerStream
+}
+
+type geoNameServiceChildrenServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoNameServiceChildrenServer) Send(m *GeoNameItemResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GeoNameService_Ancestors_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GeoNameHierarchyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeoNameServiceServer).Ancestors(m, &geoNameServiceAncestorsServer{stream})
+}
+
+type GeoNameService_AncestorsServer interface {
+	Send(*GeoNameItemResponse) error
+	grpc.ServerStream
+}
+
+type geoNameServiceAncestorsServer struct {
+	grpc.ServerStream
+}
+
+func (x *geoNameServiceAncestorsServer) Send(m *GeoNameItemResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // GeoNameService_ServiceDesc is the grpc.ServiceDesc for GeoNameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var GeoNameService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "geoname.GeoNameService",
 	HandlerType: (*GeoNameServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GeoName",
+			Handler:    _GeoNameService_GeoName_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Continent",
@@ -316,6 +643,31 @@ var GeoNameService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GeoNameService_Subdivision_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Subdivision2",
+			Handler:       _GeoNameService_Subdivision2_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PostalCode",
+			Handler:       _GeoNameService_PostalCode_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Nearest",
+			Handler:       _GeoNameService_Nearest_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Children",
+			Handler:       _GeoNameService_Children_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Ancestors",
+			Handler:       _GeoNameService_Ancestors_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/grpc/geoname.proto",
 }
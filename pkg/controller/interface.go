@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/service"
@@ -9,9 +10,13 @@ import (
 
 type GeoIpService interface {
 	Country(ctx context.Context, address string) (*entity.Country, error)
-	City(ctx context.Context, address string, includeISP bool) (*entity.City, error)
+	City(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error)
 	CityLite(ctx context.Context, address string, lang string) (*entity.CityLite, error)
 	Hosting(ctx context.Context, address string) (*entity.Hosting, error)
+	CountryAll(ctx context.Context, address string) (map[string]*entity.Country, error)
+	CityAll(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (map[string]*entity.City, error)
+	CityLiteAll(ctx context.Context, address string, lang string) (map[string]*entity.CityLite, error)
+	HostingAll(ctx context.Context, address string) (map[string]*entity.Hosting, error)
 	MetaData(ctx context.Context, dbType service.DBType) (*entity.MetaData, error)
 	Database(ctx context.Context, dbType service.DBType, format service.DumpFormat) (*entity.Database, error)
 
@@ -20,12 +25,36 @@ type GeoIpService interface {
 }
 
 type GeoNameService interface {
-	Continents(ctx context.Context) []*entity.GeoNameContinent
-	Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error)
-	Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error)
-	Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error)
+	Continents(ctx context.Context, lang string) []*entity.GeoNameContinent
+	Countries(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error)
+	Subdivisions(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error)
+	Subdivisions2(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error)
+	Cities(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error)
+	PostalCodes(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error)
+	Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error)
+	GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error)
+	Children(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
+	Ancestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
 	Dump(ctx context.Context, format service.DumpFormat) ([]byte, error)
 
 	StartUpdate(ctx context.Context) error
 	CheckUpdates(ctx context.Context) (entity.DBUpdate[entity.PatchedGeoNamesVersion], error)
 }
+
+type GeoIPOverrideService interface {
+	Overrides(ctx context.Context, dbType service.DBType) ([]*entity.GeoIPOverride, error)
+	Override(ctx context.Context, dbType service.DBType, id string) (*entity.GeoIPOverride, error)
+	AddOverride(ctx context.Context, dbType service.DBType, override entity.GeoIPOverride) (*entity.GeoIPOverride, error)
+	ReplaceOverride(ctx context.Context, dbType service.DBType, id string, override entity.GeoIPOverride) (*entity.GeoIPOverride, error)
+	DeleteOverride(ctx context.Context, dbType service.DBType, id string) error
+	Expiring(ctx context.Context, dbType service.DBType, within time.Duration) ([]*entity.ExpiringGeoIPRecord, error)
+	AnalyzePatches(ctx context.Context, dbType service.DBType, limit int) (*entity.PatchAnalysis, error)
+}
+
+type ValidationService interface {
+	Validate(ctx context.Context) (*entity.ValidationReport, error)
+}
+
+type ApiKeyStore interface {
+	Usage() []entity.ApiKeyUsage
+}
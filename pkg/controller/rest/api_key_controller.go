@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/bldsoft/geos/pkg/controller"
+	_ "github.com/bldsoft/geos/pkg/entity"
+	gost "github.com/bldsoft/gost/controller"
+)
+
+type ApiKeyController struct {
+	gost.BaseController
+	keys controller.ApiKeyStore
+}
+
+func NewApiKeyController(keys controller.ApiKeyStore) *ApiKeyController {
+	return &ApiKeyController{keys: keys}
+}
+
+// @Summary API keys with request counters
+// @Security ApiKeyAuth
+// @Produce json
+// @Tags admin
+// @Success 200 {array} entity.ApiKeyUsage
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Router /api-keys [get]
+func (c *ApiKeyController) GetApiKeysHandler(w http.ResponseWriter, r *http.Request) {
+	c.ResponseJson(w, r, c.keys.Usage())
+}
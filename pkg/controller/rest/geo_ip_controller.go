@@ -7,7 +7,8 @@ import (
 	"strings"
 
 	"github.com/bldsoft/geos/pkg/controller"
-	_ "github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/microservice/middleware"
 	"github.com/bldsoft/geos/pkg/repository"
 	"github.com/bldsoft/geos/pkg/service"
 	"github.com/bldsoft/geos/pkg/utils"
@@ -29,11 +30,25 @@ func (c *GeoIpController) address(r *http.Request) string {
 	return chi.URLParam(r, "addr")
 }
 
+// cityEnrichments parses the enrich parameter: enrich=geonames or a comma separated list
+func cityEnrichments(r *http.Request) []entity.CityEnrichment {
+	var res []entity.CityEnrichment
+	for _, param := range r.URL.Query()["enrich"] {
+		for _, e := range strings.Split(param, ",") {
+			if e = strings.TrimSpace(e); len(e) > 0 {
+				res = append(res, entity.CityEnrichment(e))
+			}
+		}
+	}
+	return res
+}
+
 // @Summary city
 // @Produce json
 // @Tags geo IP
 // @Param addr path string true "ip or hostname"
 // @Param isp query bool false "include ISP info"
+// @Param enrich query []string false "add the GeoNames entities of the city GeoNameIDs" collectionFormat(csv) Enums(geonames)
 // @Success 200 {object} entity.City
 // @Failure 400 {string} string "error"
 // @Failure 500 {string} string "error"
@@ -41,7 +56,7 @@ func (c *GeoIpController) address(r *http.Request) string {
 func (c *GeoIpController) GetCityHandler(w http.ResponseWriter, r *http.Request) {
 	includeISP, _ := gost.GetQueryOption(r, "isp", false)
 	ctx := r.Context()
-	city, err := c.geoIpService.City(ctx, c.address(r), includeISP)
+	city, err := c.geoIpService.City(ctx, c.address(r), includeISP, cityEnrichments(r)...)
 	if err != nil {
 		c.responseError(w, r, err)
 		return
@@ -71,6 +86,7 @@ func (c *GeoIpController) GetCountryHandler(w http.ResponseWriter, r *http.Reque
 // @Produce json
 // @Tags geo IP
 // @Param addr path string true "ip or hostname"
+// @Param lang query string false "language of the names, en by default. The GeoNames names are used if the database lacks the language"
 // @Success 200 {object} entity.CityLite
 // @Failure 400 {string} string "error"
 // @Failure 500 {string} string "error"
@@ -104,6 +120,92 @@ func (c *GeoIpController) GetHostingHandler(w http.ResponseWriter, r *http.Reque
 	c.ResponseJson(w, r, hosting)
 }
 
+// @Summary client address, that is used as "me". For debugging the trusted proxies configuration
+// @Produce json
+// @Tags geo IP
+// @Success 200 {object} entity.ClientAddress
+// @Router /me [get]
+func (c *GeoIpController) GetMeHandler(w http.ResponseWriter, r *http.Request) {
+	c.ResponseJson(w, r, middleware.GetClientAddress(r.Context()))
+}
+
+// @Summary country for all addresses the hostname is resolved to
+// @Produce json
+// @Tags geo IP
+// @Param addr path string true "ip or hostname"
+// @Success 200 {object} map[string]entity.Country "resolved IP -> country"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /country/{addr}/all [get]
+func (c *GeoIpController) GetCountryAllHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	countries, err := c.geoIpService.CountryAll(ctx, c.address(r))
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, countries)
+}
+
+// @Summary city for all addresses the hostname is resolved to
+// @Produce json
+// @Tags geo IP
+// @Param addr path string true "ip or hostname"
+// @Param isp query bool false "include ISP info"
+// @Param enrich query []string false "add the GeoNames entities of the city GeoNameIDs" collectionFormat(csv) Enums(geonames)
+// @Success 200 {object} map[string]entity.City "resolved IP -> city"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /city/{addr}/all [get]
+func (c *GeoIpController) GetCityAllHandler(w http.ResponseWriter, r *http.Request) {
+	includeISP, _ := gost.GetQueryOption(r, "isp", false)
+	ctx := r.Context()
+	cities, err := c.geoIpService.CityAll(ctx, c.address(r), includeISP, cityEnrichments(r)...)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, cities)
+}
+
+// @Summary city lite for all addresses the hostname is resolved to
+// @Produce json
+// @Tags geo IP
+// @Param addr path string true "ip or hostname"
+// @Param lang query string false "language of the names, en by default. The GeoNames names are used if the database lacks the language"
+// @Success 200 {object} map[string]entity.CityLite "resolved IP -> city lite"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /city-lite/{addr}/all [get]
+func (c *GeoIpController) GetCityLiteAllHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	lang, _ := gost.GetQueryOption[string](r, "lang")
+	cities, err := c.geoIpService.CityLiteAll(ctx, c.address(r), lang)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, cities)
+}
+
+// @Summary hosting for all addresses the hostname is resolved to
+// @Produce json
+// @Tags geo IP
+// @Param addr path string true "ip or hostname"
+// @Success 200 {object} map[string]entity.Hosting "resolved IP -> hosting"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /hosting/{addr}/all [get]
+func (c *GeoIpController) GetHostingAllHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	hostings, err := c.geoIpService.HostingAll(ctx, c.address(r))
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, hostings)
+}
+
 // @Summary geoip database dump
 // @Security ApiKeyAuth
 // @Deprecated
@@ -220,6 +322,8 @@ func (c *GeoIpController) responseError(w http.ResponseWriter, r *http.Request,
 		c.ResponseError(w, err.Error(), http.StatusServiceUnavailable)
 	case errors.Is(err, utils.ErrNotAvailable):
 		c.ResponseError(w, err.Error(), http.StatusInternalServerError)
+	case errors.Is(err, utils.ErrHostnameLookupDisabled):
+		c.ResponseError(w, err.Error(), http.StatusBadRequest)
 	default:
 		c.ResponseError(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	}
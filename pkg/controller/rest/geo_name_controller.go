@@ -3,7 +3,9 @@ package rest
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/bldsoft/geos/pkg/controller"
 	"github.com/bldsoft/geos/pkg/entity"
@@ -12,6 +14,7 @@ import (
 	gost "github.com/bldsoft/gost/controller"
 	"github.com/bldsoft/gost/log"
 	gostUtils "github.com/bldsoft/gost/utils"
+	"github.com/go-chi/chi/v5"
 )
 
 type GeoNameController struct {
@@ -37,21 +40,37 @@ func (c *GeoNameController) getGeoNameFilter(r *http.Request) (filter *entity.Ge
 // @Summary continent
 // @Produce json
 // @Tags geonames
+// @Param lang query string false "language of the names, the names aren't translated if empty"
 // @Success 200 {object} []entity.geoNameContinentJson
 // @Router /geoname/continent [get]
 func (c *GeoNameController) GetGeoNameContinentsHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	countries := c.geoNameService.Continents(ctx)
-	c.ResponseJson(w, r, countries, false)
+	lang, _ := gost.GetQueryOption[string](r, "lang")
+	continents := c.geoNameService.Continents(ctx, lang)
+	c.ResponseJson(w, r, continents, false)
 }
 
 // @Summary country
 // @Produce json
 // @Tags geonames
 // @Param country-codes query []string false "comma separated list of country codes"
-// @Param name-prefix query string false "name prefix"
+// @Param name-prefix query string false "name or its prefix, case and accent insensitive, alternate names are matched too"
+// @Param match query string false "name match: prefix (default), exact, fuzzy (prefix or a few typos)" Enums(prefix, exact, fuzzy)
 // @Param geoname-ids query []integer false "comma separated list of GeoNames ids"
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Param continent-codes query []string false "comma separated list of continent codes"
+// @Param subdivision-codes query []string false "comma separated list of subdivision codes: <country code>.<admin1 code>"
+// @Param feature-codes query []string false "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA"
+// @Param time-zones query []string false "comma separated list of time zones, e.g. Europe/Berlin"
+// @Param min-population query integer false "min population"
+// @Param bbox query []number false "bounding box: west,south,east,north; west > east crosses the antimeridian"
+// @Param limit query integer false "max number of entities on the page"
+// @Param offset query integer false "number of entities to skip"
+// @Param sort query string false "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default" Enums(name, population, geonameid)
+// @Param cursor query string false "X-Next-Cursor of the previous page, the offset is ignored if it's set"
 // @Success 200 {object} []entity.GeoNameCountry
+// @Header 200 {integer} X-Total-Count "number of entities on all the pages"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page, missing on the last page"
 // @Failure 400 {string} string "error"
 // @Failure 500 {string} string "error"
 // @Failure 503 {string} string "error"
@@ -69,16 +88,31 @@ func (c *GeoNameController) GetGeoNameCountriesHandler(w http.ResponseWriter, r
 		c.responseError(w, r, err)
 		return
 	}
-	c.ResponseJson(w, r, countries, false)
+	setPageHeaders(w, countries.Total, countries.NextCursor)
+	c.ResponseJson(w, r, countries.Items, false)
 }
 
 // @Summary city lite
 // @Produce json
 // @Tags geonames
 // @Param country-codes query []string false "comma separated list of country codes"
-// @Param name-prefix query string false "name prefix"
+// @Param name-prefix query string false "name or its prefix, case and accent insensitive, alternate names are matched too"
+// @Param match query string false "name match: prefix (default), exact, fuzzy (prefix or a few typos)" Enums(prefix, exact, fuzzy)
 // @Param geoname-ids query []integer false "comma separated list of GeoNames ids"
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Param continent-codes query []string false "comma separated list of continent codes"
+// @Param subdivision-codes query []string false "comma separated list of subdivision codes: <country code>.<admin1 code>"
+// @Param feature-codes query []string false "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA"
+// @Param time-zones query []string false "comma separated list of time zones, e.g. Europe/Berlin"
+// @Param min-population query integer false "min population"
+// @Param bbox query []number false "bounding box: west,south,east,north; west > east crosses the antimeridian"
+// @Param limit query integer false "max number of entities on the page"
+// @Param offset query integer false "number of entities to skip"
+// @Param sort query string false "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default" Enums(name, population, geonameid)
+// @Param cursor query string false "X-Next-Cursor of the previous page, the offset is ignored if it's set"
 // @Success 200 {object} []entity.GeoNameAdminSubdivision
+// @Header 200 {integer} X-Total-Count "number of entities on all the pages"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page, missing on the last page"
 // @Failure 400 {string} string "error"
 // @Failure 500 {string} string "error"
 // @Failure 503 {string} string "error"
@@ -96,16 +130,73 @@ func (c *GeoNameController) GetGeoNameSubdivisionsHandler(w http.ResponseWriter,
 		c.responseError(w, r, err)
 		return
 	}
-	c.ResponseJson(w, r, subdivisions, false)
+	setPageHeaders(w, subdivisions.Total, subdivisions.NextCursor)
+	c.ResponseJson(w, r, subdivisions.Items, false)
+}
+
+// @Summary second-level subdivision (county, district)
+// @Produce json
+// @Tags geonames
+// @Param country-codes query []string false "comma separated list of country codes"
+// @Param name-prefix query string false "name or its prefix, case and accent insensitive, alternate names are matched too"
+// @Param match query string false "name match: prefix (default), exact, fuzzy (prefix or a few typos)" Enums(prefix, exact, fuzzy)
+// @Param geoname-ids query []integer false "comma separated list of GeoNames ids"
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Param continent-codes query []string false "comma separated list of continent codes"
+// @Param subdivision-codes query []string false "comma separated list of subdivision codes: <country code>.<admin1 code>"
+// @Param feature-codes query []string false "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA"
+// @Param time-zones query []string false "comma separated list of time zones, e.g. Europe/Berlin"
+// @Param min-population query integer false "min population"
+// @Param bbox query []number false "bounding box: west,south,east,north; west > east crosses the antimeridian"
+// @Param limit query integer false "max number of entities on the page"
+// @Param offset query integer false "number of entities to skip"
+// @Param sort query string false "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default" Enums(name, population, geonameid)
+// @Param cursor query string false "X-Next-Cursor of the previous page, the offset is ignored if it's set"
+// @Success 200 {object} []entity.GeoNameAdminSubdivision2
+// @Header 200 {integer} X-Total-Count "number of entities on all the pages"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page, missing on the last page"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/subdivision2 [get]
+func (c *GeoNameController) GetGeoNameSubdivisions2Handler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := c.getGeoNameFilter(r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	subdivisions, err := c.geoNameService.Subdivisions2(ctx, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	setPageHeaders(w, subdivisions.Total, subdivisions.NextCursor)
+	c.ResponseJson(w, r, subdivisions.Items, false)
 }
 
 // @Summary city
 // @Produce json
 // @Tags geonames
 // @Param country-codes query []string false "comma separated list of country codes"
-// @Param name-prefix query string false "name prefix"
+// @Param name-prefix query string false "name or its prefix, case and accent insensitive, alternate names are matched too"
+// @Param match query string false "name match: prefix (default), exact, fuzzy (prefix or a few typos)" Enums(prefix, exact, fuzzy)
 // @Param geoname-ids query []integer false "comma separated list of GeoNames ids"
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Param continent-codes query []string false "comma separated list of continent codes"
+// @Param subdivision-codes query []string false "comma separated list of subdivision codes: <country code>.<admin1 code>"
+// @Param feature-codes query []string false "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA"
+// @Param time-zones query []string false "comma separated list of time zones, e.g. Europe/Berlin"
+// @Param min-population query integer false "min population"
+// @Param bbox query []number false "bounding box: west,south,east,north; west > east crosses the antimeridian"
+// @Param limit query integer false "max number of entities on the page"
+// @Param offset query integer false "number of entities to skip"
+// @Param sort query string false "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default" Enums(name, population, geonameid)
+// @Param cursor query string false "X-Next-Cursor of the previous page, the offset is ignored if it's set"
 // @Success 200 {object} []entity.GeoName
+// @Header 200 {integer} X-Total-Count "number of entities on all the pages"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page, missing on the last page"
 // @Failure 400 {string} string "error"
 // @Failure 500 {string} string "error"
 // @Failure 503 {string} string "error"
@@ -123,9 +214,187 @@ func (c *GeoNameController) GetGeoNameCitiesHandler(w http.ResponseWriter, r *ht
 		c.responseError(w, r, err)
 		return
 	}
+	setPageHeaders(w, cities.Total, cities.NextCursor)
+	c.ResponseJson(w, r, cities.Items, false)
+}
+
+// @Summary postal code
+// @Produce json
+// @Tags geonames
+// @Param country-codes query []string false "comma separated list of country codes"
+// @Param name-prefix query string false "postal code or place name or its prefix, case and accent insensitive"
+// @Param match query string false "name match: prefix (default), exact, fuzzy (prefix or a few typos)" Enums(prefix, exact, fuzzy)
+// @Param lang query string false "language of the continent and country names, the names aren't translated if empty"
+// @Param continent-codes query []string false "comma separated list of continent codes"
+// @Param subdivision-codes query []string false "comma separated list of subdivision codes: <country code>.<admin1 code>"
+// @Param feature-codes query []string false "comma separated list of GeoNames feature codes, e.g. PPLC,PPLA"
+// @Param time-zones query []string false "comma separated list of time zones, e.g. Europe/Berlin"
+// @Param min-population query integer false "min population"
+// @Param bbox query []number false "bounding box: west,south,east,north; west > east crosses the antimeridian"
+// @Param limit query integer false "max number of entities on the page"
+// @Param offset query integer false "number of entities to skip"
+// @Param sort query string false "order: by name, by population (the most populated first) or by id. The name matches are ranked by quality by default" Enums(name, population, geonameid)
+// @Param cursor query string false "X-Next-Cursor of the previous page, the offset is ignored if it's set"
+// @Success 200 {object} []entity.GeoNamePostalCode
+// @Header 200 {integer} X-Total-Count "number of entities on all the pages"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page, missing on the last page"
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/postal [get]
+func (c *GeoNameController) GetGeoNamePostalCodesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := c.getGeoNameFilter(r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	postalCodes, err := c.geoNameService.PostalCodes(ctx, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	setPageHeaders(w, postalCodes.Total, postalCodes.NextCursor)
+	c.ResponseJson(w, r, postalCodes.Items, false)
+}
+
+// @Summary nearest city
+// @Description Reverse geocoding: the cities closest to the location, nearest first
+// @Produce json
+// @Tags geonames
+// @Param lat query number true "latitude"
+// @Param lon query number true "longitude"
+// @Param limit query integer false "max number of cities, 1 by default"
+// @Param radius_km query number false "max distance in km"
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Success 200 {object} []entity.GeoNameNearestCity
+// @Failure 400 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/nearest [get]
+func (c *GeoNameController) GetGeoNameNearestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	if !query.Has("lat") || !query.Has("lon") {
+		c.responseError(w, r, fmt.Errorf("lat and lon are required: %w", utils.ErrInvalidArgument))
+		return
+	}
+	filter, err := gostUtils.FromRequest[entity.GeoNameNearestFilter](r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	cities, err := c.geoNameService.Nearest(ctx, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
 	c.ResponseJson(w, r, cities, false)
 }
 
+func (c *GeoNameController) getHierarchyRequest(r *http.Request) (geoNameID uint32, filter *entity.GeoNameHierarchyFilter, err error) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		return 0, nil, fmt.Errorf("id: %w", utils.ErrInvalidArgument)
+	}
+	filter, err = gostUtils.FromRequest[entity.GeoNameHierarchyFilter](r)
+	if err != nil {
+		return 0, nil, err
+	}
+	return uint32(id), filter, nil
+}
+
+// @Summary geoname by id
+// @Description The continent, country, subdivision, subdivision2 or city with the id, the type is detected if it isn't set
+// @Produce json
+// @Tags geonames
+// @Param id path integer true "GeoNames id"
+// @Param type query string false "type of the entity, detected if empty" Enums(continent, country, subdivision, subdivision2, city)
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Success 200 {object} entity.GeoNameItem
+// @Failure 400 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/{id} [get]
+func (c *GeoNameController) GetGeoNameHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	geoNameID, filter, err := c.getHierarchyRequest(r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	item, err := c.geoNameService.GeoName(ctx, geoNameID, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, item, false)
+}
+
+// @Summary geoname children
+// @Description The entities one level down the hierarchy: the countries of a continent, the subdivisions of a country,
+// @Description the subdivisions2 and the cities of a subdivision, the cities of a subdivision2
+// @Produce json
+// @Tags geonames
+// @Param id path integer true "GeoNames id"
+// @Param type query string false "type of the entity, detected if empty" Enums(continent, country, subdivision, subdivision2, city)
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Param limit query integer false "max number of children"
+// @Success 200 {object} []entity.GeoNameItem
+// @Failure 400 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/{id}/children [get]
+func (c *GeoNameController) GetGeoNameChildrenHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	geoNameID, filter, err := c.getHierarchyRequest(r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	children, err := c.geoNameService.Children(ctx, geoNameID, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, children, false)
+}
+
+// @Summary geoname ancestors
+// @Description The entities up the hierarchy, the parent first: e.g. subdivision2, subdivision, country and continent of a city
+// @Produce json
+// @Tags geonames
+// @Param id path integer true "GeoNames id"
+// @Param type query string false "type of the entity, detected if empty" Enums(continent, country, subdivision, subdivision2, city)
+// @Param lang query string false "language of the names, the names aren't translated if empty"
+// @Success 200 {object} []entity.GeoNameItem
+// @Failure 400 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Failure 503 {string} string "error"
+// @Router /geoname/{id}/ancestors [get]
+func (c *GeoNameController) GetGeoNameAncestorsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	geoNameID, filter, err := c.getHierarchyRequest(r)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+
+	ancestors, err := c.geoNameService.Ancestors(ctx, geoNameID, *filter)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, ancestors, false)
+}
+
 // @Summary geonames csv dump
 // @Produce text/csv
 // @Tags geonames
@@ -147,6 +416,14 @@ func (c *GeoNameController) GetDumpHandler(w http.ResponseWriter, r *http.Reques
 	w.Write(dump)
 }
 
+// setPageHeaders sets the headers with the total count and the next cursor of the page
+func setPageHeaders(w http.ResponseWriter, total int, nextCursor string) {
+	w.Header().Set(entity.GeoNameTotalCountHeader, strconv.Itoa(total))
+	if len(nextCursor) > 0 {
+		w.Header().Set(entity.GeoNameNextCursorHeader, nextCursor)
+	}
+}
+
 func (c *GeoNameController) responseError(w http.ResponseWriter, r *http.Request, err error) {
 	log.FromContext(r.Context()).Error(err.Error())
 	switch {
@@ -156,6 +433,10 @@ func (c *GeoNameController) responseError(w http.ResponseWriter, r *http.Request
 		c.ResponseError(w, err.Error(), http.StatusServiceUnavailable)
 	case errors.Is(err, utils.ErrNotAvailable):
 		c.ResponseError(w, err.Error(), http.StatusInternalServerError)
+	case errors.Is(err, utils.ErrInvalidArgument):
+		c.ResponseError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, utils.ErrNotFound):
+		c.ResponseError(w, err.Error(), http.StatusNotFound)
 	default:
 		c.ResponseError(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
 	}
@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/controller"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/service"
+	"github.com/bldsoft/geos/pkg/utils"
+	gost "github.com/bldsoft/gost/controller"
+	"github.com/bldsoft/gost/log"
+	"github.com/go-chi/chi/v5"
+)
+
+type GeoIPOverrideController struct {
+	gost.BaseController
+	service controller.GeoIPOverrideService
+}
+
+func NewGeoIPOverrideController(service controller.GeoIPOverrideService) *GeoIPOverrideController {
+	return &GeoIPOverrideController{service: service}
+}
+
+func (c *GeoIPOverrideController) db(r *http.Request) service.DBType {
+	return service.DBType(chi.URLParam(r, "db"))
+}
+
+func (c *GeoIPOverrideController) override(w http.ResponseWriter, r *http.Request) (entity.GeoIPOverride, bool) {
+	var override entity.GeoIPOverride
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		c.ResponseError(w, err.Error(), http.StatusBadRequest)
+		return override, false
+	}
+	return override, true
+}
+
+// @Summary GeoIP overrides
+// @Description The network records set at runtime, they are on top of the db and its patches. The expired ones are listed until the next update.
+// @Security ApiKeyAuth
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Success 200 {array} entity.GeoIPOverride
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db} [get]
+func (c *GeoIPOverrideController) GetOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	overrides, err := c.service.Overrides(r.Context(), c.db(r))
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, overrides)
+}
+
+// @Summary GeoIP override
+// @Security ApiKeyAuth
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param id path string true "override ID"
+// @Success 200 {object} entity.GeoIPOverride
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db}/{id} [get]
+func (c *GeoIPOverrideController) GetOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	override, err := c.service.Override(r.Context(), c.db(r), chi.URLParam(r, "id"))
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, override)
+}
+
+// @Summary add GeoIP override
+// @Description The record is City, ISP or Hosting, depending on the db. The override is applied immediately.
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param override body entity.GeoIPOverride true "override, the ID and the timestamps are ignored"
+// @Success 200 {object} entity.GeoIPOverride
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 409 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db} [post]
+func (c *GeoIPOverrideController) AddOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	override, ok := c.override(w, r)
+	if !ok {
+		return
+	}
+	res, err := c.service.AddOverride(r.Context(), c.db(r), override)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, res)
+}
+
+// @Summary replace GeoIP override
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param id path string true "override ID"
+// @Param override body entity.GeoIPOverride true "override, the ID and the timestamps are ignored"
+// @Success 200 {object} entity.GeoIPOverride
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 409 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db}/{id} [put]
+func (c *GeoIPOverrideController) ReplaceOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	override, ok := c.override(w, r)
+	if !ok {
+		return
+	}
+	res, err := c.service.ReplaceOverride(r.Context(), c.db(r), chi.URLParam(r, "id"), override)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, res)
+}
+
+// @Summary delete GeoIP override
+// @Security ApiKeyAuth
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param id path string true "override ID"
+// @Success 200
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 404 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db}/{id} [delete]
+func (c *GeoIPOverrideController) DeleteOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	if err := c.service.DeleteOverride(r.Context(), c.db(r), chi.URLParam(r, "id")); err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseOK(w)
+}
+
+// defaultExpiringWithin is the default period of the expiring list
+const defaultExpiringWithin = 7 * 24 * time.Hour
+
+// @Summary expiring GeoIP patch records and overrides
+// @Description The patch records with valid_until and the runtime overrides with expiresAt that expire within the period, the earliest first.
+// @Security ApiKeyAuth
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param within query string false "period, e.g. 72h, 168h by default"
+// @Success 200 {array} entity.ExpiringGeoIPRecord
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db}/expiring [get]
+func (c *GeoIPOverrideController) GetExpiringHandler(w http.ResponseWriter, r *http.Request) {
+	within := defaultExpiringWithin
+	if param := r.URL.Query().Get("within"); len(param) > 0 {
+		var err error
+		if within, err = time.ParseDuration(param); err != nil {
+			c.ResponseError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	records, err := c.service.Expiring(r.Context(), c.db(r), within)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, records)
+}
+
+// defaultPatchAnalysisLimit is the default number of the reported networks
+const defaultPatchAnalysisLimit = 1000
+
+// @Summary GeoIP patch analysis
+// @Description The networks of the patches and the runtime overrides that overlap each other, the record of the winner is returned for the whole network.
+// @Description The networks that don't change the records of the upstream db are reported as no-ops.
+// @Description The patches are from the bottom layer to the top one, the order of the archive is set by its manifest.json or by the file names.
+// @Security ApiKeyAuth
+// @Produce json
+// @Tags admin
+// @Param db path string true "db type" Enums(city,isp,hosting)
+// @Param limit query int false "max number of the reported networks, 1000 by default, 0 for all"
+// @Success 200 {object} entity.PatchAnalysis
+// @Failure 400 {string} string "error"
+// @Failure 401 {string} string "error"
+// @Failure 403 {string} string "error"
+// @Failure 500 {string} string "error"
+// @Router /overrides/{db}/analysis [get]
+func (c *GeoIPOverrideController) GetPatchAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	limit := defaultPatchAnalysisLimit
+	if param := r.URL.Query().Get("limit"); len(param) > 0 {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil {
+			c.ResponseError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	analysis, err := c.service.AnalyzePatches(r.Context(), c.db(r), limit)
+	if err != nil {
+		c.responseError(w, r, err)
+		return
+	}
+	c.ResponseJson(w, r, analysis)
+}
+
+func (c *GeoIPOverrideController) responseError(w http.ResponseWriter, r *http.Request, err error) {
+	log.FromContext(r.Context()).Error(err.Error())
+	switch {
+	case errors.Is(err, utils.ErrInvalidArgument):
+		c.ResponseError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, utils.ErrNotFound):
+		c.ResponseError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, utils.ErrAlreadyExists):
+		c.ResponseError(w, err.Error(), http.StatusConflict)
+	default:
+		c.ResponseError(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -15,12 +15,17 @@ import (
 
 type ManagementController struct {
 	gost.BaseController
-	geoIpService   controller.GeoIpService
-	geoNameService controller.GeoNameService
+	geoIpService      controller.GeoIpService
+	geoNameService    controller.GeoNameService
+	validationService controller.ValidationService
 }
 
-func NewManagementController(geoIpService controller.GeoIpService, geoNameService controller.GeoNameService) *ManagementController {
-	return &ManagementController{geoIpService: geoIpService, geoNameService: geoNameService}
+func NewManagementController(
+	geoIpService controller.GeoIpService,
+	geoNameService controller.GeoNameService,
+	validationService controller.ValidationService,
+) *ManagementController {
+	return &ManagementController{geoIpService: geoIpService, geoNameService: geoNameService, validationService: validationService}
 }
 
 func (c *ManagementController) CheckGeoIPUpdatesHandler(w http.ResponseWriter, r *http.Request) {
@@ -72,3 +77,17 @@ func (c *ManagementController) UpdateGeonamesHandler(w http.ResponseWriter, r *h
 	}
 	c.ResponseOK(w)
 }
+
+// ValidateHandler checks that the GeoNameIDs of the city db (with the patches) are in GeoNames (with the patches)
+func (c *ManagementController) ValidateHandler(w http.ResponseWriter, r *http.Request) {
+	report, err := c.validationService.Validate(r.Context())
+	if err != nil {
+		if errors.Is(err, utils.ErrNotReady) {
+			c.ResponseError(w, err.Error(), http.StatusServiceUnavailable)
+		} else {
+			c.ResponseError(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	c.ResponseJson(w, r, report)
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeLookup       ApiKeyScope = "lookup"
+	ApiKeyScopeDump         ApiKeyScope = "dump"
+	ApiKeyScopeUpdate       ApiKeyScope = "update"
+	ApiKeyScopeGeoNamesDump ApiKeyScope = "geonames-dump"
+)
+
+var ApiKeyScopes = []ApiKeyScope{ApiKeyScopeLookup, ApiKeyScopeDump, ApiKeyScopeUpdate, ApiKeyScopeGeoNamesDump}
+
+// RateLimit is a token bucket: RPS tokens are added every second, up to Burst
+type RateLimit struct {
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+type ApiKey struct {
+	ID         string                    `json:"id"`
+	Name       string                    `json:"name,omitempty"`
+	Key        string                    `json:"key,omitempty"`
+	Scopes     []ApiKeyScope             `json:"scopes"`
+	ExpiresAt  *time.Time                `json:"expiresAt,omitempty"`
+	RateLimits map[ApiKeyScope]RateLimit `json:"rateLimits,omitempty"` // overrides the default limits
+}
+
+func (k *ApiKey) HasScope(scope ApiKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+func (k *ApiKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// ApiKeyUsage is an API key without the secret, but with request counters
+type ApiKeyUsage struct {
+	ID         string                    `json:"id"`
+	Name       string                    `json:"name,omitempty"`
+	Scopes     []ApiKeyScope             `json:"scopes"`
+	ExpiresAt  *time.Time                `json:"expiresAt,omitempty"`
+	RateLimits map[ApiKeyScope]RateLimit `json:"rateLimits,omitempty"`
+	Requests   uint64                    `json:"requests"`
+	Denied     uint64                    `json:"denied"`
+	LastUsedAt *time.Time                `json:"lastUsedAt,omitempty"`
+}
@@ -57,7 +57,8 @@ type City struct {
 		IsSatelliteProvider bool `maxminddb:"is_satellite_provider" json:"isSatelliteProvider,omitempty"`
 	} `maxminddb:"traits" json:"traits,omitempty"`
 
-	ISP *ISP `json:"ISP,omitempty"`
+	ISP      *ISP          `json:"ISP,omitempty"`
+	GeoNames *CityGeoNames `json:"geoNames,omitempty"` // with enrich=geonames only
 }
 
 func (city City) ToMMDBType() mmdbtype.Map {
@@ -129,7 +130,6 @@ func (city City) ToMMDBType() mmdbtype.Map {
 
 	if l := len(city.Subdivisions); l > 0 {
 		s := make(mmdbtype.Slice, 0, l)
-		res[mmdbtype.String("subdivisions")] = s
 		for _, sd := range city.Subdivisions {
 			sdNames := make(mmdbtype.Map)
 			for key, value := range sd.Names {
@@ -141,6 +141,7 @@ func (city City) ToMMDBType() mmdbtype.Map {
 				mmdbtype.String("names"):      sdNames,
 			})
 		}
+		res[mmdbtype.String("subdivisions")] = s
 	}
 
 	res[mmdbtype.String("traits")] = mmdbtype.Map{
@@ -187,6 +188,31 @@ func (record City) MarshalCSV() (names, row []string, err error) {
 	return names, row, nil
 }
 
+// UnmarshalCSV is the inverse of MarshalCSV, the row is without the network.
+// The subdivision is set if its ID isn't zero, the names aren't in the CSV.
+func (record *City) UnmarshalCSV(row []string) error {
+	names, _, _ := City{}.MarshalCSV()
+	p := newCSVRowParser(names, row)
+	record.City.GeoNameID = uint(p.Uint(32))
+	if subdivisionGeonameID := uint(p.Uint(32)); subdivisionGeonameID != 0 {
+		record.Subdivisions = append(record.Subdivisions, struct {
+			GeoNameID uint              `maxminddb:"geoname_id" json:"geoNameID,omitempty"`
+			IsoCode   string            `maxminddb:"iso_code" json:"isoCode,omitempty"`
+			Names     map[string]string `maxminddb:"names" json:"names,omitempty"`
+		}{GeoNameID: subdivisionGeonameID})
+	}
+	record.Country.GeoNameID = uint(p.Uint(32))
+	record.RegisteredCountry.GeoNameID = uint(p.Uint(32))
+	record.RepresentedCountry.GeoNameID = uint(p.Uint(32))
+	record.Continent.GeoNameID = uint(p.Uint(32))
+	record.Traits.IsAnonymousProxy = p.Bool()
+	record.Traits.IsSatelliteProvider = p.Bool()
+	record.Location.Latitude = p.Float()
+	record.Location.Longitude = p.Float()
+	record.Location.AccuracyRadius = uint16(p.Uint(16))
+	return p.Err()
+}
+
 func formatBool(b bool) string {
 	if b {
 		return "1"
@@ -204,6 +230,17 @@ type ISP struct {
 	AutonomousSystemNumber       uint   `maxminddb:"autonomous_system_number"`
 }
 
+func (record ISP) ToMMDBType() mmdbtype.Map {
+	res := make(mmdbtype.Map)
+	res[mmdbtype.String("autonomous_system_organization")] = mmdbtype.String(record.AutonomousSystemOrganization)
+	res[mmdbtype.String("isp")] = mmdbtype.String(record.ISP)
+	res[mmdbtype.String("mobile_country_code")] = mmdbtype.String(record.MobileCountryCode)
+	res[mmdbtype.String("mobile_network_code")] = mmdbtype.String(record.MobileNetworkCode)
+	res[mmdbtype.String("organization")] = mmdbtype.String(record.Organization)
+	res[mmdbtype.String("autonomous_system_number")] = mmdbtype.Uint32(record.AutonomousSystemNumber)
+	return res
+}
+
 func (record ISP) MarshalCSV() (names, row []string, err error) {
 	names = []string{
 		"autonomous_system_organization",
@@ -217,9 +254,22 @@ func (record ISP) MarshalCSV() (names, row []string, err error) {
 		record.AutonomousSystemOrganization,
 		record.ISP,
 		record.MobileCountryCode,
-		record.MobileCountryCode,
+		record.MobileNetworkCode,
 		record.Organization,
 		strconv.FormatUint(uint64(record.AutonomousSystemNumber), 10),
 	}
 	return names, row, nil
 }
+
+// UnmarshalCSV is the inverse of MarshalCSV, the row is without the network
+func (record *ISP) UnmarshalCSV(row []string) error {
+	names, _, _ := ISP{}.MarshalCSV()
+	p := newCSVRowParser(names, row)
+	record.AutonomousSystemOrganization = p.String()
+	record.ISP = p.String()
+	record.MobileCountryCode = p.String()
+	record.MobileNetworkCode = p.String()
+	record.Organization = p.String()
+	record.AutonomousSystemNumber = uint(p.Uint(32))
+	return p.Err()
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// CityEnrichment is the additional data the GeoIP city is enriched with
+type CityEnrichment string
+
+const (
+	// CityEnrichmentGeoNames adds the GeoNames entities of the city GeoNameIDs
+	CityEnrichmentGeoNames CityEnrichment = "geonames"
+)
+
+func (e CityEnrichment) Validate() error {
+	switch e {
+	case CityEnrichmentGeoNames:
+		return nil
+	}
+	return fmt.Errorf("unknown city enrichment %q: %w", e, utils.ErrInvalidArgument)
+}
+
+// CityGeoNames are the GeoNames entities of the GeoIP city. The entities missing in GeoNames are omitted.
+type CityGeoNames struct {
+	Continent    *GeoNameContinent `json:"continent,omitempty"`
+	Country      *GeoNameCountry   `json:"country,omitempty"`
+	Subdivisions []*GeoNameItem    `json:"subdivisions,omitempty"` // subdivision or subdivision2, in the order of the city subdivisions
+	City         *GeoName          `json:"city,omitempty"`
+}
@@ -12,7 +12,8 @@ type CityLite struct {
 }
 type CityLiteDb struct {
 	City struct {
-		Names map[string]string `maxminddb:"names" json:"names,omitempty"`
+		GeoNameID uint32            `maxminddb:"geoname_id" json:"geoNameID,omitempty"`
+		Names     map[string]string `maxminddb:"names" json:"names,omitempty"`
 	} `maxminddb:"city" json:"city,omitempty"`
 	Country struct {
 		ISOCode string            `maxminddb:"iso_code" json:"isoCode,omitempty"`
@@ -27,11 +28,20 @@ type LocationLite struct {
 	TimeZone  string  `maxminddb:"time_zone" json:"timeZone,omitempty"`
 }
 
-func DbToCityLite(cityLiteDb *CityLiteDb, lang string) *CityLite {
+// DbToCityLite picks the names in the language. The names missing in the database are taken from GeoNames, if it's not nil.
+func DbToCityLite(cityLiteDb *CityLiteDb, lang string, geoNames GeoNameLocalizer) *CityLite {
 	var cityLite CityLite
 	cityLite.City.Name = cityLiteDb.City.Names[lang]
 	cityLite.Country.ISOCode = cityLiteDb.Country.ISOCode
 	cityLite.Country.Name = cityLiteDb.Country.Names[lang]
 	cityLite.Location = cityLiteDb.Location
+	if geoNames != nil {
+		if len(cityLite.City.Name) == 0 && cityLiteDb.City.GeoNameID != 0 {
+			cityLite.City.Name = geoNames.LocalizedName(int(cityLiteDb.City.GeoNameID), lang)
+		}
+		if len(cityLite.Country.Name) == 0 && len(cityLite.Country.ISOCode) != 0 {
+			cityLite.Country.Name = geoNames.LocalizedCountryName(cityLite.Country.ISOCode, lang)
+		}
+	}
 	return &cityLite
 }
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestISPMarshalCSV(t *testing.T) {
+	isp := ISP{
+		AutonomousSystemOrganization: "Org AS",
+		ISP:                          "ISP",
+		MobileCountryCode:            "257",
+		MobileNetworkCode:            "02",
+		Organization:                 "Org",
+		AutonomousSystemNumber:       6697,
+	}
+	names, row, err := isp.MarshalCSV()
+	require.NoError(t, err)
+	require.Len(t, row, len(names))
+	columns := make(map[string]string)
+	for i, name := range names {
+		columns[name] = row[i]
+	}
+	assert.Equal(t, "257", columns["mobile_country_code"])
+	assert.Equal(t, "02", columns["mobile_network_code"], "the network code isn't the country code")
+
+	var parsed ISP
+	require.NoError(t, parsed.UnmarshalCSV(row))
+	assert.Equal(t, isp, parsed)
+}
+
+func TestCityToMMDBTypeSubdivisions(t *testing.T) {
+	var city City
+	require.NoError(t, json.Unmarshal([]byte(`{"subdivisions": [
+		{"geoNameID": 625143, "isoCode": "HM", "names": {"en": "Minsk City"}},
+		{"geoNameID": 625144, "isoCode": "MI"}
+	]}`), &city))
+
+	subdivisions, ok := city.ToMMDBType()[mmdbtype.String("subdivisions")].(mmdbtype.Slice)
+	require.True(t, ok)
+	require.Len(t, subdivisions, 2, "all the subdivisions are converted")
+	first := subdivisions[0].(mmdbtype.Map)
+	assert.Equal(t, mmdbtype.Uint64(625143), first[mmdbtype.String("geoname_id")])
+	assert.Equal(t, mmdbtype.String("HM"), first[mmdbtype.String("iso_code")])
+	assert.Equal(t, mmdbtype.Map{mmdbtype.String("en"): mmdbtype.String("Minsk City")}, first[mmdbtype.String("names")])
+	assert.Equal(t, mmdbtype.String("MI"), subdivisions[1].(mmdbtype.Map)[mmdbtype.String("iso_code")])
+
+	_, ok = City{}.ToMMDBType()[mmdbtype.String("subdivisions")]
+	assert.False(t, ok, "no subdivisions")
+}
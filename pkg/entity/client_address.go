@@ -0,0 +1,8 @@
+package entity
+
+// ClientAddress describes how the client IP ("me" address) was chosen
+type ClientAddress struct {
+	IP   string   `json:"ip"`
+	Peer string   `json:"peer"`
+	Hops []string `json:"hops,omitempty"` // forwarding chain from the client side to the peer, as reported by the proxies
+}
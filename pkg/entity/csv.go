@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// csvRowParser parses the columns of a CSV row one by one, the first error is kept
+type csvRowParser struct {
+	names []string
+	row   []string
+	i     int
+	err   error
+}
+
+func newCSVRowParser(names, row []string) *csvRowParser {
+	p := &csvRowParser{names: names, row: row}
+	if len(row) != len(names) {
+		p.err = fmt.Errorf("%d columns, %d expected: %w", len(row), len(names), utils.ErrInvalidArgument)
+	}
+	return p
+}
+
+func (p *csvRowParser) next() (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	p.i++
+	return p.row[p.i-1], true
+}
+
+func (p *csvRowParser) fail(err error) {
+	p.err = fmt.Errorf("%s: %w: %w", p.names[p.i-1], utils.ErrInvalidArgument, err)
+}
+
+func (p *csvRowParser) String() string {
+	value, _ := p.next()
+	return value
+}
+
+func (p *csvRowParser) Uint(bitSize int) uint64 {
+	value, ok := p.next()
+	if !ok || len(value) == 0 {
+		return 0
+	}
+	res, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		p.fail(err)
+	}
+	return res
+}
+
+func (p *csvRowParser) Float() float64 {
+	value, ok := p.next()
+	if !ok || len(value) == 0 {
+		return 0
+	}
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.fail(err)
+	}
+	return res
+}
+
+// Bool is the inverse of formatBool, "true" and "false" are accepted too
+func (p *csvRowParser) Bool() bool {
+	value, ok := p.next()
+	if !ok || len(value) == 0 {
+		return false
+	}
+	res, err := strconv.ParseBool(value)
+	if err != nil {
+		p.fail(err)
+	}
+	return res
+}
+
+func (p *csvRowParser) Err() error {
+	return p.err
+}
@@ -75,24 +75,32 @@ type PatchVersion = ModTimeVersion
 type GeoNamesVersion = ModTimeVersion
 
 type PatchedMMDBVersion struct {
-	DB    MMDBVersion     `json:"db,omitempty"`
-	Patch *ModTimeVersion `json:"patch,omitempty"`
+	DB        MMDBVersion     `json:"db,omitempty"`
+	Patch     *ModTimeVersion `json:"patch,omitempty"`
+	Overrides *ModTimeVersion `json:"overrides,omitempty"` // the last change of the runtime overrides
 }
 
 func (v PatchedMMDBVersion) Compare(other PatchedMMDBVersion) int {
 	vPatch := cmp.Or(v.Patch, new(ModTimeVersion))
 	oPatch := cmp.Or(other.Patch, new(ModTimeVersion))
+	vOverrides := cmp.Or(v.Overrides, new(ModTimeVersion))
+	oOverrides := cmp.Or(other.Overrides, new(ModTimeVersion))
 	return cmp.Or(
 		v.DB.Compare(other.DB),
 		vPatch.Compare(*oPatch),
+		vOverrides.Compare(*oOverrides),
 	)
 }
 
 func (v PatchedMMDBVersion) String() string {
-	if v.Patch == nil {
-		return fmt.Sprintf("%s-%d", v.DB.Version.String(), v.DB.BuildEpoch)
+	res := fmt.Sprintf("%s-%d", v.DB.Version.String(), v.DB.BuildEpoch)
+	if v.Patch != nil {
+		res += fmt.Sprintf("/p-%d", time.Time(*v.Patch).Unix())
 	}
-	return fmt.Sprintf("%s-%d/p-%d", v.DB.Version.String(), v.DB.BuildEpoch, time.Time(*v.Patch).Unix())
+	if v.Overrides != nil {
+		res += fmt.Sprintf("/o-%d", time.Time(*v.Overrides).Unix())
+	}
+	return res
 }
 
 type PatchedGeoNamesVersion struct {
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// GeoIPOverride is a network record of the city, ISP or hosting db set at runtime through the management API.
+// The overrides are on top of the db and its patches.
+type GeoIPOverride struct {
+	ID        string          `json:"id"`
+	Network   string          `json:"network"`
+	Record    json.RawMessage `json:"record" swaggertype:"object"` // City, ISP or Hosting, depending on the db
+	Author    string          `json:"author"`                      // the API key name if it's empty
+	Reason    string          `json:"reason"`
+	ExpiresAt *time.Time      `json:"expiresAt,omitempty"` // never expires if it's empty
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+func (o *GeoIPOverride) Expired(now time.Time) bool {
+	return o.ExpiresAt != nil && !now.Before(*o.ExpiresAt)
+}
+
+// Validate checks the fields set by the user, the record is checked by the db
+func (o *GeoIPOverride) Validate() error {
+	if _, _, err := net.ParseCIDR(o.Network); err != nil {
+		return fmt.Errorf("network: %w: %w", utils.ErrInvalidArgument, err)
+	}
+	if len(o.Record) == 0 || string(o.Record) == "null" {
+		return fmt.Errorf("record is required: %w", utils.ErrInvalidArgument)
+	}
+	if len(o.Author) == 0 {
+		return fmt.Errorf("author is required: %w", utils.ErrInvalidArgument)
+	}
+	if len(o.Reason) == 0 {
+		return fmt.Errorf("reason is required: %w", utils.ErrInvalidArgument)
+	}
+	return nil
+}
@@ -1,17 +1,20 @@
 package entity
 
 import (
+	"cmp"
 	"encoding/json"
+	"strings"
 
 	"github.com/mkrou/geonames/models"
 )
 
 type GeoName struct {
 	*models.Geoname
-	ContinentCode   string `csv:"continent code"`
-	ContinentName   string `csv:"continent name"`
-	CountryName     string `csv:"country name"`
-	SubdivisionName string `csv:"subdivision name"`
+	ContinentCode    string `csv:"continent code"`
+	ContinentName    string `csv:"continent name"`
+	CountryName      string `csv:"country name"`
+	SubdivisionName  string `csv:"subdivision name"`
+	Subdivision2Name string `csv:"subdivision2 name"`
 }
 
 // same as models.Geoname, but with json tags
@@ -73,21 +76,69 @@ func (g GeoName) GetTimeZone() string {
 	return g.Timezone
 }
 
+func (g GeoName) GetFeatureCode() string {
+	return g.Geoname.Code
+}
+
+func (g GeoName) GetSubdivisionCode() string {
+	return g.GetCountryCode() + "." + g.Admin1Code
+}
+
+func (g GeoName) GetLatitude() float64 {
+	return g.Latitude
+}
+
+func (g GeoName) GetLongitude() float64 {
+	return g.Longitude
+}
+
+func (g GeoName) GetAlternateNames() []string {
+	names := []string{g.AsciiName}
+	if len(g.AlternateNames) != 0 {
+		names = append(names, strings.Split(g.AlternateNames, ",")...)
+	}
+	return names
+}
+
+func (g GeoName) GetPopulation() int {
+	return g.Population
+}
+
+// Localized returns a copy of the city with the names in the language. The untranslated names are kept.
+func (g *GeoName) Localized(l GeoNameLocalizer, lang string) *GeoName {
+	model := *g.Geoname
+	model.Name = cmp.Or(l.LocalizedName(g.Id, lang), g.Name)
+	subdivision2Name := g.Subdivision2Name
+	if len(g.Admin2Code) != 0 {
+		subdivision2Name = cmp.Or(l.LocalizedSubdivisionName(g.GetCountryCode()+"."+g.Admin1Code+"."+g.Admin2Code, lang), subdivision2Name)
+	}
+	return &GeoName{
+		Geoname:          &model,
+		ContinentCode:    g.ContinentCode,
+		ContinentName:    cmp.Or(l.LocalizedContinentName(g.ContinentCode, lang), g.ContinentName),
+		CountryName:      cmp.Or(l.LocalizedCountryName(g.GetCountryCode(), lang), g.CountryName),
+		SubdivisionName:  cmp.Or(l.LocalizedSubdivisionName(g.GetCountryCode()+"."+g.Admin1Code, lang), g.SubdivisionName),
+		Subdivision2Name: subdivision2Name,
+	}
+}
+
 func (s GeoName) MarshalJSON() ([]byte, error) {
 
 	type ModelTmp modelGeoNameJson
 	return json.Marshal(struct {
 		*ModelTmp
-		ContinentCode   string `csv:"continent code" json:"continentCode"`
-		ContinentName   string `csv:"continent name" json:"continentName"`
-		CountryName     string `csv:"country name" json:"countryName"`
-		SubdivisionName string `csv:"subdivision name" json:"subdivisionName"`
+		ContinentCode    string `csv:"continent code" json:"continentCode"`
+		ContinentName    string `csv:"continent name" json:"continentName"`
+		CountryName      string `csv:"country name" json:"countryName"`
+		SubdivisionName  string `csv:"subdivision name" json:"subdivisionName"`
+		Subdivision2Name string `csv:"subdivision2 name" json:"subdivision2Name,omitempty"`
 	}{
-		ModelTmp:        (*ModelTmp)(s.Geoname),
-		ContinentCode:   s.ContinentCode,
-		ContinentName:   s.ContinentName,
-		CountryName:     s.CountryName,
-		SubdivisionName: s.SubdivisionName,
+		ModelTmp:         (*ModelTmp)(s.Geoname),
+		ContinentCode:    s.ContinentCode,
+		ContinentName:    s.ContinentName,
+		CountryName:      s.CountryName,
+		SubdivisionName:  s.SubdivisionName,
+		Subdivision2Name: s.Subdivision2Name,
 	})
 }
 
@@ -95,10 +146,11 @@ func (s *GeoName) UnmarshalJSON(data []byte) error {
 	type ModelTmp modelGeoNameJson
 	type tmp struct {
 		*ModelTmp
-		ContinentCode   string `csv:"continent code" json:"continentCode"`
-		ContinentName   string `csv:"continent name" json:"continentName"`
-		CountryName     string `csv:"country name" json:"countryName"`
-		SubdivisionName string `csv:"subdivision name" json:"subdivisionName"`
+		ContinentCode    string `csv:"continent code" json:"continentCode"`
+		ContinentName    string `csv:"continent name" json:"continentName"`
+		CountryName      string `csv:"country name" json:"countryName"`
+		SubdivisionName  string `csv:"subdivision name" json:"subdivisionName"`
+		Subdivision2Name string `csv:"subdivision2 name" json:"subdivision2Name,omitempty"`
 	}
 	var geoNameJson tmp
 	if err := json.Unmarshal(data, &geoNameJson); err != nil {
@@ -109,5 +161,6 @@ func (s *GeoName) UnmarshalJSON(data []byte) error {
 	s.ContinentName = geoNameJson.ContinentName
 	s.CountryName = geoNameJson.CountryName
 	s.SubdivisionName = geoNameJson.SubdivisionName
+	s.Subdivision2Name = geoNameJson.Subdivision2Name
 	return nil
 }
@@ -1,6 +1,9 @@
 package entity
 
-import "encoding/json"
+import (
+	"cmp"
+	"encoding/json"
+)
 
 type GeoNameContinent struct {
 	geonameID int
@@ -62,6 +65,11 @@ func (c GeoNameContinent) GetTimeZone() string {
 	return ""
 }
 
+// Localized returns a copy of the continent with the name in the language. The untranslated name is kept.
+func (c *GeoNameContinent) Localized(l GeoNameLocalizer, lang string) *GeoNameContinent {
+	return NewGeoNameContinent(c.geonameID, c.code, cmp.Or(l.LocalizedName(c.geonameID, lang), c.name))
+}
+
 func (s GeoNameContinent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(geoNameContinentJson{
 		GeonameID: s.GetGeoNameID(),
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"cmp"
 	"encoding/json"
 
 	"github.com/mkrou/geonames/models"
@@ -58,6 +59,10 @@ func (s GeoNameCountry) GetCountryName() string {
 	return s.GetName()
 }
 
+func (s GeoNameCountry) GetPopulation() int {
+	return s.Population
+}
+
 func (s GeoNameCountry) GetSubdivisionName() string {
 	return ""
 }
@@ -70,6 +75,16 @@ func (s GeoNameCountry) GetTimeZone() string {
 	return ""
 }
 
+// Localized returns a copy of the country with the names in the language. The untranslated names are kept.
+func (s *GeoNameCountry) Localized(l GeoNameLocalizer, lang string) *GeoNameCountry {
+	model := *s.Country
+	model.Name = cmp.Or(l.LocalizedName(s.GeonameID, lang), s.Country.Name)
+	return &GeoNameCountry{
+		Country:       &model,
+		ContinentName: cmp.Or(l.LocalizedContinentName(s.Continent, lang), s.ContinentName),
+	}
+}
+
 func (s GeoNameCountry) MarshalJSON() ([]byte, error) {
 	type ModelTmp modelCountryJson
 	return json.Marshal(struct {
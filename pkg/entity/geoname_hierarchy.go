@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// GeoNameType is the level of the GeoNames hierarchy: continent -> country -> subdivision -> subdivision2 -> city
+type GeoNameType string
+
+const (
+	GeoNameTypeContinent    GeoNameType = "continent"
+	GeoNameTypeCountry      GeoNameType = "country"
+	GeoNameTypeSubdivision  GeoNameType = "subdivision"
+	GeoNameTypeSubdivision2 GeoNameType = "subdivision2"
+	GeoNameTypeCity         GeoNameType = "city"
+)
+
+// GeoNameTypes are ordered from the most specific to the least, the type of an ID is detected in this order.
+// Patch records use the same ID on every level.
+var GeoNameTypes = []GeoNameType{
+	GeoNameTypeCity,
+	GeoNameTypeSubdivision2,
+	GeoNameTypeSubdivision,
+	GeoNameTypeCountry,
+	GeoNameTypeContinent,
+}
+
+func (t GeoNameType) Validate() error {
+	switch t {
+	case "", GeoNameTypeContinent, GeoNameTypeCountry, GeoNameTypeSubdivision, GeoNameTypeSubdivision2, GeoNameTypeCity:
+		return nil
+	}
+	return fmt.Errorf("unknown geoname type %q: %w", t, utils.ErrInvalidArgument)
+}
+
+type GeoNameHierarchyFilter struct {
+	Type  GeoNameType `schema:"type" json:"type,omitempty"` // the type of the entity with the ID, detected if empty
+	Lang  string      `schema:"lang" json:"lang,omitempty"`
+	Limit uint32      `schema:"limit" json:"limit"` // the max number of children, 0 means unlimited
+}
+
+func (f *GeoNameHierarchyFilter) Validate() error {
+	return f.Type.Validate()
+}
+
+// GeoNameItem is a GeoNames entity of any type, exactly one of the entities is set
+type GeoNameItem struct {
+	Type         GeoNameType               `json:"type"`
+	Continent    *GeoNameContinent         `json:"continent,omitempty"`
+	Country      *GeoNameCountry           `json:"country,omitempty"`
+	Subdivision  *GeoNameAdminSubdivision  `json:"subdivision,omitempty"`
+	Subdivision2 *GeoNameAdminSubdivision2 `json:"subdivision2,omitempty"`
+	City         *GeoName                  `json:"city,omitempty"`
+}
+
+func NewGeoNameItem(e GeoNameEntity) *GeoNameItem {
+	switch e := e.(type) {
+	case *GeoNameContinent:
+		return &GeoNameItem{Type: GeoNameTypeContinent, Continent: e}
+	case *GeoNameCountry:
+		return &GeoNameItem{Type: GeoNameTypeCountry, Country: e}
+	case *GeoNameAdminSubdivision:
+		return &GeoNameItem{Type: GeoNameTypeSubdivision, Subdivision: e}
+	case *GeoNameAdminSubdivision2:
+		return &GeoNameItem{Type: GeoNameTypeSubdivision2, Subdivision2: e}
+	case *GeoName:
+		return &GeoNameItem{Type: GeoNameTypeCity, City: e}
+	}
+	return nil
+}
+
+// Entity returns the entity that is set
+func (i *GeoNameItem) Entity() GeoNameEntity {
+	switch i.Type {
+	case GeoNameTypeContinent:
+		return i.Continent
+	case GeoNameTypeCountry:
+		return i.Country
+	case GeoNameTypeSubdivision:
+		return i.Subdivision
+	case GeoNameTypeSubdivision2:
+		return i.Subdivision2
+	case GeoNameTypeCity:
+		return i.City
+	}
+	return nil
+}
+
+// Localized returns a copy of the item with the names in the language
+func (i *GeoNameItem) Localized(l GeoNameLocalizer, lang string) *GeoNameItem {
+	switch i.Type {
+	case GeoNameTypeContinent:
+		return NewGeoNameItem(i.Continent.Localized(l, lang))
+	case GeoNameTypeCountry:
+		return NewGeoNameItem(i.Country.Localized(l, lang))
+	case GeoNameTypeSubdivision:
+		return NewGeoNameItem(i.Subdivision.Localized(l, lang))
+	case GeoNameTypeSubdivision2:
+		return NewGeoNameItem(i.Subdivision2.Localized(l, lang))
+	case GeoNameTypeCity:
+		return NewGeoNameItem(i.City.Localized(l, lang))
+	}
+	return i
+}
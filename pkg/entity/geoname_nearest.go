@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+const (
+	DefaultGeoNameNearestLimit = 1
+	MaxGeoNameNearestLimit     = 1000
+)
+
+type GeoNameNearestFilter struct {
+	Latitude  float64 `schema:"lat" json:"lat"`
+	Longitude float64 `schema:"lon" json:"lon"`
+	Limit     uint32  `schema:"limit" json:"limit"`        // 1 by default
+	RadiusKm  float64 `schema:"radius_km" json:"radiusKm"` // 0 means unlimited
+	Lang      string  `schema:"lang" json:"lang,omitempty"`
+}
+
+func (f *GeoNameNearestFilter) Validate() error {
+	// the negated ranges reject NaN too
+	switch {
+	case !(f.Latitude >= -90 && f.Latitude <= 90):
+		return fmt.Errorf("lat must be in [-90, 90]: %w", utils.ErrInvalidArgument)
+	case !(f.Longitude >= -180 && f.Longitude <= 180):
+		return fmt.Errorf("lon must be in [-180, 180]: %w", utils.ErrInvalidArgument)
+	case !(f.RadiusKm >= 0) || math.IsInf(f.RadiusKm, 1):
+		return fmt.Errorf("radius_km must be a non-negative number: %w", utils.ErrInvalidArgument)
+	case f.Limit > MaxGeoNameNearestLimit:
+		return fmt.Errorf("limit must not exceed %d: %w", MaxGeoNameNearestLimit, utils.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// GeoNameNearestCity is a city found by reverse geocoding
+type GeoNameNearestCity struct {
+	City       *GeoName `json:"city"`
+	DistanceKm float64  `json:"distanceKm"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoNameNearestFilterValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  GeoNameNearestFilter
+		wantErr bool
+	}{
+		{"valid", GeoNameNearestFilter{Latitude: 53.9, Longitude: 27.56667, RadiusKm: 10}, false},
+		{"the bounds", GeoNameNearestFilter{Latitude: -90, Longitude: 180}, false},
+		{"latitude out of range", GeoNameNearestFilter{Latitude: 90.1}, true},
+		{"longitude out of range", GeoNameNearestFilter{Longitude: -180.1}, true},
+		{"NaN latitude", GeoNameNearestFilter{Latitude: math.NaN()}, true},
+		{"NaN longitude", GeoNameNearestFilter{Longitude: math.NaN()}, true},
+		{"infinite latitude", GeoNameNearestFilter{Latitude: math.Inf(1)}, true},
+		{"infinite longitude", GeoNameNearestFilter{Longitude: math.Inf(-1)}, true},
+		{"negative radius", GeoNameNearestFilter{RadiusKm: -1}, true},
+		{"NaN radius", GeoNameNearestFilter{RadiusKm: math.NaN()}, true},
+		{"infinite radius", GeoNameNearestFilter{RadiusKm: math.Inf(1)}, true},
+		{"limit", GeoNameNearestFilter{Limit: MaxGeoNameNearestLimit + 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package entity
+
+import "cmp"
+
+// GeoNamePostalCode is a postal code from the GeoNames postal code dumps (https://download.geonames.org/export/zip/).
+// The postal codes have no GeoNames id, a code can be shared by several places.
+type GeoNamePostalCode struct {
+	CountryCode string  `csv:"country code" json:"countryCode"`
+	PostalCode  string  `csv:"postal code" json:"postalCode"`
+	PlaceName   string  `csv:"place name" json:"placeName"`
+	AdminName1  string  `csv:"admin name1" json:"adminName1"` // state
+	AdminCode1  string  `csv:"admin code1" json:"adminCode1"`
+	AdminName2  string  `csv:"admin name2" json:"adminName2"` // county/province
+	AdminCode2  string  `csv:"admin code2" json:"adminCode2"`
+	AdminName3  string  `csv:"admin name3" json:"adminName3"` // community
+	AdminCode3  string  `csv:"admin code3" json:"adminCode3"`
+	Latitude    float64 `csv:"latitude,omitempty" json:"latitude"`
+	Longitude   float64 `csv:"longitude,omitempty" json:"longitude"`
+	Accuracy    int     `csv:"accuracy,omitempty" json:"accuracy,omitempty"` // 1 = estimated, 4 = geonameid, 6 = centroid of addresses or shape
+
+	ContinentCode string `csv:"-" json:"continentCode"`
+	ContinentName string `csv:"-" json:"continentName"`
+	CountryName   string `csv:"-" json:"countryName"`
+}
+
+// GeoNamePostalCodeHeaders are the columns of the postal code dump, it has no header
+var GeoNamePostalCodeHeaders = []string{
+	"country code", "postal code", "place name",
+	"admin name1", "admin code1", "admin name2", "admin code2", "admin name3", "admin code3",
+	"latitude", "longitude", "accuracy",
+}
+
+func (p GeoNamePostalCode) GetGeoNameID() int {
+	return 0
+}
+
+// GetName returns the postal code, the place name is matched as an alternate name
+func (p GeoNamePostalCode) GetName() string {
+	return p.PostalCode
+}
+
+func (p GeoNamePostalCode) GetContinentCode() string {
+	return p.ContinentCode
+}
+
+func (p GeoNamePostalCode) GetContinentName() string {
+	return p.ContinentName
+}
+
+func (p GeoNamePostalCode) GetCountryCode() string {
+	return p.CountryCode
+}
+
+func (p GeoNamePostalCode) GetCountryName() string {
+	return p.CountryName
+}
+
+func (p GeoNamePostalCode) GetSubdivisionName() string {
+	return p.AdminName1
+}
+
+func (p GeoNamePostalCode) GetCityName() string {
+	return p.PlaceName
+}
+
+func (p GeoNamePostalCode) GetTimeZone() string {
+	return ""
+}
+
+func (p GeoNamePostalCode) GetSubdivisionCode() string {
+	return p.CountryCode + "." + p.AdminCode1
+}
+
+func (p GeoNamePostalCode) GetLatitude() float64 {
+	return p.Latitude
+}
+
+func (p GeoNamePostalCode) GetLongitude() float64 {
+	return p.Longitude
+}
+
+func (p GeoNamePostalCode) GetAlternateNames() []string {
+	return []string{p.PlaceName}
+}
+
+// Localized returns a copy of the postal code with the continent and country names in the language.
+// The place and admin names come from the postal dump and aren't translated.
+func (p *GeoNamePostalCode) Localized(l GeoNameLocalizer, lang string) *GeoNamePostalCode {
+	res := *p
+	res.ContinentName = cmp.Or(l.LocalizedContinentName(p.ContinentCode, lang), p.ContinentName)
+	res.CountryName = cmp.Or(l.LocalizedCountryName(p.CountryCode, lang), p.CountryName)
+	return &res
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"cmp"
 	"encoding/json"
 	"strings"
 
@@ -66,6 +67,26 @@ func (s GeoNameAdminSubdivision) GetTimeZone() string {
 	return ""
 }
 
+func (s GeoNameAdminSubdivision) GetSubdivisionCode() string {
+	return s.AdminDivision.Code
+}
+
+func (s GeoNameAdminSubdivision) GetAlternateNames() []string {
+	return []string{s.AsciiName}
+}
+
+// Localized returns a copy of the subdivision with the names in the language. The untranslated names are kept.
+func (s *GeoNameAdminSubdivision) Localized(l GeoNameLocalizer, lang string) *GeoNameAdminSubdivision {
+	model := *s.AdminDivision
+	model.Name = cmp.Or(l.LocalizedName(s.GeonameId, lang), s.AdminDivision.Name)
+	return &GeoNameAdminSubdivision{
+		AdminDivision: &model,
+		ContinentCode: s.ContinentCode,
+		ContinentName: cmp.Or(l.LocalizedContinentName(s.ContinentCode, lang), s.ContinentName),
+		CountryName:   cmp.Or(l.LocalizedCountryName(s.GetCountryCode(), lang), s.CountryName),
+	}
+}
+
 func (s GeoNameAdminSubdivision) MarshalJSON() ([]byte, error) {
 	type ModelTmp modelSubdivisionJson
 	return json.Marshal(struct {
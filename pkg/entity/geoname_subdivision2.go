@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"cmp"
+	"encoding/json"
+	"strings"
+
+	"github.com/mkrou/geonames/models"
+)
+
+// GeoNameAdminSubdivision2 is a second-level administrative division (county, district) from admin2Codes.txt
+type GeoNameAdminSubdivision2 struct {
+	*models.AdminSubdivision
+	ContinentCode   string `csv:"continent code"`
+	ContinentName   string `csv:"continent name"`
+	CountryName     string `csv:"country name"`
+	SubdivisionName string `csv:"subdivision name"`
+}
+
+// same as models.AdminSubdivision, but with json tags
+type modelSubdivision2Json struct {
+	Code      string `csv:"concatenated codes" valid:"required" json:"code"`
+	Name      string `csv:"name" valid:"required" json:"name"`
+	AsciiName string `csv:"asciiname" valid:"required" json:"asciiName"`
+	GeonameId int    `csv:"geonameId" valid:"required" json:"geoNameID"`
+}
+
+func (s GeoNameAdminSubdivision2) GetGeoNameID() int {
+	return s.AdminSubdivision.GeonameId
+}
+
+func (s GeoNameAdminSubdivision2) GetName() string {
+	return s.AdminSubdivision.Name
+}
+
+// SubdivisionCode returns the code of the first-level subdivision: "<country code>.<admin1 code>"
+func (s GeoNameAdminSubdivision2) SubdivisionCode() string {
+	if i := strings.LastIndexByte(s.AdminSubdivision.Code, '.'); i >= 0 {
+		return s.AdminSubdivision.Code[:i]
+	}
+	return s.AdminSubdivision.Code
+}
+
+func (s GeoNameAdminSubdivision2) AdminCode() string {
+	splitted := strings.SplitN(s.AdminSubdivision.Code, ".", 3)
+	return splitted[len(splitted)-1]
+}
+
+func (s GeoNameAdminSubdivision2) GetContinentCode() string {
+	return s.ContinentCode
+}
+
+func (s GeoNameAdminSubdivision2) GetContinentName() string {
+	return s.ContinentName
+}
+
+func (s GeoNameAdminSubdivision2) GetCountryCode() string {
+	return strings.SplitN(s.AdminSubdivision.Code, ".", 2)[0]
+}
+
+func (s GeoNameAdminSubdivision2) GetCountryName() string {
+	return s.CountryName
+}
+
+func (s GeoNameAdminSubdivision2) GetSubdivisionName() string {
+	return s.SubdivisionName
+}
+
+func (s GeoNameAdminSubdivision2) GetCityName() string {
+	return ""
+}
+
+func (s GeoNameAdminSubdivision2) GetTimeZone() string {
+	return ""
+}
+
+// GetSubdivisionCode returns the code of the first-level subdivision
+func (s GeoNameAdminSubdivision2) GetSubdivisionCode() string {
+	return s.SubdivisionCode()
+}
+
+func (s GeoNameAdminSubdivision2) GetAlternateNames() []string {
+	return []string{s.AsciiName}
+}
+
+// Localized returns a copy of the subdivision with the names in the language. The untranslated names are kept.
+func (s *GeoNameAdminSubdivision2) Localized(l GeoNameLocalizer, lang string) *GeoNameAdminSubdivision2 {
+	model := *s.AdminSubdivision
+	model.Name = cmp.Or(l.LocalizedName(s.GeonameId, lang), s.AdminSubdivision.Name)
+	return &GeoNameAdminSubdivision2{
+		AdminSubdivision: &model,
+		ContinentCode:    s.ContinentCode,
+		ContinentName:    cmp.Or(l.LocalizedContinentName(s.ContinentCode, lang), s.ContinentName),
+		CountryName:      cmp.Or(l.LocalizedCountryName(s.GetCountryCode(), lang), s.CountryName),
+		SubdivisionName:  cmp.Or(l.LocalizedSubdivisionName(s.SubdivisionCode(), lang), s.SubdivisionName),
+	}
+}
+
+func (s GeoNameAdminSubdivision2) MarshalJSON() ([]byte, error) {
+	type ModelTmp modelSubdivision2Json
+	return json.Marshal(struct {
+		*ModelTmp
+		ContinentCode   string `csv:"continent code" json:"continentCode"`
+		ContinentName   string `csv:"continent name" json:"continentName"`
+		CountryName     string `csv:"country name" json:"countryName"`
+		SubdivisionName string `csv:"subdivision name" json:"subdivisionName"`
+	}{
+		ModelTmp:        (*ModelTmp)(s.AdminSubdivision),
+		ContinentCode:   s.ContinentCode,
+		ContinentName:   s.ContinentName,
+		CountryName:     s.CountryName,
+		SubdivisionName: s.SubdivisionName,
+	})
+}
+
+func (s *GeoNameAdminSubdivision2) UnmarshalJSON(data []byte) error {
+	type ModelTmp modelSubdivision2Json
+	type tmp struct {
+		*ModelTmp
+		ContinentCode   string `csv:"continent code" json:"continentCode"`
+		ContinentName   string `csv:"continent name" json:"continentName"`
+		CountryName     string `csv:"country name" json:"countryName"`
+		SubdivisionName string `csv:"subdivision name" json:"subdivisionName"`
+	}
+	var subdivisionJson tmp
+	if err := json.Unmarshal(data, &subdivisionJson); err != nil {
+		return err
+	}
+	s.AdminSubdivision = (*models.AdminSubdivision)(subdivisionJson.ModelTmp)
+	s.ContinentCode = subdivisionJson.ContinentCode
+	s.ContinentName = subdivisionJson.ContinentName
+	s.CountryName = subdivisionJson.CountryName
+	s.SubdivisionName = subdivisionJson.SubdivisionName
+	return nil
+}
@@ -1,10 +1,17 @@
 package entity
 
 import (
+	"fmt"
 	"slices"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/bldsoft/geos/pkg/utils"
 )
 
+// FirstCustomGeonameID is the first ID of the patch entities, the IDs below are used by GeoNames
+const FirstCustomGeonameID = 1_000_000_000
+
 type GeoNameEntity interface {
 	GetGeoNameID() int
 	GetName() string //own name
@@ -17,11 +24,213 @@ type GeoNameEntity interface {
 	GetTimeZone() string
 }
 
+// GeoNameAlternateNamer is implemented by the entities that can be found by other names than GetName
+type GeoNameAlternateNamer interface {
+	GetAlternateNames() []string
+}
+
+// GeoNamePopulator is implemented by the entities with population, it's used to rank the search results
+type GeoNamePopulator interface {
+	GetPopulation() int
+}
+
+// GeoNameFeatureCoder is implemented by the entities with a GeoNames feature code: PPL, PPLC, PPLA, etc.
+type GeoNameFeatureCoder interface {
+	GetFeatureCode() string
+}
+
+// GeoNameSubdivisionCoder is implemented by the entities that belong to a first-level subdivision,
+// the code is "<country code>.<admin1 code>"
+type GeoNameSubdivisionCoder interface {
+	GetSubdivisionCode() string
+}
+
+// GeoNameLocator is implemented by the entities with coordinates
+type GeoNameLocator interface {
+	GetLatitude() float64
+	GetLongitude() float64
+}
+
+// GeoNameLocalizer provides the names of the GeoNames entities in other languages.
+// An empty string is returned if the name in the language is unknown.
+type GeoNameLocalizer interface {
+	LocalizedName(geoNameID int, lang string) string
+	LocalizedContinentName(continentCode, lang string) string
+	LocalizedCountryName(countryCode, lang string) string
+	// subdivisionCode is "<country code>.<admin1 code>" or "<country code>.<admin1 code>.<admin2 code>"
+	LocalizedSubdivisionName(subdivisionCode, lang string) string
+}
+
+// GeoNameMatch is the way the names are matched against GeoNameFilter.NamePrefix.
+// The names are compared case and accent insensitive, the alternate names are matched too.
+type GeoNameMatch string
+
+const (
+	GeoNameMatchPrefix GeoNameMatch = "prefix"
+	GeoNameMatchExact  GeoNameMatch = "exact"
+	GeoNameMatchFuzzy  GeoNameMatch = "fuzzy" // prefix or a few typos
+)
+
+// FuzzyMaxEdits is the number of typos allowed in the folded query
+func FuzzyMaxEdits(query string) int {
+	switch n := utf8.RuneCountInString(query); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Quality returns how well the folded name matches the folded query: 0 for the exact match, 1 for the prefix,
+// 1 + the edit distance for the fuzzy match. -1 means no match.
+func (m GeoNameMatch) Quality(name, query string) int {
+	if name == query {
+		return 0
+	}
+	if m == GeoNameMatchExact {
+		return -1
+	}
+	if strings.HasPrefix(name, query) {
+		return 1
+	}
+	if m == GeoNameMatchFuzzy {
+		if maxEdits := FuzzyMaxEdits(query); maxEdits > 0 {
+			if d := utils.EditDistance(name, query, maxEdits); d <= maxEdits {
+				return 1 + d
+			}
+		}
+	}
+	return -1
+}
+
+// GeoNameSort is the order of the listed entities. By default the name matches are ranked by quality
+// and the rest keep the order of the dump.
+type GeoNameSort string
+
+const (
+	GeoNameSortName       GeoNameSort = "name"       // by the untranslated name
+	GeoNameSortPopulation GeoNameSort = "population" // the most populated first
+	GeoNameSortGeoNameID  GeoNameSort = "geonameid"
+)
+
 type GeoNameFilter struct {
-	GeoNameIDs   []uint32 `schema:"geoname-ids" json:"geonameIds"`
-	CountryCodes []string `schema:"country-codes" json:"countryCodes"`
-	NamePrefix   string   `schema:"name-prefix" json:"namePrefix"`
-	Limit        uint32   `schema:"limit" json:"limit"`
+	GeoNameIDs   []uint32     `schema:"geoname-ids" json:"geonameIds"`
+	CountryCodes []string     `schema:"country-codes" json:"countryCodes"`
+	NamePrefix   string       `schema:"name-prefix" json:"namePrefix"`
+	MatchMode    GeoNameMatch `schema:"match" json:"match,omitempty"` // prefix by default
+	Lang         string       `schema:"lang" json:"lang,omitempty"`   // the language of the names in the response, the names aren't translated if empty
+
+	ContinentCodes   []string  `schema:"continent-codes" json:"continentCodes,omitempty"`
+	SubdivisionCodes []string  `schema:"subdivision-codes" json:"subdivisionCodes,omitempty"` // "<country code>.<admin1 code>"
+	FeatureCodes     []string  `schema:"feature-codes" json:"featureCodes,omitempty"`
+	TimeZones        []string  `schema:"time-zones" json:"timeZones,omitempty"`
+	MinPopulation    int       `schema:"min-population" json:"minPopulation,omitempty"`
+	BBox             []float64 `schema:"bbox" json:"bbox,omitempty"` // west, south, east, north; west > east crosses the antimeridian
+
+	Sort   GeoNameSort `schema:"sort" json:"sort,omitempty"`
+	Offset uint32      `schema:"offset" json:"offset,omitempty"`
+	Cursor string      `schema:"cursor" json:"cursor,omitempty"` // GeoNamePage.NextCursor of the previous page, the offset is ignored if it's set
+	Limit  uint32      `schema:"limit" json:"limit"`
+}
+
+func (f *GeoNameFilter) Validate() error {
+	switch f.MatchMode {
+	case "", GeoNameMatchPrefix, GeoNameMatchExact, GeoNameMatchFuzzy:
+	default:
+		return fmt.Errorf("unknown match %q: %w", f.MatchMode, utils.ErrInvalidArgument)
+	}
+	switch f.Sort {
+	case "", GeoNameSortName, GeoNameSortPopulation, GeoNameSortGeoNameID:
+	default:
+		return fmt.Errorf("unknown sort %q: %w", f.Sort, utils.ErrInvalidArgument)
+	}
+	if len(f.BBox) == 0 {
+		return nil
+	}
+	if len(f.BBox) != 4 {
+		return fmt.Errorf("bbox must be west,south,east,north: %w", utils.ErrInvalidArgument)
+	}
+	west, south, east, north := f.BBox[0], f.BBox[1], f.BBox[2], f.BBox[3]
+	switch {
+	case south < -90 || north > 90 || south > north:
+		return fmt.Errorf("bbox latitudes must be in [-90, 90], south <= north: %w", utils.ErrInvalidArgument)
+	case west < -180 || west > 180 || east < -180 || east > 180:
+		return fmt.Errorf("bbox longitudes must be in [-180, 180]: %w", utils.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// InBBox checks the location against the bounding box, any location is in the box if it isn't set
+func (f *GeoNameFilter) InBBox(lat, lon float64) bool {
+	if len(f.BBox) != 4 {
+		return true
+	}
+	west, south, east, north := f.BBox[0], f.BBox[1], f.BBox[2], f.BBox[3]
+	if lat < south || lat > north {
+		return false
+	}
+	if west <= east {
+		return west <= lon && lon <= east
+	}
+	return lon >= west || lon <= east
+}
+
+func containsFold(values []string, s string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+// MatchAttributes checks the filter predicates other than the ids and the name.
+// An entity without the attribute doesn't match, e.g. a country doesn't match the time zones.
+func (f *GeoNameFilter) MatchAttributes(e GeoNameEntity) bool {
+	if len(f.CountryCodes) > 0 && !containsFold(f.CountryCodes, e.GetCountryCode()) {
+		return false
+	}
+	if len(f.ContinentCodes) > 0 && !containsFold(f.ContinentCodes, e.GetContinentCode()) {
+		return false
+	}
+	if len(f.TimeZones) > 0 && !containsFold(f.TimeZones, e.GetTimeZone()) {
+		return false
+	}
+	if len(f.FeatureCodes) > 0 {
+		coder, ok := e.(GeoNameFeatureCoder)
+		if !ok || !containsFold(f.FeatureCodes, coder.GetFeatureCode()) {
+			return false
+		}
+	}
+	if len(f.SubdivisionCodes) > 0 {
+		coder, ok := e.(GeoNameSubdivisionCoder)
+		if !ok || !containsFold(f.SubdivisionCodes, coder.GetSubdivisionCode()) {
+			return false
+		}
+	}
+	if f.MinPopulation > 0 {
+		populator, ok := e.(GeoNamePopulator)
+		if !ok || populator.GetPopulation() < f.MinPopulation {
+			return false
+		}
+	}
+	if len(f.BBox) > 0 {
+		locator, ok := e.(GeoNameLocator)
+		if !ok || !f.InBBox(locator.GetLatitude(), locator.GetLongitude()) {
+			return false
+		}
+	}
+	return true
+}
+
+// The headers of the REST responses and the metadata of the gRPC streams with the GeoNamePage fields
+const (
+	GeoNameTotalCountHeader = "X-Total-Count"
+	GeoNameNextCursorHeader = "X-Next-Cursor"
+)
+
+// GeoNamePage is a page of the listed entities
+type GeoNamePage[T any] struct {
+	Items      []T
+	Total      int    // the number of the entities matching the filter on all the pages
+	NextCursor string // empty for the last page
 }
 
 func (f *GeoNameFilter) Match(e GeoNameEntity) bool {
@@ -29,18 +238,38 @@ func (f *GeoNameFilter) Match(e GeoNameEntity) bool {
 		return false
 	}
 
-	if len(f.CountryCodes) > 0 && !slices.Contains(f.CountryCodes, e.GetCountryCode()) {
+	if !f.MatchAttributes(e) {
 		return false
 	}
 
-	if len(f.NamePrefix) > 0 && !strings.HasPrefix(e.GetName(), f.NamePrefix) {
+	if len(f.NamePrefix) > 0 && f.NameQuality(e) < 0 {
 		return false
 	}
 
 	return true
 }
 
+// NameQuality returns the best match quality of the entity names, see GeoNameMatch.Quality
+func (f *GeoNameFilter) NameQuality(e GeoNameEntity) int {
+	names := []string{e.GetName()}
+	if namer, ok := e.(GeoNameAlternateNamer); ok {
+		names = append(names, namer.GetAlternateNames()...)
+	}
+	query := utils.FoldName(f.NamePrefix)
+	best := -1
+	for _, name := range names {
+		for _, folded := range utils.FoldNameVariants(name) {
+			if q := f.MatchMode.Quality(folded, query); q >= 0 && (best < 0 || q < best) {
+				best = q
+			}
+		}
+	}
+	return best
+}
+
 var _ GeoNameEntity = &GeoNameCountry{}
 var _ GeoNameEntity = &GeoNameAdminSubdivision{}
+var _ GeoNameEntity = &GeoNameAdminSubdivision2{}
+var _ GeoNameEntity = &GeoNamePostalCode{}
 var _ GeoNameEntity = &GeoName{}
 var _ GeoNameEntity = &GeoNameContinent{}
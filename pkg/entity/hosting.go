@@ -25,3 +25,12 @@ func (h Hosting) MarshalCSV() (names, row []string, err error) {
 	}
 	return names, row, nil
 }
+
+// UnmarshalCSV is the inverse of MarshalCSV, the row is without the network
+func (h *Hosting) UnmarshalCSV(row []string) error {
+	names, _, _ := Hosting{}.MarshalCSV()
+	p := newCSVRowParser(names, row)
+	h.Datacenter = p.String()
+	h.Domain = p.String()
+	return p.Err()
+}
@@ -0,0 +1,32 @@
+package entity
+
+// PatchAnalysis reports how the GeoIP patches of a db overlap each other and the upstream db
+type PatchAnalysis struct {
+	Patches []string `json:"patches"` // from the bottom layer to the top one, the record of the later patch wins
+	// Networks are the patch networks that overlap the other patches or don't change the upstream records
+	Networks  []*PatchNetworkAnalysis `json:"networks"`
+	Truncated bool                    `json:"truncated,omitempty"` // the networks are limited, the counts are not
+	Total     int                     `json:"total"`               // the networks of all the patches
+	Overlaps  int                     `json:"overlaps"`            // the networks that overlap the other patches
+	Shadowed  int                     `json:"shadowed"`            // the networks replaced by the later patches
+	NoOps     int                     `json:"noOps"`               // the networks that don't change the upstream records
+}
+
+// PatchNetworkAnalysis is a network of the patch. The networks of a patch are the ones of its built db,
+// so the overlapping records of the same patch are already split.
+type PatchNetworkAnalysis struct {
+	Patch    string         `json:"patch"`
+	Network  string         `json:"network"`
+	Merge    bool           `json:"merge,omitempty"`    // the record is partial
+	Upstream string         `json:"upstream,omitempty"` // the upstream network of the address
+	Overlaps []PatchNetwork `json:"overlaps,omitempty"` // the networks of the other patches
+	// Winner is the top patch that has a record of the whole network, a partial record is merged onto the lower ones
+	Winner string `json:"winner"`
+	// NoOp is true if the upstream network contains the network and has all the values of the record
+	NoOp bool `json:"noOp,omitempty"`
+}
+
+type PatchNetwork struct {
+	Patch   string `json:"patch"`
+	Network string `json:"network"`
+}
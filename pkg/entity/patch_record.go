@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// PatchRecordMeta is the optional metadata of a GeoIP patch record
+type PatchRecordMeta struct {
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`  // the record is ignored before
+	ValidUntil *time.Time `json:"valid_until,omitempty"` // the record is ignored since
+	Comment    string     `json:"comment,omitempty"`
+	// Merge is for the partial records: the set fields are merged onto the record of the lower layers
+	// (the db or the previous patches) instead of replacing it. The zero values aren't set.
+	Merge bool `json:"merge,omitempty"`
+}
+
+func (m PatchRecordMeta) ValidAt(t time.Time) bool {
+	return (m.ValidFrom == nil || !t.Before(*m.ValidFrom)) && (m.ValidUntil == nil || t.Before(*m.ValidUntil))
+}
+
+func (m PatchRecordMeta) Validate() error {
+	if m.ValidFrom != nil && m.ValidUntil != nil && !m.ValidFrom.Before(*m.ValidUntil) {
+		return fmt.Errorf("valid_from must be before valid_until: %w", utils.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// CityPatchRecord is a record of the city db patch. The patch is a JSON map of the networks to the records.
+type CityPatchRecord struct {
+	City
+	PatchRecordMeta
+}
+
+// ExpiringGeoIPRecord is a patch record or a runtime override that is applied until the time
+type ExpiringGeoIPRecord struct {
+	Network    string    `json:"network"`
+	ValidUntil time.Time `json:"validUntil"`
+	Comment    string    `json:"comment,omitempty"`    // the comment of the patch record or the reason of the override
+	Patch      string    `json:"patch,omitempty"`      // the patch file of the record
+	OverrideID string    `json:"overrideID,omitempty"` // the runtime override
+	Author     string    `json:"author,omitempty"`     // the author of the runtime override
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// ValidationIssueType is the kind of inconsistency between the GeoIP databases and GeoNames
+type ValidationIssueType string
+
+const (
+	// ValidationIssueDanglingGeoNameID is a GeoNameID of the city db that isn't in GeoNames
+	ValidationIssueDanglingGeoNameID ValidationIssueType = "dangling_geoname_id"
+	// ValidationIssueCountryMismatch is an entity that belongs to another country in GeoNames
+	ValidationIssueCountryMismatch ValidationIssueType = "country_mismatch"
+	// ValidationIssueCustomIDCollision is a GeoNames patch entity that uses the ID of an original entity
+	ValidationIssueCustomIDCollision ValidationIssueType = "custom_id_collision"
+)
+
+type ValidationIssue struct {
+	Type      ValidationIssueType `json:"type"`
+	GeoNameID uint32              `json:"geoNameID"`
+	// EntityType is the level of the entity with the ID: the city db fields and the patch entities are typed
+	EntityType GeoNameType `json:"entityType,omitempty"`
+	Message    string      `json:"message"`
+	// Networks is the number of the city db networks with the issue, Network is one of them
+	Networks int    `json:"networks,omitempty"`
+	Network  string `json:"network,omitempty"`
+}
+
+type ValidationReport struct {
+	StartedAt  time.Time `json:"startedAt"`
+	Duration   string    `json:"duration"`
+	Networks   int       `json:"networks"`   // the number of the city db networks walked
+	GeoNameIDs int       `json:"geoNameIDs"` // the number of the distinct GeoNameIDs checked
+	// Summary is the number of the issues of each type, the list of the issues may be truncated
+	Summary   map[ValidationIssueType]int `json:"summary"`
+	Issues    []*ValidationIssue          `json:"issues"`
+	Truncated bool                        `json:"truncated,omitempty"`
+}
+
+// GeoNameIDCollision is a patch entity with the ID of an original GeoNames entity
+type GeoNameIDCollision struct {
+	GeoNameID    uint32
+	CustomType   GeoNameType
+	CustomName   string
+	OriginalType GeoNameType // empty if the original entity isn't loaded
+	OriginalName string
+}
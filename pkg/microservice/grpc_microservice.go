@@ -8,24 +8,50 @@ import (
 	grpc_controller "github.com/bldsoft/geos/pkg/controller/grpc"
 	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
 	"github.com/bldsoft/geos/pkg/microservice/middleware"
+	"github.com/bldsoft/geos/pkg/ratelimit"
+	"github.com/bldsoft/geos/pkg/tlsconfig"
 	"github.com/bldsoft/gost/log"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 )
 
 type GrpcMicroservice struct {
-	address        string
-	grpcServer     *grpc.Server
-	geoIpService   controller.GeoIpService
-	geoNameService controller.GeoNameService
+	address              string
+	grpcServer           *grpc.Server
+	geoIpService         controller.GeoIpService
+	geoNameService       controller.GeoNameService
+	geoIPOverrideService controller.GeoIPOverrideService
+	trustedProxies       middleware.TrustedProxies
+
+	apiKeys              middleware.ApiKeyAuthorizer
+	lookupApiKeyRequired bool
+	rateLimiter          *ratelimit.Limiter
+	tls                  *tlsconfig.Reloader
 }
 
-func NewGrpcMicroservice(address string, geoIpService controller.GeoIpService, geoNameService controller.GeoNameService) *GrpcMicroservice {
+func NewGrpcMicroservice(
+	address string,
+	geoIpService controller.GeoIpService,
+	geoNameService controller.GeoNameService,
+	geoIPOverrideService controller.GeoIPOverrideService,
+	trustedProxies middleware.TrustedProxies,
+	apiKeys middleware.ApiKeyAuthorizer,
+	lookupApiKeyRequired bool,
+	rateLimiter *ratelimit.Limiter,
+	tls *tlsconfig.Reloader, // nil for plaintext
+) *GrpcMicroservice {
 	return &GrpcMicroservice{
-		address:        address,
-		geoIpService:   geoIpService,
-		geoNameService: geoNameService,
+		address:              address,
+		geoIpService:         geoIpService,
+		geoNameService:       geoNameService,
+		geoIPOverrideService: geoIPOverrideService,
+		trustedProxies:       trustedProxies,
+		apiKeys:              apiKeys,
+		lookupApiKeyRequired: lookupApiKeyRequired,
+		rateLimiter:          rateLimiter,
+		tls:                  tls,
 	}
 }
 
@@ -34,6 +60,8 @@ func (s *GrpcMicroservice) registerServices() {
 	pb.RegisterGeoIpServiceServer(s.grpcServer, geoIpController)
 	geoNameController := grpc_controller.NewGeoNameController(s.geoNameService)
 	pb.RegisterGeoNameServiceServer(s.grpcServer, geoNameController)
+	geoIPOverrideController := grpc_controller.NewGeoIPOverrideController(s.geoIPOverrideService)
+	pb.RegisterGeoIPOverrideServiceServer(s.grpcServer, geoIPOverrideController)
 }
 
 func (s *GrpcMicroservice) Run() error {
@@ -44,17 +72,32 @@ func (s *GrpcMicroservice) Run() error {
 		return err
 	}
 
-	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(
-		grpc_middleware.ChainUnaryServer(
-			middleware.RequestIDMiddleware,
-			middleware.RealIPMiddleware,
-			middleware.LoggerMiddleware(),
-			middleware.RecoveryMiddleware,
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				middleware.RequestIDMiddleware,
+				middleware.RealIPMiddleware(s.trustedProxies),
+				middleware.LoggerMiddleware(),
+				middleware.ApiKeyMiddleware(s.apiKeys, s.lookupApiKeyRequired),
+				middleware.RateLimitMiddleware(s.rateLimiter),
+				middleware.RecoveryMiddleware,
+			),
+		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.RealIPStreamMiddleware(s.trustedProxies),
+				middleware.ApiKeyStreamMiddleware(s.apiKeys, s.lookupApiKeyRequired),
+				middleware.RateLimitStreamMiddleware(s.rateLimiter),
+			),
 		),
-	))
+	}
+	if s.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tls.TLSConfig())))
+	}
+	s.grpcServer = grpc.NewServer(opts...)
 	s.registerServices()
 
-	log.Infof("Grpc server started. Listening on %s", s.address)
+	log.Infof("Grpc server started. Listening on %s, tls: %t", s.address, s.tls != nil)
 	defer log.Infof("Grpc server stopped")
 	return s.grpcServer.Serve(lis)
 }
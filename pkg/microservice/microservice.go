@@ -7,12 +7,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bldsoft/geos/pkg/apikey"
 	"github.com/bldsoft/geos/pkg/config"
 	"github.com/bldsoft/geos/pkg/controller"
 	"github.com/bldsoft/geos/pkg/controller/rest"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/microservice/middleware"
+	"github.com/bldsoft/geos/pkg/ratelimit"
 	"github.com/bldsoft/geos/pkg/repository"
+	"github.com/bldsoft/geos/pkg/resolver"
 	"github.com/bldsoft/geos/pkg/service"
-	"github.com/bldsoft/gost/auth"
+	"github.com/bldsoft/geos/pkg/tlsconfig"
 	"github.com/bldsoft/gost/clickhouse"
 	gost "github.com/bldsoft/gost/controller"
 	"github.com/bldsoft/gost/discovery"
@@ -27,20 +32,30 @@ import (
 const (
 	BaseApiPath                 = "/geoip"
 	APIKey                      = "GEOS-API-Key"
-	APIKeyMetaKey               = "api-key"
 	MMDBCitiesBuildEpochMetaKey = "mmdbCityTs"
 	MMDBIspBuildEpochMetaKey    = "mmdbISPTs"
 	GrpcAddressMetaKey          = "grpc-address"
+	GrpcTLSMetaKey              = "grpc-tls"
+	RestTLSAddressMetaKey       = "rest-tls-address"
 	ServiceName                 = config.ServiceName
 )
 
+// Deprecated: API keys aren't published to discovery anymore, pass the key to the discovery client instead
+const APIKeyMetaKey = "api-key"
+
 type Microservice struct {
 	config *config.Config
 
-	geoIpService   controller.GeoIpService
-	geoNameService controller.GeoNameService
+	geoIpService         controller.GeoIpService
+	geoNameService       controller.GeoNameService
+	geoIPOverrideService controller.GeoIPOverrideService
+	validationService    *service.ValidationService
 
-	discovery discovery.Discovery
+	discovery      discovery.Discovery
+	trustedProxies middleware.TrustedProxies
+	apiKeys        *apikey.Store
+	rateLimiter    *ratelimit.Limiter
+	restTLSServer  *RestTLSServer
 
 	asyncRunners []server.AsyncRunner
 }
@@ -72,8 +87,6 @@ func (m *Microservice) setDiscoveryMeta() {
 	} else {
 		log.Logger.ErrorWithFields(log.Fields{"err": err}, "failed to get cities database metadata")
 	}
-
-	m.discovery.SetMetadata(APIKeyMetaKey, m.config.ApiKey)
 }
 
 func (m *Microservice) initServices() {
@@ -90,6 +103,22 @@ func (m *Microservice) initServices() {
 		log.Debug("Log export to ClickHouse is off")
 	}
 
+	geonameStorageConfig := repository.StorageConfig{
+		LocalDir:         m.config.GeoNameDumpDirPath,
+		PatchesRemoteURL: m.config.GeoNamePatchesSource,
+		AutoUpdatePeriod: time.Duration(m.config.AutoUpdatePeriodSec) * time.Second,
+		AlternateNames:   m.config.GeoNameAlternateNames,
+		Languages:        m.config.GeoNameLanguageList(),
+		Admin2Codes:      m.config.GeoNameAdmin2Codes,
+		PostalCodes:      m.config.GeoNamePostalCodeCountries(),
+		CityDumps:        m.config.GeoNameCityDumpList(),
+		FeatureClasses:   m.config.GeoNameFeatureClassList(),
+		CityAltNames:     m.config.GeoNameCityAltNames,
+	}
+
+	geoNameRep := repository.NewGeoNamesRepository(geonameStorageConfig)
+	m.geoNameService = service.NewGeoNameService(geoNameRep)
+
 	rep := repository.NewGeoIPRepository(repository.GeoIPRepositoryConfig{
 		City: repository.DBConfig{
 			LocalPath:        m.config.GeoDbPath,
@@ -108,17 +137,43 @@ func (m *Microservice) initServices() {
 		},
 		CSVDirPath:       m.config.GeoIPCsvDumpDirPath,
 		AutoUpdatePeriod: time.Duration(m.config.AutoUpdatePeriodSec) * time.Second,
+		GeoNames:         geoNameRep.Localizer(),
 	})
-	m.geoIpService = service.NewGeoIpService(rep)
+	dnsResolver, err := resolver.NewResolver(m.config.DNS)
+	if err != nil {
+		log.Fatalf("Failed to create DNS resolver: %s", err)
+	}
+	m.geoIpService = service.NewGeoIpService(rep, dnsResolver, m.geoNameService)
+	m.geoIPOverrideService = service.NewGeoIPOverrideService(rep)
 
-	geonameStorageConfig := repository.StorageConfig{
-		LocalDir:         m.config.GeoNameDumpDirPath,
-		PatchesRemoteURL: m.config.GeoNamePatchesSource,
-		AutoUpdatePeriod: time.Duration(m.config.AutoUpdatePeriodSec) * time.Second,
+	m.validationService = service.NewValidationService(rep, geoNameRep)
+	if m.config.ValidateAfterUpdate {
+		rep.OnCityUpdated(m.validationService.ValidateInBackground)
+		geoNameRep.OnUpdated(m.validationService.ValidateInBackground)
 	}
 
-	geoNameRep := repository.NewGeoNamesRepository(geonameStorageConfig)
-	m.geoNameService = service.NewGeoNameService(geoNameRep)
+	if m.trustedProxies, err = middleware.ParseTrustedProxies(m.config.TrustedProxies); err != nil {
+		log.Fatalf("Failed to parse trusted proxies: %s", err)
+	}
+
+	m.apiKeys, err = apikey.NewStore(apikey.Config{
+		Key:          m.config.ApiKey,
+		Keys:         m.config.ApiKeys,
+		FilePath:     m.config.ApiKeysFilePath,
+		ReloadPeriod: time.Duration(m.config.ApiKeysReloadPeriodSec) * time.Second,
+	})
+	if err != nil {
+		log.Fatalf("Failed to load API keys: %s", err)
+	}
+	switch {
+	case !m.apiKeys.Enabled() && m.config.LookupApiKeyRequired:
+		log.Warn("No API keys are configured, but LOOKUP_API_KEY_REQUIRED is set: all the endpoints except /ping are unavailable")
+	case !m.apiKeys.Enabled():
+		log.Warn("No API keys are configured, dump and update endpoints are unavailable")
+	default:
+		log.Info("API keys aren't published to the discovery metadata, the discovered clients need client.WithApiKey")
+	}
+	m.rateLimiter = ratelimit.NewLimiter(m.config.RateLimit)
 
 	m.discovery = common.NewDiscovery(m.config.Server, m.config.Discovery)
 	m.setDiscoveryMeta()
@@ -126,68 +181,150 @@ func (m *Microservice) initServices() {
 	m.asyncRunners = append(m.asyncRunners, m.discovery)
 	m.asyncRunners = append(m.asyncRunners, server.NewContextAsyncRunner(rep.Run))
 	m.asyncRunners = append(m.asyncRunners, server.NewContextAsyncRunner(geoNameRep.Run))
+	m.asyncRunners = append(m.asyncRunners, server.NewContextAsyncRunner(m.apiKeys.Run))
 
 	if m.config.NeedGrpc() {
-		grpcService := NewGrpcMicroservice(m.config.GRPCServiceBindAddress.HostPort(), m.geoIpService, m.geoNameService)
+		var grpcTLS *tlsconfig.Reloader
+		if m.config.GRPCTLS.Enabled() {
+			grpcTLS = m.tlsReloader(m.config.GRPCTLS)
+			m.discovery.SetMetadata(GrpcTLSMetaKey, "true")
+		}
+		grpcService := NewGrpcMicroservice(m.config.GRPCServiceBindAddress.HostPort(), m.geoIpService, m.geoNameService, m.geoIPOverrideService, m.trustedProxies, m.apiKeys, m.config.LookupApiKeyRequired, m.rateLimiter, grpcTLS)
 		m.asyncRunners = append(m.asyncRunners, grpcService)
 
 		m.discovery.SetMetadata(GrpcAddressMetaKey, m.config.GRPCServiceAddress.String())
 	} else {
 		log.Info("gRPC is off")
 	}
+
+	if m.config.NeedRestTLS() {
+		m.restTLSServer = NewRestTLSServer(m.config.RestTLSBindAddress.HostPort(), m.tlsReloader(m.config.RestTLS))
+		m.asyncRunners = append(m.asyncRunners, m.restTLSServer)
+
+		m.discovery.SetMetadata(RestTLSAddressMetaKey, m.config.RestTLSAddress.String())
+	}
+}
+
+func (m *Microservice) tlsReloader(config tlsconfig.ServerConfig) *tlsconfig.Reloader {
+	reloader, err := tlsconfig.NewReloader(config)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificate: %s", err)
+	}
+	m.asyncRunners = append(m.asyncRunners, server.NewContextAsyncRunner(reloader.Run))
+	return reloader
 }
 
 func (m *Microservice) BuildRoutes(router chi.Router) {
 	if d, ok := m.discovery.(*inhouse.Discovery); ok {
 		d.Mount(router)
 	}
-	router.Route(BaseApiPath, func(r chi.Router) {
-		r.Get("/ping", gost.GetPingHandler)
-		r.With(m.ApiKeyMiddleware()).Get("/env", gost.GetEnvHandler(m.config, nil))
-		r.Get("/version", gost.GetVersionHandler)
+	router.Route(BaseApiPath, m.buildApiRoutes)
+	if m.restTLSServer != nil {
+		m.restTLSServer.SetHandler(router)
+	}
+}
+
+func (m *Microservice) buildApiRoutes(r chi.Router) {
+	r.Use(middleware.RealIPHTTPMiddleware(m.trustedProxies))
+	r.Get("/ping", gost.GetPingHandler)
+	r.Group(m.buildServiceRoutes)
+}
 
-		geoIpController := rest.NewGeoIpController(m.geoIpService)
+// buildServiceRoutes builds the routes that are served only over HTTPS if REST_TLS_ONLY is set
+func (m *Microservice) buildServiceRoutes(r chi.Router) {
+	if m.config.RestTLSOnly {
+		r.Use(middleware.RequireTLSHTTPMiddleware)
+	}
+	r.With(m.ScopeMiddleware(entity.ApiKeyScopeUpdate)).Get("/env", gost.GetEnvHandler(m.config, nil))
+	r.Get("/version", gost.GetVersionHandler)
+
+	apiKeyController := rest.NewApiKeyController(m.apiKeys)
+	r.With(m.ScopeMiddleware(entity.ApiKeyScopeUpdate)).Get("/api-keys", apiKeyController.GetApiKeysHandler)
+
+	geoIpController := rest.NewGeoIpController(m.geoIpService)
+	r.Get("/me", geoIpController.GetMeHandler)
+	r.Group(func(r chi.Router) {
+		r.Use(m.ScopeMiddleware(entity.ApiKeyScopeLookup))
 		r.Get("/country/{addr}", geoIpController.GetCountryHandler)
 		r.Get("/city/{addr}", geoIpController.GetCityHandler)
 		r.Get("/city-lite/{addr}", geoIpController.GetCityLiteHandler)
 		r.Get("/hosting/{addr}", geoIpController.GetHostingHandler)
+		r.Get("/country/{addr}/all", geoIpController.GetCountryAllHandler)
+		r.Get("/city/{addr}/all", geoIpController.GetCityAllHandler)
+		r.Get("/city-lite/{addr}/all", geoIpController.GetCityLiteAllHandler)
+		r.Get("/hosting/{addr}/all", geoIpController.GetHostingAllHandler)
+	})
 
-		managementController := rest.NewManagementController(m.geoIpService, m.geoNameService)
-		r.Group(func(r chi.Router) {
-			r.Use(m.ApiKeyMiddleware())
-			r.Get("/dump", geoIpController.GetDumpHandler) // deprecated, used by streampool
-		})
+	managementController := rest.NewManagementController(m.geoIpService, m.geoNameService, m.validationService)
+	r.Group(func(r chi.Router) {
+		r.Use(m.ScopeMiddleware(entity.ApiKeyScopeDump))
+		r.Get("/dump", geoIpController.GetDumpHandler) // deprecated, used by streampool
+	})
+
+	r.With(m.ScopeMiddleware(entity.ApiKeyScopeUpdate)).Get("/dump/validate", managementController.ValidateHandler)
 
-		r.Route("/dump/{db}", func(r chi.Router) {
-			r.Use(m.ApiKeyMiddleware())
+	r.Route("/dump/{db}", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(m.ScopeMiddleware(entity.ApiKeyScopeDump))
 			r.Get("/csv", geoIpController.GetCSVDatabaseHandler)
 			r.Get("/mmdb", geoIpController.GetMMDBDatabaseHandler)
 			r.Get("/metadata", geoIpController.GetDatabaseMetaHandler)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(m.ScopeMiddleware(entity.ApiKeyScopeUpdate))
 			r.Get("/update", managementController.CheckGeoIPUpdatesHandler)
 			r.Put("/update", managementController.UpdateGeoIPHandler)
 		})
+	})
 
-		geoNameController := rest.NewGeoNameController(m.geoNameService)
-		r.Route("/geoname", func(r chi.Router) {
+	geoIPOverrideController := rest.NewGeoIPOverrideController(m.geoIPOverrideService)
+	r.Route("/overrides/{db}", func(r chi.Router) {
+		r.Use(m.ScopeMiddleware(entity.ApiKeyScopeUpdate))
+		r.Get("/", geoIPOverrideController.GetOverridesHandler)
+		r.Post("/", geoIPOverrideController.AddOverrideHandler)
+		r.Get("/expiring", geoIPOverrideController.GetExpiringHandler)
+		r.Get("/analysis", geoIPOverrideController.GetPatchAnalysisHandler)
+		r.Get("/{id}", geoIPOverrideController.GetOverrideHandler)
+		r.Put("/{id}", geoIPOverrideController.ReplaceOverrideHandler)
+		r.Delete("/{id}", geoIPOverrideController.DeleteOverrideHandler)
+	})
+
+	geoNameController := rest.NewGeoNameController(m.geoNameService)
+	r.Route("/geoname", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(m.ScopeMiddleware(entity.ApiKeyScopeLookup))
 			r.Get("/continent", geoNameController.GetGeoNameContinentsHandler)
 			r.Get("/country", geoNameController.GetGeoNameCountriesHandler)
 			r.Post("/country", geoNameController.GetGeoNameCountriesHandler)
 			r.Get("/subdivision", geoNameController.GetGeoNameSubdivisionsHandler)
 			r.Post("/subdivision", geoNameController.GetGeoNameSubdivisionsHandler)
+			r.Get("/subdivision2", geoNameController.GetGeoNameSubdivisions2Handler)
+			r.Post("/subdivision2", geoNameController.GetGeoNameSubdivisions2Handler)
 			r.Get("/city", geoNameController.GetGeoNameCitiesHandler)
 			r.Post("/city", geoNameController.GetGeoNameCitiesHandler)
-			r.Group(func(r chi.Router) {
-				r.Use(m.ApiKeyMiddleware())
-				r.Get("/dump", geoNameController.GetDumpHandler)
-				r.Get("/update", managementController.CheckGeonamesUpdatesHandler)
-				r.Put("/update", managementController.UpdateGeonamesHandler)
-			})
+			r.Get("/postal", geoNameController.GetGeoNamePostalCodesHandler)
+			r.Post("/postal", geoNameController.GetGeoNamePostalCodesHandler)
+			r.Get("/nearest", geoNameController.GetGeoNameNearestHandler)
+			r.Get("/{id}", geoNameController.GetGeoNameHandler)
+			r.Get("/{id}/children", geoNameController.GetGeoNameChildrenHandler)
+			r.Get("/{id}/ancestors", geoNameController.GetGeoNameAncestorsHandler)
+		})
+		r.With(m.ScopeMiddleware(entity.ApiKeyScopeGeoNamesDump)).Get("/dump", geoNameController.GetDumpHandler)
+		r.Group(func(r chi.Router) {
+			r.Use(m.ScopeMiddleware(entity.ApiKeyScopeUpdate))
+			r.Get("/update", managementController.CheckGeonamesUpdatesHandler)
+			r.Put("/update", managementController.UpdateGeonamesHandler)
 		})
 	})
 }
 
-func (m *Microservice) ApiKeyMiddleware() func(next http.Handler) http.Handler {
-	return auth.ApiKeyMiddleware(APIKey, m.config.ApiKey)
+// ScopeMiddleware checks the API key and the rate limit of the scope
+func (m *Microservice) ScopeMiddleware(scope entity.ApiKeyScope) func(next http.Handler) http.Handler {
+	apiKey := middleware.ApiKeyHTTPMiddleware(m.apiKeys, scope, m.config.LookupApiKeyRequired)
+	rateLimit := middleware.RateLimitHTTPMiddleware(m.rateLimiter, scope)
+	return func(next http.Handler) http.Handler {
+		return apiKey(rateLimit(next))
+	}
 }
 
 func (m *Microservice) GetAsyncRunners() []server.AsyncRunner {
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/bldsoft/gost/log"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ApiKeyHeader = "geos-api-key"
+
+// GrpcMethodScopes maps gRPC full method names to the required scopes. The other methods require the lookup scope.
+var GrpcMethodScopes = map[string]entity.ApiKeyScope{
+	"/geoip.GeoIPOverrideService/List":    entity.ApiKeyScopeUpdate,
+	"/geoip.GeoIPOverrideService/Get":     entity.ApiKeyScopeUpdate,
+	"/geoip.GeoIPOverrideService/Add":     entity.ApiKeyScopeUpdate,
+	"/geoip.GeoIPOverrideService/Replace": entity.ApiKeyScopeUpdate,
+	"/geoip.GeoIPOverrideService/Delete":  entity.ApiKeyScopeUpdate,
+}
+
+type ApiKeyAuthorizer interface {
+	Authorize(key string, scope entity.ApiKeyScope) (*entity.ApiKey, error)
+}
+
+type apiKeyCtxKey struct{}
+
+func WithApiKey(ctx context.Context, key *entity.ApiKey) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, key)
+}
+
+// GetApiKey returns the authorized API key of the request or nil
+func GetApiKey(ctx context.Context) *entity.ApiKey {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(*entity.ApiKey)
+	return key
+}
+
+// authorize checks the key of the request.
+// Lookups are allowed without a key unless lookupKeyRequired is set, the key is only used for accounting then.
+// A sent key is checked anyway: an unknown or expired key is rejected, a key without the lookup scope is ignored.
+func authorize(ctx context.Context, keys ApiKeyAuthorizer, key string, scope entity.ApiKeyScope, lookupKeyRequired bool) (context.Context, error) {
+	optional := scope == entity.ApiKeyScopeLookup && !lookupKeyRequired
+	if len(key) == 0 && optional {
+		return ctx, nil
+	}
+	apiKey, err := keys.Authorize(key, scope)
+	if err != nil {
+		if optional && errors.Is(err, utils.ErrForbidden) {
+			return ctx, nil
+		}
+		log.FromContext(ctx).WarnWithFields(log.Fields{"err": err}, "api key is rejected")
+		return ctx, err
+	}
+	ctx = WithApiKey(ctx, apiKey)
+	return context.WithValue(ctx, log.LoggerCtxKey, log.FromContext(ctx).WithFields(log.Fields{"apiKey": apiKey.ID})), nil
+}
+
+// ApiKeyHTTPMiddleware checks the API key from the GEOS-API-Key header
+func ApiKeyHTTPMiddleware(keys ApiKeyAuthorizer, scope entity.ApiKeyScope, lookupKeyRequired bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := authorize(r.Context(), keys, r.Header.Get(ApiKeyHeader), scope, lookupKeyRequired)
+			switch {
+			case errors.Is(err, utils.ErrForbidden):
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			case err != nil:
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			default:
+				next.ServeHTTP(w, r.WithContext(ctx))
+			}
+		})
+	}
+}
+
+func grpcMethodScope(fullMethod string) entity.ApiKeyScope {
+	if scope, ok := GrpcMethodScopes[fullMethod]; ok {
+		return scope
+	}
+	return entity.ApiKeyScopeLookup
+}
+
+func grpcAuthError(err error) error {
+	if errors.Is(err, utils.ErrForbidden) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Unauthenticated, err.Error())
+}
+
+// ApiKeyMiddleware checks the API key from the geos-api-key metadata
+func ApiKeyMiddleware(keys ApiKeyAuthorizer, lookupKeyRequired bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		ctx, err = authorize(ctx, keys, getHeader(ctx, ApiKeyHeader), grpcMethodScope(info.FullMethod), lookupKeyRequired)
+		if err != nil {
+			return nil, grpcAuthError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ApiKeyStreamMiddleware is the same as ApiKeyMiddleware, but for streams
+func ApiKeyStreamMiddleware(keys ApiKeyAuthorizer, lookupKeyRequired bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), keys, getHeader(ss.Context(), ApiKeyHeader), grpcMethodScope(info.FullMethod), lookupKeyRequired)
+		if err != nil {
+			return grpcAuthError(err)
+		}
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
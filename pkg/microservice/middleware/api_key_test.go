@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/apikey"
+	pb "github.com/bldsoft/geos/pkg/controller/grpc/proto"
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiKeyHTTPMiddleware(t *testing.T) {
+	keys, err := apikey.NewStore(apikey.Config{Keys: `[
+		{"id": "lookup", "key": "lookup-key", "scopes": ["lookup"]},
+		{"id": "dump", "key": "dump-key", "scopes": ["dump"]},
+		{"id": "expired", "key": "expired-key", "scopes": ["lookup", "dump"], "expiresAt": "2020-01-01T00:00:00Z"}
+	]`})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		scope             entity.ApiKeyScope
+		lookupKeyRequired bool
+		key               string
+		wantStatus        int
+		wantKeyID         string
+	}{
+		{name: "lookup without key", scope: entity.ApiKeyScopeLookup, wantStatus: http.StatusOK},
+		{name: "lookup with key", scope: entity.ApiKeyScopeLookup, key: "lookup-key", wantStatus: http.StatusOK, wantKeyID: "lookup"},
+		{name: "lookup with unknown key", scope: entity.ApiKeyScopeLookup, key: "unknown", wantStatus: http.StatusUnauthorized},
+		{name: "lookup with expired key", scope: entity.ApiKeyScopeLookup, key: "expired-key", wantStatus: http.StatusUnauthorized},
+		{name: "lookup with key of another scope", scope: entity.ApiKeyScopeLookup, key: "dump-key", wantStatus: http.StatusOK},
+		{name: "required lookup key", scope: entity.ApiKeyScopeLookup, lookupKeyRequired: true, wantStatus: http.StatusUnauthorized},
+		{name: "required lookup key of another scope", scope: entity.ApiKeyScopeLookup, lookupKeyRequired: true, key: "dump-key", wantStatus: http.StatusForbidden},
+		{name: "dump without key", scope: entity.ApiKeyScopeDump, wantStatus: http.StatusUnauthorized},
+		{name: "dump with key", scope: entity.ApiKeyScopeDump, key: "dump-key", wantStatus: http.StatusOK, wantKeyID: "dump"},
+		{name: "dump with key of another scope", scope: entity.ApiKeyScopeDump, key: "lookup-key", wantStatus: http.StatusForbidden},
+		{name: "dump with expired key", scope: entity.ApiKeyScopeDump, key: "expired-key", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keyID string
+			handler := ApiKeyHTTPMiddleware(keys, tt.scope, tt.lookupKeyRequired)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if key := GetApiKey(r.Context()); key != nil {
+					keyID = key.ID
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if len(tt.key) != 0 {
+				req.Header.Set(ApiKeyHeader, tt.key)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantKeyID, keyID)
+		})
+	}
+}
+
+func TestGrpcMethodScopes(t *testing.T) {
+	for _, method := range pb.GeoIPOverrideService_ServiceDesc.Methods {
+		fullMethod := "/" + pb.GeoIPOverrideService_ServiceDesc.ServiceName + "/" + method.MethodName
+		assert.Equal(t, entity.ApiKeyScopeUpdate, grpcMethodScope(fullMethod), fullMethod)
+	}
+	assert.Equal(t, entity.ApiKeyScopeLookup, grpcMethodScope("/"+pb.GeoIpService_ServiceDesc.ServiceName+"/City"))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/ratelimit"
+	"github.com/bldsoft/gost/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const RetryAfterHeader = "retry-after"
+
+// rateLimitClient returns the bucket key of the request according to RATE_LIMIT_KEY. It falls back to the real IP.
+func rateLimitClient(ctx context.Context, limiter *ratelimit.Limiter, header func(name string) string) string {
+	source := limiter.KeySource()
+	switch {
+	case source == ratelimit.KeyApiKey:
+		if apiKey := GetApiKey(ctx); apiKey != nil {
+			return "key:" + apiKey.ID
+		}
+	case strings.HasPrefix(source, ratelimit.KeyHeaderPrefix):
+		name := strings.TrimPrefix(source, ratelimit.KeyHeaderPrefix)
+		if value := header(name); len(value) != 0 {
+			return "header:" + value
+		}
+	}
+	return "ip:" + GetRealIP(ctx)
+}
+
+func allow(ctx context.Context, limiter *ratelimit.Limiter, scope entity.ApiKeyScope, header func(name string) string) (bool, time.Duration) {
+	ok, retryAfter := limiter.Allow(scope, rateLimitClient(ctx, limiter, header), GetApiKey(ctx))
+	if !ok {
+		log.FromContext(ctx).DebugWithFields(log.Fields{"scope": scope, "retryAfter": retryAfter}, "rate limit exceeded")
+	}
+	return ok, retryAfter
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// RateLimitHTTPMiddleware limits the request rate of the scope. It must be placed after ApiKeyHTTPMiddleware.
+func RateLimitHTTPMiddleware(limiter *ratelimit.Limiter, scope entity.ApiKeyScope) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, retryAfter := allow(r.Context(), limiter, scope, r.Header.Get); !ok {
+				w.Header().Set(RetryAfterHeader, retryAfterSeconds(retryAfter))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func grpcRateLimitError(retryAfter time.Duration) error {
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %s", retryAfter.Round(time.Millisecond))
+}
+
+// RateLimitMiddleware limits the request rate of the gRPC method scope. It must be placed after ApiKeyMiddleware.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		header := func(name string) string { return getHeader(ctx, name) }
+		if ok, retryAfter := allow(ctx, limiter, grpcMethodScope(info.FullMethod), header); !ok {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, retryAfterSeconds(retryAfter)))
+			return nil, grpcRateLimitError(retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamMiddleware is the same as RateLimitMiddleware, but for streams
+func RateLimitStreamMiddleware(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		header := func(name string) string { return getHeader(ctx, name) }
+		if ok, retryAfter := allow(ctx, limiter, grpcMethodScope(info.FullMethod), header); !ok {
+			_ = ss.SetHeader(metadata.Pairs(RetryAfterHeader, retryAfterSeconds(retryAfter)))
+			return grpcRateLimitError(retryAfter)
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/ratelimit"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitStreamMiddlewareKeysByRealIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("127.0.0.0/8")
+	require.NoError(t, err)
+	interceptor := grpc_middleware.ChainStreamServer(
+		RealIPStreamMiddleware(proxies),
+		RateLimitStreamMiddleware(ratelimit.NewLimiter(ratelimit.Config{Key: ratelimit.KeyIP, LookupRPS: 1})),
+	)
+	city := func(ss grpc.ServerStream) codes.Code {
+		err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/geoname.GeoNameService/City"}, func(interface{}, grpc.ServerStream) error {
+			return nil
+		})
+		return status.Code(err)
+	}
+	behindProxy := func(ip string) grpc.ServerStream {
+		return newTestServerStream("127.0.0.1:1234", metadata.Pairs("x-forwarded-for", ip))
+	}
+
+	assert.Equal(t, codes.OK, city(behindProxy("192.0.2.1")))
+	assert.Equal(t, codes.OK, city(behindProxy("192.0.2.2")), "the clients behind the proxy have their own buckets")
+	limited := behindProxy("192.0.2.1")
+	assert.Equal(t, codes.ResourceExhausted, city(limited))
+	assert.Equal(t, []string{"1"}, limited.(*testServerStream).header.Get(RetryAfterHeader))
+	assert.Equal(t, codes.OK, city(newTestServerStream("198.51.100.1:1234", nil)))
+}
@@ -2,10 +2,12 @@ package middleware
 
 import (
 	"context"
-	"strings"
+	"net/http"
 
 	"github.com/bldsoft/gost/server/middleware"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
@@ -15,15 +17,40 @@ func GetRealIP(ctx context.Context) string {
 	return middleware.GetRealIP(ctx)
 }
 
-func RealIPMiddleware(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
-	ip := realIP(ctx)
-	return handler(middleware.WithRealIP(ctx, ip), req)
+func withGrpcRealIP(ctx context.Context, proxies TrustedProxies) context.Context {
+	var peerAddr string
+	if p, ok := peer.FromContext(ctx); ok {
+		peerAddr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	addr := proxies.ClientAddress(peerAddr, func(name string) []string { return md.Get(name) })
+	ctx = WithClientAddress(ctx, addr)
+	return middleware.WithRealIP(ctx, addr.IP)
+}
+
+// RealIPMiddleware sets the real IP of the gRPC client. The forwarding headers are accepted only from trusted proxies.
+func RealIPMiddleware(proxies TrustedProxies) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		return handler(withGrpcRealIP(ctx, proxies), req)
+	}
+}
+
+// RealIPStreamMiddleware is the same as RealIPMiddleware, but for streams
+func RealIPStreamMiddleware(proxies TrustedProxies) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = withGrpcRealIP(ss.Context(), proxies)
+		return handler(srv, wrapped)
+	}
 }
 
-func realIP(ctx context.Context) string {
-	if ip := getHeader(ctx, RealIPHeader); ip != "" {
-		return ip
+// RealIPHTTPMiddleware is the same as RealIPMiddleware, but for REST.
+func RealIPHTTPMiddleware(proxies TrustedProxies) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addr := proxies.ClientAddress(r.RemoteAddr, func(name string) []string { return r.Header.Values(name) })
+			ctx := WithClientAddress(r.Context(), addr)
+			next.ServeHTTP(w, r.WithContext(middleware.WithRealIP(ctx, addr.IP)))
+		})
 	}
-	p, _ := peer.FromContext(ctx)
-	return strings.Split(p.Addr.String(), ":")[0]
 }
@@ -0,0 +1,14 @@
+package middleware
+
+import "net/http"
+
+// RequireTLSHTTPMiddleware rejects the requests that aren't received over TLS
+func RequireTLSHTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil {
+			http.Error(w, "HTTPS is required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireTLSHTTPMiddleware(t *testing.T) {
+	handler := RequireTLSHTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	plaintext := httptest.NewServer(handler)
+	defer plaintext.Close()
+	resp, err := plaintext.Client().Get(plaintext.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "the plaintext request is refused")
+
+	secure := httptest.NewTLSServer(handler)
+	defer secure.Close()
+	resp, err = secure.Client().Get(secure.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/entity"
+)
+
+const (
+	ForwardedHeader     = "forwarded"
+	XForwardedForHeader = "x-forwarded-for"
+)
+
+type clientAddressCtxKey struct{}
+
+func WithClientAddress(ctx context.Context, addr *entity.ClientAddress) context.Context {
+	return context.WithValue(ctx, clientAddressCtxKey{}, addr)
+}
+
+func GetClientAddress(ctx context.Context) *entity.ClientAddress {
+	addr, _ := ctx.Value(clientAddressCtxKey{}).(*entity.ClientAddress)
+	return addr
+}
+
+// TrustedProxies is a list of networks whose forwarding headers are trusted
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses a comma separated list of CIDRs or IPs
+func ParseTrustedProxies(s string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		if !strings.Contains(item, "/") {
+			addr, err := netip.ParseAddr(item)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", item, err)
+			}
+			proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", item, err)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+func (p TrustedProxies) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientAddress chooses the client IP. The forwarding chain is walked right-to-left starting from the peer,
+// the first hop that isn't a trusted proxy is the client.
+// header returns all values of the header, the name is in lower case.
+func (p TrustedProxies) ClientAddress(peer string, header func(name string) []string) *entity.ClientAddress {
+	peerIP := hostIP(peer)
+	res := &entity.ClientAddress{IP: peerIP.String(), Peer: peer}
+	if !peerIP.IsValid() {
+		res.IP = peer
+		return res
+	}
+
+	res.Hops = forwardedChain(header)
+	if len(res.Hops) == 0 || !p.Contains(peerIP) {
+		return res
+	}
+
+	for i := len(res.Hops) - 1; i >= 0; i-- {
+		ip := hostIP(res.Hops[i])
+		if !ip.IsValid() {
+			// obfuscated or malformed hop, the last trusted proxy is the best we know
+			break
+		}
+		res.IP = ip.String()
+		if !p.Contains(ip) {
+			break
+		}
+	}
+	return res
+}
+
+// forwardedChain returns the hops from the Forwarded header (RFC 7239) or, if it's missing,
+// from X-Forwarded-For or X-Real-IP.
+func forwardedChain(header func(name string) []string) []string {
+	if values := header(ForwardedHeader); len(values) != 0 {
+		var hops []string
+		for _, value := range values {
+			for _, element := range splitQuoted(value, ',') {
+				hops = append(hops, forwardedFor(element))
+			}
+		}
+		return hops
+	}
+
+	if values := header(XForwardedForHeader); len(values) != 0 {
+		var hops []string
+		for _, value := range values {
+			for _, hop := range strings.Split(value, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		return hops
+	}
+
+	if values := header(RealIPHeader); len(values) != 0 {
+		return []string{strings.TrimSpace(values[len(values)-1])}
+	}
+	return nil
+}
+
+// forwardedFor returns the value of the "for" parameter of the Forwarded header element
+func forwardedFor(element string) string {
+	for _, pair := range splitQuoted(element, ';') {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || !strings.EqualFold(name, "for") {
+			continue
+		}
+		return strings.Trim(value, `"`)
+	}
+	return ""
+}
+
+func splitQuoted(s string, sep rune) []string {
+	var (
+		res    []string
+		quoted bool
+		start  int
+	)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == sep && !quoted:
+			res = append(res, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, s[start:])
+}
+
+// hostIP parses "ip", "ip:port", "[ipv6]" and "[ipv6]:port"
+func hostIP(hostport string) netip.Addr {
+	host := strings.TrimSpace(hostport)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap().WithZone("")
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestHostIP(t *testing.T) {
+	tests := []struct {
+		hostport string
+		want     string
+	}{
+		{"192.0.2.1", "192.0.2.1"},
+		{"192.0.2.1:8080", "192.0.2.1"},
+		{" 192.0.2.1 ", "192.0.2.1"},
+		{"2001:db8::1", "2001:db8::1"},
+		{"[2001:db8::1]", "2001:db8::1"},
+		{"[2001:db8::1]:4711", "2001:db8::1"},
+		{"[fe80::1%eth0]:80", "fe80::1"},
+		{"::ffff:192.0.2.1", "192.0.2.1"},
+		{"[::ffff:192.0.2.1]:80", "192.0.2.1"},
+		{"unknown", ""},
+		{"_hidden", ""},
+		{"_hidden:80", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostport, func(t *testing.T) {
+			ip := hostIP(tt.hostport)
+			if len(tt.want) == 0 {
+				assert.False(t, ip.IsValid(), ip.String())
+				return
+			}
+			assert.Equal(t, tt.want, ip.String())
+		})
+	}
+}
+
+func TestForwardedFor(t *testing.T) {
+	tests := []struct {
+		element string
+		want    string
+	}{
+		{"for=192.0.2.60", "192.0.2.60"},
+		{`for="192.0.2.60:8080"`, "192.0.2.60:8080"},
+		{`For="[2001:db8:cafe::17]:4711"`, "[2001:db8:cafe::17]:4711"},
+		{"for=192.0.2.60;proto=http;by=203.0.113.43", "192.0.2.60"},
+		{"proto=https; for=192.0.2.60", "192.0.2.60"},
+		{`host="a;for=198.51.100.1";for=192.0.2.60`, "192.0.2.60"},
+		{"for=unknown", "unknown"},
+		{"for=_hidden", "_hidden"},
+		{"proto=http", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.element, func(t *testing.T) {
+			assert.Equal(t, tt.want, forwardedFor(tt.element))
+		})
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	proxies, err := ParseTrustedProxies("127.0.0.0/8,::1/128,10.0.0.0/8,2001:db8:ffff::/48")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		peer     string
+		headers  http.Header
+		wantIP   string
+		wantHops []string
+	}{
+		{name: "no headers", peer: "198.51.100.1:1234", wantIP: "198.51.100.1"},
+		{name: "ipv6 peer", peer: "[2001:db8::1]:1234", wantIP: "2001:db8::1"},
+		{name: "invalid peer", peer: "pipe", wantIP: "pipe"},
+		{
+			name:     "untrusted peer",
+			peer:     "198.51.100.1:1234",
+			headers:  http.Header{"X-Forwarded-For": {"192.0.2.1"}},
+			wantIP:   "198.51.100.1",
+			wantHops: []string{"192.0.2.1"},
+		},
+		{
+			name:     "x-forwarded-for",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"X-Forwarded-For": {"192.0.2.1, 10.0.0.2"}},
+			wantIP:   "192.0.2.1",
+			wantHops: []string{"192.0.2.1", "10.0.0.2"},
+		},
+		{
+			name:     "spoofed x-forwarded-for",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"X-Forwarded-For": {"203.0.113.7, 198.51.100.1"}},
+			wantIP:   "198.51.100.1",
+			wantHops: []string{"203.0.113.7", "198.51.100.1"},
+		},
+		{
+			name:     "several x-forwarded-for headers",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"X-Forwarded-For": {"192.0.2.1", "10.0.0.2"}},
+			wantIP:   "192.0.2.1",
+			wantHops: []string{"192.0.2.1", "10.0.0.2"},
+		},
+		{
+			name:     "ipv6 x-forwarded-for with ports",
+			peer:     "[::1]:1234",
+			headers:  http.Header{"X-Forwarded-For": {"[2001:db8::1]:4711, [2001:db8:ffff::2]:80"}},
+			wantIP:   "2001:db8::1",
+			wantHops: []string{"[2001:db8::1]:4711", "[2001:db8:ffff::2]:80"},
+		},
+		{
+			name:     "x-real-ip",
+			peer:     "127.0.0.1:1234",
+			headers:  http.Header{"X-Real-Ip": {"192.0.2.1"}},
+			wantIP:   "192.0.2.1",
+			wantHops: []string{"192.0.2.1"},
+		},
+		{
+			name:     "quoted forwarded",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"Forwarded": {`for="[2001:db8::1]:4711";proto=https, for=10.0.0.2`}},
+			wantIP:   "2001:db8::1",
+			wantHops: []string{"[2001:db8::1]:4711", "10.0.0.2"},
+		},
+		{
+			name: "forwarded takes precedence",
+			peer: "10.0.0.1:1234",
+			headers: http.Header{
+				"Forwarded":       {"for=192.0.2.1"},
+				"X-Forwarded-For": {"198.51.100.1"},
+			},
+			wantIP:   "192.0.2.1",
+			wantHops: []string{"192.0.2.1"},
+		},
+		{
+			name:     "obfuscated hop",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"Forwarded": {"for=192.0.2.1, for=_hidden, for=10.0.0.2"}},
+			wantIP:   "10.0.0.2",
+			wantHops: []string{"192.0.2.1", "_hidden", "10.0.0.2"},
+		},
+		{
+			name:     "unknown hop",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"Forwarded": {"for=unknown"}},
+			wantIP:   "10.0.0.1",
+			wantHops: []string{"unknown"},
+		},
+		{
+			name:     "forwarded without for",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"Forwarded": {"proto=https"}},
+			wantIP:   "10.0.0.1",
+			wantHops: []string{""},
+		},
+		{
+			name:     "all hops are trusted",
+			peer:     "10.0.0.1:1234",
+			headers:  http.Header{"X-Forwarded-For": {"10.0.0.3, 10.0.0.2"}},
+			wantIP:   "10.0.0.3",
+			wantHops: []string{"10.0.0.3", "10.0.0.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := proxies.ClientAddress(tt.peer, func(name string) []string { return tt.headers.Values(name) })
+			assert.Equal(t, tt.wantIP, addr.IP)
+			assert.Equal(t, tt.peer, addr.Peer)
+			assert.Equal(t, tt.wantHops, addr.Hops)
+		})
+	}
+}
+
+func TestDefaultTrustedProxies(t *testing.T) {
+	var cfg config.Config
+	cfg.SetDefaults()
+	proxies, err := ParseTrustedProxies(cfg.TrustedProxies)
+	require.NoError(t, err)
+
+	header := func(name string) []string {
+		return http.Header{"X-Forwarded-For": {"192.0.2.1"}}.Values(name)
+	}
+	assert.Equal(t, "192.0.2.1", proxies.ClientAddress("127.0.0.1:1234", header).IP)
+	assert.Equal(t, "192.0.2.1", proxies.ClientAddress("[::1]:1234", header).IP)
+	for _, peer := range []string{"10.0.0.1:1234", "172.16.0.1:1234", "192.168.0.1:1234", "[fc00::1]:1234"} {
+		assert.NotEqual(t, "192.0.2.1", proxies.ClientAddress(peer, header).IP, "only loopback is trusted by default: %s", peer)
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (s *testServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// newTestServerStream returns a stream of the peer with the incoming metadata.
+func newTestServerStream(peerAddr string, md metadata.MD) *testServerStream {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: net.TCPAddrFromAddrPort(netip.MustParseAddrPort(peerAddr))})
+	return &testServerStream{ctx: metadata.NewIncomingContext(ctx, md)}
+}
+
+func TestRealIPStreamMiddleware(t *testing.T) {
+	proxies, err := ParseTrustedProxies("127.0.0.0/8")
+	require.NoError(t, err)
+	interceptor := RealIPStreamMiddleware(proxies)
+
+	realIP := func(ss grpc.ServerStream) (ip string) {
+		err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/geoip.GeoIpService/Dump"}, func(_ interface{}, ss grpc.ServerStream) error {
+			ip = GetRealIP(ss.Context())
+			return nil
+		})
+		require.NoError(t, err)
+		return ip
+	}
+	assert.Equal(t, "192.0.2.1", realIP(newTestServerStream("127.0.0.1:1234", metadata.Pairs("x-forwarded-for", "192.0.2.1"))))
+	assert.Equal(t, "198.51.100.1", realIP(newTestServerStream("198.51.100.1:1234", metadata.Pairs("x-forwarded-for", "192.0.2.1"))),
+		"the forwarding headers of untrusted peers are ignored")
+}
@@ -0,0 +1,56 @@
+package microservice
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/bldsoft/geos/pkg/tlsconfig"
+	"github.com/bldsoft/gost/log"
+)
+
+// RestTLSServer serves the handler of the plaintext REST server over HTTPS,
+// so both of them have the same routes and middlewares
+type RestTLSServer struct {
+	server  *http.Server
+	handler atomic.Pointer[http.Handler]
+}
+
+func NewRestTLSServer(address string, tls *tlsconfig.Reloader) *RestTLSServer {
+	s := &RestTLSServer{}
+	s.server = &http.Server{
+		Addr:      address,
+		Handler:   http.HandlerFunc(s.serveHTTP),
+		TLSConfig: tls.TLSConfig(),
+	}
+	return s
+}
+
+// SetHandler sets the router of the plaintext server once its routes are built
+func (s *RestTLSServer) SetHandler(handler http.Handler) {
+	s.handler.Store(&handler)
+}
+
+func (s *RestTLSServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	handler := s.handler.Load()
+	if handler == nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	(*handler).ServeHTTP(w, r)
+}
+
+func (s *RestTLSServer) Run() error {
+	log.Infof("REST TLS server started. Listening on %s", s.server.Addr)
+	defer log.Infof("REST TLS server stopped")
+	// the certificates are provided by TLSConfig
+	if err := s.server.ListenAndServeTLS("", ""); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *RestTLSServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,162 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"golang.org/x/time/rate"
+)
+
+const (
+	KeyIP           = "ip"
+	KeyApiKey       = "api-key"
+	KeyHeaderPrefix = "header:"
+)
+
+const (
+	// overflowClient is the client of the bucket shared by the clients that don't fit into MaxBuckets
+	overflowClient = "overflow"
+	// the full buckets are dropped at most once per period, more often only if there are MaxBuckets of them
+	sweepPeriod     = time.Minute
+	fullSweepPeriod = time.Second
+)
+
+type Config struct {
+	Key         string  `mapstructure:"KEY" description:"What the rate limit buckets are keyed by: ip, api-key or header:<name>. Requests without an API key or the header are keyed by IP"`
+	LookupRPS   float64 `mapstructure:"LOOKUP_RPS" description:"Max lookup requests per second per client. 0 disables the limit"`
+	LookupBurst int     `mapstructure:"LOOKUP_BURST" description:"Max burst of lookup requests per client. If 0, it's equal to LOOKUP_RPS"`
+	DumpRPS     float64 `mapstructure:"DUMP_RPS" description:"Max dump requests per second per client. 0 disables the limit"`
+	DumpBurst   int     `mapstructure:"DUMP_BURST" description:"Max burst of dump requests per client. If 0, it's equal to DUMP_RPS"`
+	UpdateRPS   float64 `mapstructure:"UPDATE_RPS" description:"Max update requests per second per client. 0 disables the limit"`
+	UpdateBurst int     `mapstructure:"UPDATE_BURST" description:"Max burst of update requests per client. If 0, it's equal to UPDATE_RPS"`
+	MaxBuckets  uint64  `mapstructure:"MAX_BUCKETS" description:"Max number of tracked clients. A client is forgotten only when its bucket is refilled, the clients that don't fit share a bucket per scope. 0 is unlimited"`
+}
+
+func (c *Config) SetDefaults() {
+	c.Key = KeyIP
+	c.MaxBuckets = 100_000
+}
+
+func (c *Config) Validate() error {
+	if c.Key != KeyIP && c.Key != KeyApiKey && !strings.HasPrefix(c.Key, KeyHeaderPrefix) {
+		return fmt.Errorf("RATE_LIMIT_KEY: unknown value %q", c.Key)
+	}
+	return nil
+}
+
+// Limit returns the default limit of the scope. geonames-dump shares the limit with dump.
+func (c *Config) Limit(scope entity.ApiKeyScope) entity.RateLimit {
+	switch scope {
+	case entity.ApiKeyScopeLookup:
+		return entity.RateLimit{RPS: c.LookupRPS, Burst: c.LookupBurst}
+	case entity.ApiKeyScopeDump, entity.ApiKeyScopeGeoNamesDump:
+		return entity.RateLimit{RPS: c.DumpRPS, Burst: c.DumpBurst}
+	case entity.ApiKeyScopeUpdate:
+		return entity.RateLimit{RPS: c.UpdateRPS, Burst: c.UpdateBurst}
+	}
+	return entity.RateLimit{}
+}
+
+// Limiter is a set of token buckets keyed by scope and client.
+// A bucket is dropped only when it's full: it's the same as a new one, so the client can't reset its limit.
+type Limiter struct {
+	config    Config
+	now       func() time.Time
+	mtx       sync.Mutex
+	buckets   map[string]*rate.Limiter
+	lastSweep time.Time
+}
+
+func NewLimiter(config Config) *Limiter {
+	return &Limiter{
+		config:  config,
+		now:     time.Now,
+		buckets: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *Limiter) KeySource() string {
+	return l.config.Key
+}
+
+// Allow takes a token from the client bucket. The API key limits override the default ones.
+// If the request isn't allowed, it returns the time after which it can be retried.
+func (l *Limiter) Allow(scope entity.ApiKeyScope, client string, apiKey *entity.ApiKey) (bool, time.Duration) {
+	limit := l.config.Limit(scope)
+	if apiKey != nil {
+		if keyLimit, ok := apiKey.RateLimits[scope]; ok {
+			// the quota belongs to the key, so it's shared by all of its clients
+			limit, client = keyLimit, "key:"+apiKey.ID
+		}
+	}
+	if limit.RPS <= 0 {
+		return true, 0
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = max(int(math.Ceil(limit.RPS)), 1)
+	}
+
+	now := l.now()
+	bucket := l.bucket(now, string(scope), client, rate.Limit(limit.RPS), burst)
+	reservation := bucket.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// bucket returns the bucket of the client. If there are MaxBuckets buckets and none of them is full,
+// the new client gets the overflow bucket of the scope.
+func (l *Limiter) bucket(now time.Time, scope, client string, limit rate.Limit, burst int) *rate.Limiter {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if now.Sub(l.lastSweep) >= sweepPeriod {
+		l.sweep(now)
+	}
+
+	key := scope + "/" + client
+	bucket, ok := l.buckets[key]
+	if !ok && l.atCapacity() {
+		if now.Sub(l.lastSweep) >= fullSweepPeriod {
+			l.sweep(now)
+		}
+		if l.atCapacity() {
+			key = scope + "/" + overflowClient
+			bucket, ok = l.buckets[key]
+		}
+	}
+	if !ok {
+		bucket = rate.NewLimiter(limit, burst)
+		l.buckets[key] = bucket
+		return bucket
+	}
+	// the limits of the key may be changed by reload
+	if bucket.Limit() != limit || bucket.Burst() != burst {
+		bucket.SetLimitAt(now, limit)
+		bucket.SetBurstAt(now, burst)
+	}
+	return bucket
+}
+
+func (l *Limiter) atCapacity() bool {
+	return l.config.MaxBuckets != 0 && uint64(len(l.buckets)) >= l.config.MaxBuckets
+}
+
+// sweep drops the full buckets
+func (l *Limiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(config Config) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(config)
+	l.now = clock.Now
+	return l, clock
+}
+
+func allowN(l *Limiter, n int, scope entity.ApiKeyScope, client string, apiKey *entity.ApiKey) (allowed int) {
+	for i := 0; i < n; i++ {
+		if ok, _ := l.Allow(scope, client, apiKey); ok {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestLimiterAllow(t *testing.T) {
+	l, clock := newTestLimiter(Config{LookupRPS: 2, LookupBurst: 3, DumpRPS: 0.5})
+
+	assert.Equal(t, 3, allowN(l, 5, entity.ApiKeyScopeLookup, "a", nil), "the burst")
+	ok, retryAfter := l.Allow(entity.ApiKeyScopeLookup, "a", nil)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	assert.Equal(t, 3, allowN(l, 5, entity.ApiKeyScopeLookup, "b", nil), "the clients have their own buckets")
+	assert.Equal(t, 1, allowN(l, 5, entity.ApiKeyScopeDump, "a", nil), "the scopes have their own buckets, the burst is the RPS rounded up")
+	assert.Equal(t, 10, allowN(l, 10, entity.ApiKeyScopeUpdate, "a", nil), "no limit")
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(t, 1, allowN(l, 5, entity.ApiKeyScopeLookup, "a", nil), "the denied requests don't take tokens")
+	clock.Advance(time.Hour)
+	assert.Equal(t, 3, allowN(l, 5, entity.ApiKeyScopeLookup, "a", nil), "the bucket is refilled up to the burst")
+}
+
+func TestLimiterApiKeyLimits(t *testing.T) {
+	l, clock := newTestLimiter(Config{LookupRPS: 1})
+	key := &entity.ApiKey{ID: "k", RateLimits: map[entity.ApiKeyScope]entity.RateLimit{entity.ApiKeyScopeLookup: {RPS: 10, Burst: 4}}}
+
+	assert.Equal(t, 2, allowN(l, 2, entity.ApiKeyScopeLookup, "a", key))
+	assert.Equal(t, 2, allowN(l, 5, entity.ApiKeyScopeLookup, "b", key), "the quota of the key is shared by its clients")
+	assert.Equal(t, 1, allowN(l, 5, entity.ApiKeyScopeLookup, "a", &entity.ApiKey{ID: "other"}), "the default limit")
+
+	key.RateLimits[entity.ApiKeyScopeLookup] = entity.RateLimit{RPS: 10, Burst: 20}
+	clock.Advance(time.Hour)
+	assert.Equal(t, 20, allowN(l, 30, entity.ApiKeyScopeLookup, "a", key), "the reloaded limits are applied to the bucket")
+}
+
+func TestLimiterCapacity(t *testing.T) {
+	l, clock := newTestLimiter(Config{LookupRPS: 1, LookupBurst: 2, MaxBuckets: 3})
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 2, allowN(l, 3, entity.ApiKeyScopeLookup, fmt.Sprint(i), nil))
+	}
+
+	// the tracked clients are exhausted, so the new ones don't evict them
+	assert.Equal(t, 2, allowN(l, 3, entity.ApiKeyScopeLookup, "new1", nil), "the overflow bucket")
+	assert.Equal(t, 0, allowN(l, 3, entity.ApiKeyScopeLookup, "new2", nil), "the overflow bucket is shared")
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 0, allowN(l, 1, entity.ApiKeyScopeLookup, fmt.Sprint(i), nil), "the bucket isn't reset")
+	}
+
+	// client 0 keeps using its bucket, the others are refilled and dropped
+	clock.Advance(time.Second)
+	assert.Equal(t, 1, allowN(l, 1, entity.ApiKeyScopeLookup, "0", nil))
+	clock.Advance(time.Second)
+	assert.Equal(t, 2, allowN(l, 3, entity.ApiKeyScopeLookup, "new3", nil), "a full bucket is dropped for the new client")
+	assert.Equal(t, 1, allowN(l, 3, entity.ApiKeyScopeLookup, "0", nil), "the bucket in use isn't dropped")
+	assert.LessOrEqual(t, uint64(len(l.buckets)), l.config.MaxBuckets)
+}
+
+func TestLimiterSweepsFullBuckets(t *testing.T) {
+	l, clock := newTestLimiter(Config{LookupRPS: 1, LookupBurst: 5})
+	for i := 0; i < 100; i++ {
+		allowN(l, 1, entity.ApiKeyScopeLookup, fmt.Sprint(i), nil)
+	}
+	assert.Len(t, l.buckets, 100)
+
+	clock.Advance(sweepPeriod)
+	allowN(l, 1, entity.ApiKeyScopeLookup, "a", nil)
+	assert.Len(t, l.buckets, 1, "the idle buckets are dropped without MaxBuckets")
+}
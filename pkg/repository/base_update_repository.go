@@ -15,6 +15,7 @@ type updaterWithLastErr struct {
 	updateFunc func(ctx context.Context, force bool) error
 	inProgress atomic.Bool
 	lastErr    atomic.Pointer[string]
+	onUpdated  []func(ctx context.Context)
 }
 
 func newUpdaterWithLastErr(updateFunc func(ctx context.Context, force bool) error) *updaterWithLastErr {
@@ -41,6 +42,9 @@ func (u *updaterWithLastErr) Update(ctx context.Context, force bool) error {
 		return err
 	}
 	u.lastErr.Store(nil)
+	for _, f := range u.onUpdated {
+		f(ctx)
+	}
 	return nil
 }
 
@@ -101,6 +105,11 @@ func (r *baseUpdateRepository) update(ctx context.Context, opts updateOptions) e
 	return nil
 }
 
+// OnUpdated adds the function called after each successful update. It isn't safe to call it while running.
+func (r *baseUpdateRepository) OnUpdated(f func(ctx context.Context)) {
+	r.updater.onUpdated = append(r.updater.onUpdated, f)
+}
+
 func (r *baseUpdateRepository) IsInProgress() bool {
 	return r.updater.InProgress()
 }
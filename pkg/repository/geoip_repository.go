@@ -16,6 +16,7 @@ import (
 	"github.com/bldsoft/geos/pkg/utils"
 	"github.com/bldsoft/gost/log"
 	"github.com/bldsoft/gost/utils/errgroup"
+	"github.com/oschwald/maxminddb-golang"
 	"golang.org/x/sync/singleflight"
 )
 
@@ -43,7 +44,7 @@ const (
 	MaxmindDBTypeHosting MaxmindDBType = "hosting"
 )
 
-func openPatchedDB[T maxmind.CSVEntity](
+func openPatchedDB[T maxmind.PatchEntity](
 	conf DBConfig, customPrefix, csvDumpDir string, required bool,
 ) *maxmindDBWithCachedCSVDump {
 	logger := log.Logger.WithFields(log.Fields{"db": customPrefix})
@@ -79,10 +80,21 @@ func openPatchedDB[T maxmind.CSVEntity](
 			filepath.Join(filepath.Dir(conf.LocalPath), customPrefix+"_patch"+filepath.Ext(patchesURL.Path)),
 			patchesURL.String(),
 		)
-		customDB := maxmind.NewCustomDatabase(ctx, patchesSource)
+		customDB := maxmind.NewCustomDatabase[T](ctx, patchesSource)
 		patchedDB = patchedDB.SetCustom(customDB)
 	}
 
+	overridesPath := filepath.Join(filepath.Dir(conf.LocalPath), customPrefix+"_overrides.jsonl")
+	overrides, err := maxmind.NewOverrideDatabase[T](ctx, overridesPath)
+	if err != nil {
+		if required {
+			logger.Fatalf("Failed to open overrides: %s", err)
+		}
+		logger.Warnf("Failed to open overrides: %s", err)
+		return nil
+	}
+	patchedDB = patchedDB.SetOverrides(overrides)
+
 	return withCachedCSVDump[T](ctx, patchedDB, filepath.Join(csvDumpDir, customPrefix+".csv"))
 }
 
@@ -98,6 +110,7 @@ type GeoIPRepositoryConfig struct {
 	Hosting          DBConfig
 	CSVDirPath       string
 	AutoUpdatePeriod time.Duration
+	GeoNames         entity.GeoNameLocalizer // optional, the fallback for the names missing in the city db
 }
 
 type GeoIPRepository struct {
@@ -176,7 +189,7 @@ func (r *GeoIPRepository) CityLite(ctx context.Context, ip net.IP, lang string)
 	if err != nil {
 		return nil, err
 	}
-	return entity.DbToCityLite(cityLiteDB, lang), nil
+	return entity.DbToCityLite(cityLiteDB, lang, r.cfg.GeoNames), nil
 }
 
 func (r *GeoIPRepository) Hosting(ctx context.Context, ip net.IP) (*entity.Hosting, error) {
@@ -252,6 +265,117 @@ func (r *GeoIPRepository) database(_ context.Context, dbType MaxmindDBType) (max
 	return db, nil
 }
 
+func (r *GeoIPRepository) patchedDB(dbType MaxmindDBType) (*maxmindDBWithCachedCSVDump, error) {
+	var db *maxmindDBWithCachedCSVDump
+	switch dbType {
+	case MaxmindDBTypeCity:
+		db = r.dbCity
+	case MaxmindDBTypeISP:
+		db = r.dbISP
+	case MaxmindDBTypeHosting:
+		db = r.dbHosting
+	default:
+		return nil, fmt.Errorf("unknown database type %q: %w", dbType, utils.ErrInvalidArgument)
+	}
+	if db == nil {
+		return nil, fmt.Errorf("%s db is %w", dbType, utils.ErrDisabled)
+	}
+	return db, nil
+}
+
+func (r *GeoIPRepository) overrides(dbType MaxmindDBType) (*maxmind.OverrideDatabase, error) {
+	db, err := r.patchedDB(dbType)
+	if err != nil {
+		return nil, err
+	}
+	if db.Overrides() == nil {
+		return nil, fmt.Errorf("%s db overrides are %w", dbType, utils.ErrDisabled)
+	}
+	return db.Overrides(), nil
+}
+
+func (r *GeoIPRepository) Overrides(ctx context.Context, dbType MaxmindDBType) ([]*entity.GeoIPOverride, error) {
+	overrides, err := r.overrides(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.List(ctx), nil
+}
+
+func (r *GeoIPRepository) Override(ctx context.Context, dbType MaxmindDBType, id string) (*entity.GeoIPOverride, error) {
+	overrides, err := r.overrides(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.Get(ctx, id)
+}
+
+func (r *GeoIPRepository) AddOverride(ctx context.Context, dbType MaxmindDBType, override entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	overrides, err := r.overrides(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.Add(ctx, override)
+}
+
+func (r *GeoIPRepository) ReplaceOverride(ctx context.Context, dbType MaxmindDBType, id string, override entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	overrides, err := r.overrides(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return overrides.Replace(ctx, id, override)
+}
+
+func (r *GeoIPRepository) DeleteOverride(ctx context.Context, dbType MaxmindDBType, id string) error {
+	overrides, err := r.overrides(dbType)
+	if err != nil {
+		return err
+	}
+	return overrides.Remove(ctx, id)
+}
+
+// Expiring returns the patch records and the runtime overrides of the db that expire before the time
+func (r *GeoIPRepository) Expiring(ctx context.Context, dbType MaxmindDBType, until time.Time) ([]*entity.ExpiringGeoIPRecord, error) {
+	db, err := r.patchedDB(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return db.Expiring(until), nil
+}
+
+// AnalyzePatches reports the overlaps of the patches and the runtime overrides of the db
+func (r *GeoIPRepository) AnalyzePatches(ctx context.Context, dbType MaxmindDBType, limit int) (*entity.PatchAnalysis, error) {
+	db, err := r.patchedDB(dbType)
+	if err != nil {
+		return nil, err
+	}
+	return db.AnalyzePatches(ctx, limit)
+}
+
+// WalkCityNetworks calls the function for each network of the city db, the patches included
+func (r *GeoIPRepository) WalkCityNetworks(ctx context.Context, f func(network *net.IPNet, city *entity.City) error) error {
+	networks, err := r.dbCity.Networks(ctx, maxminddb.SkipAliasedNetworks)
+	if err != nil {
+		return err
+	}
+	for networks.Next() {
+		var city entity.City
+		network, err := networks.Network(&city)
+		if err != nil {
+			return err
+		}
+		if err := f(network, &city); err != nil {
+			return err
+		}
+	}
+	return networks.Err()
+}
+
+// OnCityUpdated adds the function called after each successful update of the city db
+func (r *GeoIPRepository) OnCityUpdated(f func(ctx context.Context)) {
+	r.cityUpdater.OnUpdated(f)
+}
+
 func (r *GeoIPRepository) Run(ctx context.Context) error {
 	var errGroup errgroup.Group
 	errGroup.Go(func() error {
@@ -13,6 +13,7 @@ import (
 	"github.com/bldsoft/geos/pkg/storage/geonames"
 	"github.com/bldsoft/geos/pkg/storage/source"
 	"github.com/bldsoft/gost/log"
+	"github.com/mkrou/geonames/models"
 	"golang.org/x/sync/singleflight"
 )
 
@@ -22,6 +23,13 @@ type StorageConfig struct {
 	LocalDir         string
 	PatchesRemoteURL string
 	AutoUpdatePeriod time.Duration
+	AlternateNames   bool     // load alternateNamesV2 to localize the names
+	Languages        []string // the languages of the alternate names, all if empty
+	Admin2Codes      bool     // load admin2Codes.txt
+	PostalCodes      []string // the countries to load the postal codes for, see source.GeoNamesAllCountriesPostalCodes
+	CityDumps        []string // the dumps to load the cities from, cities500 if empty, see source.GeoNamesCityDumps
+	FeatureClasses   []string // the feature classes of the cities to load, all if empty
+	CityAltNames     bool     // keep the alternatenames column of the city dumps for the name search
 }
 type GeoNameRepository struct {
 	cfg     StorageConfig
@@ -38,6 +46,29 @@ func NewGeoNamesRepository(config StorageConfig) *GeoNameRepository {
 	ctx := context.WithValue(context.Background(), log.LoggerCtxKey, logger)
 
 	origSource := source.NewGeoNamesSource(config.LocalDir)
+	if len(config.CityDumps) > 0 {
+		dumps := make([]models.GeoNameFile, 0, len(config.CityDumps))
+		for _, name := range config.CityDumps {
+			dump, err := source.ParseGeoNamesCityDump(name)
+			if err != nil {
+				log.FromContext(ctx).Fatalf("Failed to parse GeoNames city dumps: %s", err)
+			}
+			dumps = append(dumps, dump)
+		}
+		origSource = origSource.WithCityDumps(dumps, config.FeatureClasses)
+	}
+	if !config.CityAltNames {
+		origSource = origSource.WithoutCityAlternateNames()
+	}
+	if config.AlternateNames {
+		origSource = origSource.WithAlternateNames(config.Languages)
+	}
+	if config.Admin2Codes {
+		origSource = origSource.WithAdmin2Codes()
+	}
+	if len(config.PostalCodes) > 0 {
+		origSource = origSource.WithPostalCodes(config.PostalCodes)
+	}
 	original := geonames.NewStorage(ctx, origSource)
 	storage := geonames.NewPatchedStorage(original)
 
@@ -71,6 +102,16 @@ func (r *GeoNameRepository) Run(ctx context.Context) error {
 	return r.baseUpdateRepository.Run(ctx)
 }
 
+// OnUpdated adds the function called after each successful update
+func (r *GeoNameRepository) OnUpdated(f func(ctx context.Context)) {
+	r.baseUpdateRepository.OnUpdated(f)
+}
+
+// CustomIDCollisions returns the patch entities with the IDs of the GeoNames entities
+func (r *GeoNameRepository) CustomIDCollisions(ctx context.Context) ([]*entity.GeoNameIDCollision, error) {
+	return r.storage.CustomIDCollisions(ctx)
+}
+
 func (r *GeoNameRepository) StartUpdate(ctx context.Context) error {
 	return r.baseUpdateRepository.StartUpdate(ctx)
 }
@@ -90,20 +131,49 @@ func (r *GeoNameRepository) CheckUpdates(ctx context.Context) (entity.DBUpdate[e
 	return result.(entity.DBUpdate[entity.PatchedGeoNamesVersion]), err
 }
 
-func (r *GeoNameRepository) Continents(ctx context.Context) []*entity.GeoNameContinent {
-	return r.storage.Continents(ctx)
+func (r *GeoNameRepository) Continents(ctx context.Context, lang string) []*entity.GeoNameContinent {
+	return r.storage.LocalizedContinents(ctx, lang)
+}
+
+// Localizer returns the names of the GeoNames entities in other languages
+func (r *GeoNameRepository) Localizer() entity.GeoNameLocalizer {
+	return r.storage.Localizer()
+}
+
+func (r *GeoNameRepository) Countries(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+	return r.storage.CountriesPage(ctx, filter)
+}
+
+func (r *GeoNameRepository) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+	return r.storage.SubdivisionsPage(ctx, filter)
+}
+
+func (r *GeoNameRepository) Subdivisions2(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return r.storage.Subdivisions2Page(ctx, filter)
+}
+
+func (r *GeoNameRepository) Cities(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+	return r.storage.CitiesPage(ctx, filter)
+}
+
+func (r *GeoNameRepository) PostalCodes(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return r.storage.PostalCodesPage(ctx, filter)
+}
+
+func (r *GeoNameRepository) Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	return r.storage.Nearest(ctx, filter)
 }
 
-func (r *GeoNameRepository) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
-	return r.storage.Countries(ctx, filter)
+func (r *GeoNameRepository) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return r.storage.GeoName(ctx, geoNameID, filter)
 }
 
-func (r *GeoNameRepository) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
-	return r.storage.Subdivisions(ctx, filter)
+func (r *GeoNameRepository) Children(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return r.storage.Children(ctx, geoNameID, filter)
 }
 
-func (r *GeoNameRepository) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
-	return r.storage.Cities(ctx, filter)
+func (r *GeoNameRepository) Ancestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return r.storage.Ancestors(ctx, geoNameID, filter)
 }
 
 func (r *GeoNameRepository) Dump(ctx context.Context, format DumpFormat) ([]byte, error) {
@@ -124,7 +194,7 @@ func (r *GeoNameRepository) Dump(ctx context.Context, format DumpFormat) ([]byte
 		}
 	}
 
-	cities, err := r.Cities(ctx, entity.GeoNameFilter{})
+	cities, err := r.storage.Cities(ctx, entity.GeoNameFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +203,7 @@ func (r *GeoNameRepository) Dump(ctx context.Context, format DumpFormat) ([]byte
 		return nil, err
 	}
 
-	subdivs, err := r.Subdivisions(ctx, entity.GeoNameFilter{})
+	subdivs, err := r.storage.Subdivisions(ctx, entity.GeoNameFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +212,7 @@ func (r *GeoNameRepository) Dump(ctx context.Context, format DumpFormat) ([]byte
 		return nil, err
 	}
 
-	countries, err := r.Countries(ctx, entity.GeoNameFilter{})
+	countries, err := r.storage.Countries(ctx, entity.GeoNameFilter{})
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +221,7 @@ func (r *GeoNameRepository) Dump(ctx context.Context, format DumpFormat) ([]byte
 		return nil, err
 	}
 
-	continents := r.Continents(ctx)
+	continents := r.Continents(ctx, "")
 	if err := writeEntitiesToCSV(csvWriter, continents); err != nil {
 		return nil, err
 	}
@@ -83,12 +83,17 @@ func (db *maxmindDBWithCachedCSVDump) WriteCSVTo(ctx context.Context, w io.Write
 }
 
 func (db *maxmindDBWithCachedCSVDump) Update(ctx context.Context, force bool) error {
+	// the overrides don't depend on the update sources, so they're removed even if the sources are unavailable
+	if err := db.PatchedDatabase.RemoveExpiredOverrides(ctx); err != nil {
+		log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to remove the expired overrides")
+	}
+
 	updates, err := db.PatchedDatabase.CheckUpdates(ctx)
 	if err != nil {
 		return err
 	}
 
-	if updates.RemoteVersion.Compare(updates.CurrentVersion) > 0 {
+	if updates.RemoteVersion.Compare(updates.CurrentVersion) > 0 || db.PatchedDatabase.PatchValidityChanged() {
 		if err := db.PatchedDatabase.Update(ctx, force); err != nil {
 			return err
 		}
@@ -0,0 +1,357 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/miekg/dns"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	resolvConfPath = "/etc/resolv.conf"
+	hostsPath      = "/etc/hosts"
+)
+
+type IPPreference string
+
+const (
+	PreferAny  IPPreference = "any"
+	PreferIPv4 IPPreference = "ipv4"
+	PreferIPv6 IPPreference = "ipv6"
+)
+
+type Config struct {
+	LookupEnabled bool          `mapstructure:"LOOKUP_ENABLED" description:"Allow hostnames to be passed instead of IP addresses. If disabled, only IP addresses are accepted"`
+	Server        string        `mapstructure:"SERVER" description:"Comma separated list of upstream DNS servers (host or host:port). If empty, the nameservers from /etc/resolv.conf are used. /etc/hosts and the search domains of /etc/resolv.conf are used in both cases"`
+	Timeout       time.Duration `mapstructure:"TIMEOUT" description:"Max time of a single hostname lookup"`
+	IPPreference  IPPreference  `mapstructure:"IP_PREFERENCE" description:"Preferred address family of resolved addresses: any (the family answered first), ipv4, ipv6. The first preferred address is used for lookups"`
+	CacheSize     uint64        `mapstructure:"CACHE_SIZE" description:"Max number of cached hostnames. 0 disables the limit"`
+	CacheMinTTL   time.Duration `mapstructure:"CACHE_MIN_TTL" description:"Min time to cache a lookup result. It's also used for hostnames that don't exist"`
+	CacheMaxTTL   time.Duration `mapstructure:"CACHE_MAX_TTL" description:"Max time to cache a lookup result, regardless of the record TTL. It's also used for the hostnames of /etc/hosts"`
+}
+
+func (c *Config) SetDefaults() {
+	c.LookupEnabled = true
+	c.Timeout = 2 * time.Second
+	c.IPPreference = PreferAny
+	c.CacheSize = 10_000
+	c.CacheMinTTL = 30 * time.Second
+	c.CacheMaxTTL = time.Hour
+}
+
+func (c *Config) Validate() error {
+	switch c.IPPreference {
+	case PreferAny, PreferIPv4, PreferIPv6:
+	default:
+		return fmt.Errorf("DNS_IP_PREFERENCE: unknown value %q", c.IPPreference)
+	}
+	if c.CacheMaxTTL < c.CacheMinTTL {
+		return fmt.Errorf("DNS_CACHE_MAX_TTL is less than DNS_CACHE_MIN_TTL")
+	}
+	return nil
+}
+
+// Resolver resolves hostnames using /etc/hosts and the configured upstream DNS servers.
+// The search domains of /etc/resolv.conf are applied to the names that aren't fully qualified.
+// The results are cached according to the TTL of the DNS records.
+type Resolver struct {
+	config  Config
+	servers []string
+	search  *dns.ClientConfig
+	udp     *dns.Client
+	tcp     *dns.Client
+	cache   *ttlcache.Cache[string, []net.IP]
+	sf      singleflight.Group
+}
+
+func NewResolver(config Config) (*Resolver, error) {
+	r := &Resolver{
+		config: config,
+		search: &dns.ClientConfig{Ndots: 1},
+		udp:    &dns.Client{Net: "udp"},
+		tcp:    &dns.Client{Net: "tcp"},
+		cache: ttlcache.New(
+			ttlcache.WithCapacity[string, []net.IP](config.CacheSize),
+			ttlcache.WithDisableTouchOnHit[string, []net.IP](),
+		),
+	}
+	if !config.LookupEnabled {
+		return r, nil
+	}
+
+	conf, err := dns.ClientConfigFromFile(resolvConfPath)
+	switch {
+	case err == nil:
+		r.search = conf
+	case len(config.Server) == 0:
+		return nil, fmt.Errorf("dns resolver: %w", err)
+	}
+	if r.servers, err = nameservers(config.Server, conf); err != nil {
+		return nil, fmt.Errorf("dns resolver: %w", err)
+	}
+	return r, nil
+}
+
+func nameservers(servers string, conf *dns.ClientConfig) ([]string, error) {
+	var res []string
+	if len(servers) != 0 {
+		for _, server := range strings.Split(servers, ",") {
+			server = strings.TrimSpace(server)
+			if len(server) == 0 {
+				continue
+			}
+			if _, _, err := net.SplitHostPort(server); err != nil {
+				server = net.JoinHostPort(server, "53")
+			}
+			res = append(res, server)
+		}
+		if len(res) == 0 {
+			return nil, fmt.Errorf("no nameservers in %q", servers)
+		}
+		return res, nil
+	}
+
+	for _, server := range conf.Servers {
+		res = append(res, net.JoinHostPort(server, conf.Port))
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no nameservers found in %s", resolvConfPath)
+	}
+	return res, nil
+}
+
+// LookupIP returns the addresses of the host ordered according to the configured IP preference.
+func (r *Resolver) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	if !r.config.LookupEnabled {
+		return nil, fmt.Errorf("%s: %w", host, utils.ErrHostnameLookupDisabled)
+	}
+
+	host = strings.ToLower(host)
+	if _, ok := dns.IsDomainName(host); !ok || len(strings.TrimSuffix(host, ".")) == 0 {
+		return nil, fmt.Errorf("invalid hostname %q", host)
+	}
+
+	var ips []net.IP
+	if item := r.cache.Get(host); item != nil {
+		ips = item.Value()
+	} else {
+		// the lookup is shared by the concurrent callers, so it isn't bound to the context of any of them
+		ch := r.sf.DoChan(host, func() (interface{}, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), r.config.Timeout)
+			defer cancel()
+			ips, ttl, err := r.lookup(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			r.cache.Set(host, ips, ttl)
+			return ips, nil
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case res := <-ch:
+			if res.Err != nil {
+				return nil, res.Err
+			}
+			ips = res.Val.([]net.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("%s: %w", host, utils.ErrNotFound)
+	}
+	return slices.Clone(ips), nil
+}
+
+// lookup returns the addresses of the host and the time to cache them.
+// /etc/hosts goes first, then the names of the search list are resolved one by one
+// until one of them has addresses.
+func (r *Resolver) lookup(ctx context.Context, host string) ([]net.IP, time.Duration, error) {
+	if ips := r.order(lookupHosts(hostsPath, strings.TrimSuffix(host, "."))); len(ips) != 0 {
+		return ips, r.config.CacheMaxTTL, nil
+	}
+
+	for _, name := range r.search.NameList(host) {
+		ips, ttl, err := r.lookupName(ctx, name)
+		if err != nil {
+			return nil, 0, fmt.Errorf("lookup %s: %w", host, err)
+		}
+		if len(ips) != 0 {
+			ttl = min(max(ttl, r.config.CacheMinTTL), r.config.CacheMaxTTL)
+			return ips, ttl, nil
+		}
+	}
+	return nil, r.config.CacheMinTTL, nil
+}
+
+// lookupName resolves both address families of the fully qualified name. The failure of one of them
+// is treated as an empty answer if the other one succeeded, the lookup fails only if both of them failed.
+// The returned TTL is the min TTL of the answers.
+func (r *Resolver) lookupName(ctx context.Context, name string) ([]net.IP, time.Duration, error) {
+	type answer struct {
+		ips []net.IP
+		ttl uint32
+		err error
+	}
+
+	qtypes := []uint16{dns.TypeA, dns.TypeAAAA}
+	answers := make([]answer, len(qtypes))
+	var (
+		mu      sync.Mutex
+		arrived []int
+		wg      sync.WaitGroup
+	)
+	for i, qtype := range qtypes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ips, ttl, err := r.query(ctx, name, qtype)
+			answers[i] = answer{ips: ips, ttl: ttl, err: err}
+			mu.Lock()
+			arrived = append(arrived, i)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	var (
+		ips  [][]net.IP
+		ttl  = uint32(math.MaxUint32)
+		errs []error
+	)
+	for _, i := range r.familyOrder(arrived) {
+		a := answers[i]
+		if a.err != nil {
+			errs = append(errs, a.err)
+			continue
+		}
+		// empty answers have no TTL, so they don't shorten the caching of the other address family
+		if len(a.ips) != 0 {
+			ips = append(ips, a.ips)
+			ttl = min(ttl, a.ttl)
+		}
+	}
+	if len(errs) == len(qtypes) {
+		return nil, 0, errors.Join(errs...)
+	}
+	return slices.Concat(ips...), time.Duration(ttl) * time.Second, nil
+}
+
+// familyOrder returns the indexes of the A and AAAA answers in the order of the IP preference.
+// PreferAny keeps the order in which the answers arrived.
+func (r *Resolver) familyOrder(arrived []int) []int {
+	switch r.config.IPPreference {
+	case PreferIPv4:
+		return []int{0, 1}
+	case PreferIPv6:
+		return []int{1, 0}
+	default:
+		return arrived
+	}
+}
+
+// order sorts the addresses of /etc/hosts according to the IP preference.
+// PreferAny keeps the order of the file.
+func (r *Resolver) order(ips []net.IP) []net.IP {
+	if r.config.IPPreference == PreferAny {
+		return ips
+	}
+	slices.SortStableFunc(ips, func(a, b net.IP) int {
+		preferred := func(ip net.IP) bool { return (ip.To4() != nil) == (r.config.IPPreference == PreferIPv4) }
+		switch {
+		case preferred(a) == preferred(b):
+			return 0
+		case preferred(a):
+			return -1
+		default:
+			return 1
+		}
+	})
+	return ips
+}
+
+// query asks the servers one by one until one of them answers.
+// The returned TTL is the min TTL of the answer records.
+func (r *Resolver) query(ctx context.Context, name string, qtype uint16) ([]net.IP, uint32, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+
+	var lastErr error
+	for _, server := range r.servers {
+		resp, _, err := r.udp.ExchangeContext(ctx, msg, server)
+		if err == nil && resp.Truncated {
+			resp, _, err = r.tcp.ExchangeContext(ctx, msg, server)
+		}
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		switch resp.Rcode {
+		case dns.RcodeSuccess:
+		case dns.RcodeNameError:
+			return nil, 0, nil
+		default:
+			lastErr = fmt.Errorf("%s: %s", server, dns.RcodeToString[resp.Rcode])
+			continue
+		}
+
+		var ips []net.IP
+		ttl := uint32(math.MaxUint32)
+		for _, rr := range resp.Answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				ips = append(ips, rr.A)
+			case *dns.AAAA:
+				ips = append(ips, rr.AAAA)
+			}
+			ttl = min(ttl, rr.Header().Ttl)
+		}
+		return ips, ttl, nil
+	}
+	return nil, 0, lastErr
+}
+
+// lookupHosts returns the addresses of the host listed in the hosts file.
+// The file is read on every cache miss, so its changes are picked up without a restart.
+func lookupHosts(path, host string) []net.IP {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var res []net.IP
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		ip := net.ParseIP(fields[0])
+		if ip == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			if strings.EqualFold(strings.TrimSuffix(name, "."), host) {
+				res = append(res, ip)
+				break
+			}
+		}
+	}
+	return res
+}
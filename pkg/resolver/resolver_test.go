@@ -0,0 +1,265 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRecords map[uint16]map[string]string // qtype -> fqdn -> address
+
+// testServer is an in-process DNS server. SERVFAIL is returned for the names of the failing query types.
+type testServer struct {
+	records testRecords
+	ttls    map[uint16]uint32 // 60 by default
+	failing map[uint16]bool
+	delay   time.Duration
+	delays  map[uint16]time.Duration // added to the delay
+	queries atomic.Int32
+}
+
+func (s *testServer) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	s.queries.Add(1)
+	q := req.Question[0]
+	time.Sleep(s.delay + s.delays[q.Qtype])
+	ttl, ok := s.ttls[q.Qtype]
+	if !ok {
+		ttl = 60
+	}
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	switch {
+	case s.failing[q.Qtype]:
+		resp.Rcode = dns.RcodeServerFailure
+	case s.records[dns.TypeA][q.Name] == "" && s.records[dns.TypeAAAA][q.Name] == "":
+		resp.Rcode = dns.RcodeNameError
+	case s.records[q.Qtype][q.Name] != "":
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", q.Name, ttl, dns.TypeToString[q.Qtype], s.records[q.Qtype][q.Name]))
+		if err == nil {
+			resp.Answer = append(resp.Answer, rr)
+		}
+	}
+	_ = w.WriteMsg(resp)
+}
+
+// setTestFiles replaces /etc/resolv.conf and /etc/hosts for the test.
+func setTestFiles(t *testing.T, resolvConf, hosts string) {
+	dir := t.TempDir()
+	oldResolvConf, oldHosts := resolvConfPath, hostsPath
+	resolvConfPath, hostsPath = filepath.Join(dir, "resolv.conf"), filepath.Join(dir, "hosts")
+	t.Cleanup(func() { resolvConfPath, hostsPath = oldResolvConf, oldHosts })
+	require.NoError(t, os.WriteFile(resolvConfPath, []byte(resolvConf), 0o644))
+	require.NoError(t, os.WriteFile(hostsPath, []byte(hosts), 0o644))
+}
+
+func newTestResolver(t *testing.T, server *testServer, opts ...func(*Config)) *Resolver {
+	if resolvConfPath == "/etc/resolv.conf" {
+		setTestFiles(t, "", "")
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	dnsServer := &dns.Server{PacketConn: conn, Handler: server}
+	go func() { _ = dnsServer.ActivateAndServe() }()
+	t.Cleanup(func() { _ = dnsServer.Shutdown() })
+
+	var config Config
+	config.SetDefaults()
+	config.Server = conn.LocalAddr().String()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	r, err := NewResolver(config)
+	require.NoError(t, err)
+	return r
+}
+
+var records = testRecords{
+	dns.TypeA: {
+		"dual.test.": "192.0.2.1",
+		"v4.test.":   "192.0.2.2",
+	},
+	dns.TypeAAAA: {
+		"dual.test.": "2001:db8::1",
+	},
+}
+
+func TestLookupIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		preference IPPreference
+		failing    map[uint16]bool
+		delays     map[uint16]time.Duration
+		want       []string
+		wantErr    error
+	}{
+		{name: "ipv4 preferred", host: "dual.test", preference: PreferIPv4, want: []string{"192.0.2.1", "2001:db8::1"},
+			delays: map[uint16]time.Duration{dns.TypeA: 20 * time.Millisecond}},
+		{name: "ipv6 preferred", host: "DUAL.test.", preference: PreferIPv6, want: []string{"2001:db8::1", "192.0.2.1"}},
+		{name: "any, AAAA answered first", host: "dual.test", want: []string{"2001:db8::1", "192.0.2.1"},
+			delays: map[uint16]time.Duration{dns.TypeA: 20 * time.Millisecond}},
+		{name: "any, A answered first", host: "dual.test", want: []string{"192.0.2.1", "2001:db8::1"},
+			delays: map[uint16]time.Duration{dns.TypeAAAA: 20 * time.Millisecond}},
+		{name: "single family", host: "v4.test", want: []string{"192.0.2.2"}},
+		{name: "failed AAAA", host: "dual.test", failing: map[uint16]bool{dns.TypeAAAA: true}, want: []string{"192.0.2.1"}},
+		{name: "failed A", host: "dual.test", failing: map[uint16]bool{dns.TypeA: true}, want: []string{"2001:db8::1"}},
+		{name: "not found", host: "missing.test", wantErr: utils.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResolver(t, &testServer{records: records, failing: tt.failing, delays: tt.delays}, func(c *Config) {
+				if len(tt.preference) != 0 {
+					c.IPPreference = tt.preference
+				}
+			})
+			ips, err := r.LookupIP(context.Background(), tt.host)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, ip := range ips {
+				got = append(got, ip.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLookupIPFailsIfBothFamiliesFail(t *testing.T) {
+	r := newTestResolver(t, &testServer{records: records, failing: map[uint16]bool{dns.TypeA: true, dns.TypeAAAA: true}})
+	_, err := r.LookupIP(context.Background(), "dual.test")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestLookupIPCache(t *testing.T) {
+	server := &testServer{records: records}
+	r := newTestResolver(t, server)
+	ctx := context.Background()
+
+	for _, host := range []string{"dual.test", "missing.test"} {
+		_, _ = r.LookupIP(ctx, host)
+		queries := server.queries.Load()
+		_, _ = r.LookupIP(ctx, host)
+		assert.Equal(t, queries, server.queries.Load(), host)
+	}
+}
+
+func TestLookupIPIsNotBoundToCallerContext(t *testing.T) {
+	server := &testServer{records: records, delay: 100 * time.Millisecond}
+	r := newTestResolver(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		_, err := r.LookupIP(ctx, "dual.test")
+		done <- err
+	}()
+	time.Sleep(5 * time.Millisecond) // the first caller starts the lookup
+	ips, err := r.LookupIP(context.Background(), "dual.test")
+	require.NoError(t, err)
+	assert.Len(t, ips, 2)
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+}
+
+func TestLookupIPTimeout(t *testing.T) {
+	r := newTestResolver(t, &testServer{records: records, delay: time.Second}, func(c *Config) {
+		c.Timeout = 50 * time.Millisecond
+	})
+	start := time.Now()
+	_, err := r.LookupIP(context.Background(), "dual.test")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestLookupIPCacheTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		ttls    map[uint16]uint32
+		failing map[uint16]bool
+		want    time.Duration
+	}{
+		{name: "min TTL of the answers", host: "dual.test", ttls: map[uint16]uint32{dns.TypeA: 300, dns.TypeAAAA: 120}, want: 2 * time.Minute},
+		{name: "clamped to the min", host: "dual.test", ttls: map[uint16]uint32{dns.TypeA: 300, dns.TypeAAAA: 1}, want: 30 * time.Second},
+		{name: "clamped to the max", host: "dual.test", ttls: map[uint16]uint32{dns.TypeA: 86400, dns.TypeAAAA: 86400}, want: time.Hour},
+		{name: "an empty answer has no TTL", host: "v4.test", ttls: map[uint16]uint32{dns.TypeA: 300, dns.TypeAAAA: 1}, want: 5 * time.Minute},
+		{name: "a failed answer has no TTL", host: "dual.test", ttls: map[uint16]uint32{dns.TypeA: 300, dns.TypeAAAA: 1},
+			failing: map[uint16]bool{dns.TypeAAAA: true}, want: 5 * time.Minute},
+		{name: "not found", host: "missing.test", want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResolver(t, &testServer{records: records, ttls: tt.ttls, failing: tt.failing})
+			_, _ = r.LookupIP(context.Background(), tt.host)
+			item := r.cache.Get(tt.host)
+			require.NotNil(t, item)
+			assert.Equal(t, tt.want, item.TTL())
+		})
+	}
+}
+
+func TestLookupIPCacheExpiry(t *testing.T) {
+	server := &testServer{records: records, ttls: map[uint16]uint32{dns.TypeA: 1, dns.TypeAAAA: 1}}
+	r := newTestResolver(t, server, func(c *Config) {
+		c.CacheMinTTL = 0
+	})
+	ctx := context.Background()
+
+	_, err := r.LookupIP(ctx, "dual.test")
+	require.NoError(t, err)
+	queries := server.queries.Load()
+	_, err = r.LookupIP(ctx, "dual.test")
+	require.NoError(t, err)
+	assert.Equal(t, queries, server.queries.Load(), "cached")
+
+	time.Sleep(1100 * time.Millisecond)
+	_, err = r.LookupIP(ctx, "dual.test")
+	require.NoError(t, err)
+	assert.Greater(t, server.queries.Load(), queries, "the record TTL has expired")
+}
+
+func TestLookupIPHostsAndSearch(t *testing.T) {
+	setTestFiles(t, "search corp.test\noptions ndots:1\n", `
+# comment
+127.0.0.1 localhost
+::1       localhost ip6-localhost
+192.0.2.9 hosts.test # the upstream isn't asked
+`)
+	server := &testServer{records: testRecords{
+		dns.TypeA: {"api.corp.test.": "192.0.2.10", "db.test.": "192.0.2.11"},
+	}}
+	r := newTestResolver(t, server, func(c *Config) {
+		c.IPPreference = PreferIPv6
+	})
+	lookup := func(host string) []string {
+		ips, err := r.LookupIP(context.Background(), host)
+		require.NoError(t, err, host)
+		var res []string
+		for _, ip := range ips {
+			res = append(res, ip.String())
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"::1", "127.0.0.1"}, lookup("localhost"))
+	assert.Equal(t, []string{"192.0.2.9"}, lookup("HOSTS.test"))
+	assert.Zero(t, server.queries.Load(), "/etc/hosts goes first")
+	assert.Equal(t, []string{"192.0.2.10"}, lookup("api"), "the search domain")
+	assert.Equal(t, []string{"192.0.2.11"}, lookup("db.test"), "the name itself if the search domain has no addresses")
+	_, err := r.LookupIP(context.Background(), "api.")
+	assert.ErrorIs(t, err, utils.ErrNotFound, "the search domains aren't applied to fully qualified names")
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/microservice/middleware"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/bldsoft/gost/log"
+)
+
+type GeoIPOverrideRepository interface {
+	Overrides(ctx context.Context, dbType DBType) ([]*entity.GeoIPOverride, error)
+	Override(ctx context.Context, dbType DBType, id string) (*entity.GeoIPOverride, error)
+	AddOverride(ctx context.Context, dbType DBType, override entity.GeoIPOverride) (*entity.GeoIPOverride, error)
+	ReplaceOverride(ctx context.Context, dbType DBType, id string, override entity.GeoIPOverride) (*entity.GeoIPOverride, error)
+	DeleteOverride(ctx context.Context, dbType DBType, id string) error
+	Expiring(ctx context.Context, dbType DBType, until time.Time) ([]*entity.ExpiringGeoIPRecord, error)
+	AnalyzePatches(ctx context.Context, dbType DBType, limit int) (*entity.PatchAnalysis, error)
+}
+
+// GeoIPOverrideService manages the network records set at runtime, they are applied immediately
+type GeoIPOverrideService struct {
+	rep GeoIPOverrideRepository
+}
+
+func NewGeoIPOverrideService(rep GeoIPOverrideRepository) *GeoIPOverrideService {
+	return &GeoIPOverrideService{rep: rep}
+}
+
+func (s *GeoIPOverrideService) Overrides(ctx context.Context, dbType DBType) ([]*entity.GeoIPOverride, error) {
+	return s.rep.Overrides(ctx, dbType)
+}
+
+func (s *GeoIPOverrideService) Override(ctx context.Context, dbType DBType, id string) (*entity.GeoIPOverride, error) {
+	return s.rep.Override(ctx, dbType, id)
+}
+
+func (s *GeoIPOverrideService) AddOverride(ctx context.Context, dbType DBType, override entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	if err := s.prepare(ctx, &override); err != nil {
+		return nil, err
+	}
+	res, err := s.rep.AddOverride(ctx, dbType, override)
+	if err != nil {
+		return nil, err
+	}
+	s.log(ctx, dbType, res, "GeoIP override added")
+	return res, nil
+}
+
+func (s *GeoIPOverrideService) ReplaceOverride(ctx context.Context, dbType DBType, id string, override entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	if err := s.prepare(ctx, &override); err != nil {
+		return nil, err
+	}
+	res, err := s.rep.ReplaceOverride(ctx, dbType, id, override)
+	if err != nil {
+		return nil, err
+	}
+	s.log(ctx, dbType, res, "GeoIP override replaced")
+	return res, nil
+}
+
+func (s *GeoIPOverrideService) DeleteOverride(ctx context.Context, dbType DBType, id string) error {
+	override, err := s.rep.Override(ctx, dbType, id)
+	if err != nil {
+		return err
+	}
+	if err := s.rep.DeleteOverride(ctx, dbType, id); err != nil {
+		return err
+	}
+	s.log(ctx, dbType, override, "GeoIP override deleted")
+	return nil
+}
+
+// Expiring returns the patch records and the overrides that expire within the period
+func (s *GeoIPOverrideService) Expiring(ctx context.Context, dbType DBType, within time.Duration) ([]*entity.ExpiringGeoIPRecord, error) {
+	if within < 0 {
+		return nil, fmt.Errorf("negative period: %w", utils.ErrInvalidArgument)
+	}
+	return s.rep.Expiring(ctx, dbType, time.Now().Add(within))
+}
+
+// AnalyzePatches reports the overlapping networks of the patches and the overrides, and the ones that don't change the db
+func (s *GeoIPOverrideService) AnalyzePatches(ctx context.Context, dbType DBType, limit int) (*entity.PatchAnalysis, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("negative limit: %w", utils.ErrInvalidArgument)
+	}
+	return s.rep.AnalyzePatches(ctx, dbType, limit)
+}
+
+// prepare sets the author to the API key name if it's missing and validates the override
+func (s *GeoIPOverrideService) prepare(ctx context.Context, override *entity.GeoIPOverride) error {
+	if len(override.Author) == 0 {
+		if key := middleware.GetApiKey(ctx); key != nil {
+			override.Author = key.Name
+			if len(override.Author) == 0 {
+				override.Author = key.ID
+			}
+		}
+	}
+	return override.Validate()
+}
+
+func (s *GeoIPOverrideService) log(ctx context.Context, dbType DBType, override *entity.GeoIPOverride, msg string) {
+	log.FromContext(ctx).InfoWithFields(log.Fields{
+		"db":      dbType,
+		"id":      override.ID,
+		"network": override.Network,
+		"author":  override.Author,
+		"reason":  override.Reason,
+	}, msg)
+}
@@ -2,11 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/microservice/middleware"
 	"github.com/bldsoft/geos/pkg/repository"
+	"github.com/bldsoft/geos/pkg/utils"
 )
 
 type DumpFormat = repository.DumpFormat
@@ -24,15 +27,35 @@ type GeoRepository interface {
 	CheckUpdates(ctx context.Context, dbType DBType) (entity.DBUpdate[entity.PatchedMMDBVersion], error)
 }
 
+type HostResolver interface {
+	LookupIP(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// GeoNameFinder finds the GeoNames entities by ID, it's used to enrich the cities
+type GeoNameFinder interface {
+	GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error)
+}
+
 type GeoIpService struct {
-	rep GeoRepository
+	rep      GeoRepository
+	resolver HostResolver
+	geoNames GeoNameFinder
 }
 
-func NewGeoIpService(rep GeoRepository) *GeoIpService {
-	return &GeoIpService{rep: rep}
+func NewGeoIpService(rep GeoRepository, resolver HostResolver, geoNames GeoNameFinder) *GeoIpService {
+	return &GeoIpService{rep: rep, resolver: resolver, geoNames: geoNames}
 }
 
 func (s *GeoIpService) ip(ctx context.Context, address string) (net.IP, error) {
+	ips, err := s.ips(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return ips[0], nil
+}
+
+// ips returns all addresses the hostname is resolved to, the preferred one goes first
+func (s *GeoIpService) ips(ctx context.Context, address string) ([]net.IP, error) {
 	if address == "me" {
 		address = middleware.GetRealIP(ctx)
 	}
@@ -43,13 +66,40 @@ func (s *GeoIpService) ip(ctx context.Context, address string) (net.IP, error) {
 	}
 
 	if ip := net.ParseIP(address); ip != nil {
-		return ip, nil
+		return []net.IP{ip}, nil
 	}
-	ips, err := net.LookupIP(address)
+	return s.resolver.LookupIP(ctx, address)
+}
+
+// lookupAll looks up each of the addresses the hostname is resolved to. The result is keyed by IP.
+func lookupAll[T any](ctx context.Context, s *GeoIpService, address string, lookup func(ctx context.Context, ip net.IP) (*T, error)) (map[string]*T, error) {
+	ips, err := s.ips(ctx, address)
 	if err != nil {
 		return nil, err
 	}
-	return ips[0], nil
+	res := make(map[string]*T, len(ips))
+	for _, ip := range ips {
+		if res[ip.String()], err = lookup(ctx, ip); err != nil {
+			return nil, fmt.Errorf("%s: %w", ip, err)
+		}
+	}
+	return res, nil
+}
+
+// findGeoName returns the entity of the first type with the ID, nil if there is no such entity. The type of
+// the ID matters for the custom IDs from the patches: they are the same on every level.
+func findGeoName(ctx context.Context, geoNames GeoNameFinder, geoNameID uint, types ...entity.GeoNameType) (*entity.GeoNameItem, error) {
+	if geoNameID == 0 {
+		return nil, nil
+	}
+	for _, typ := range types {
+		item, err := geoNames.GeoName(ctx, uint32(geoNameID), entity.GeoNameHierarchyFilter{Type: typ})
+		if errors.Is(err, utils.ErrNotFound) {
+			continue
+		}
+		return item, err
+	}
+	return nil, nil
 }
 
 func (s *GeoIpService) Country(ctx context.Context, address string) (*entity.Country, error) {
@@ -60,12 +110,82 @@ func (s *GeoIpService) Country(ctx context.Context, address string) (*entity.Cou
 	return s.rep.Country(ctx, ip)
 }
 
-func (s *GeoIpService) City(ctx context.Context, address string, includeISP bool) (*entity.City, error) {
+func (s *GeoIpService) City(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (*entity.City, error) {
+	if err := validateEnrichments(enrich); err != nil {
+		return nil, err
+	}
 	ip, err := s.ip(ctx, address)
 	if err != nil {
 		return nil, err
 	}
-	return s.rep.City(ctx, ip, includeISP)
+	return s.city(ctx, ip, includeISP, enrich)
+}
+
+func validateEnrichments(enrich []entity.CityEnrichment) error {
+	for _, e := range enrich {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// city looks up the city and enriches it. Unlike ISP, the requested enrichment isn't optional: the lookup
+// fails if the city can't be enriched.
+func (s *GeoIpService) city(ctx context.Context, ip net.IP, includeISP bool, enrich []entity.CityEnrichment) (*entity.City, error) {
+	city, err := s.rep.City(ctx, ip, includeISP)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range enrich {
+		switch e {
+		case entity.CityEnrichmentGeoNames:
+			if city.GeoNames, err = s.cityGeoNames(ctx, city); err != nil {
+				return nil, fmt.Errorf("failed to enrich the city with GeoNames: %w", err)
+			}
+		}
+	}
+	return city, nil
+}
+
+// cityGeoNames joins the city with GeoNames by the GeoNameIDs
+func (s *GeoIpService) cityGeoNames(ctx context.Context, city *entity.City) (*entity.CityGeoNames, error) {
+	find := func(geoNameID uint, types ...entity.GeoNameType) (*entity.GeoNameItem, error) {
+		return findGeoName(ctx, s.geoNames, geoNameID, types...)
+	}
+
+	res := &entity.CityGeoNames{}
+	continent, err := find(city.Continent.GeoNameID, entity.GeoNameTypeContinent)
+	if err != nil {
+		return nil, err
+	}
+	if continent != nil {
+		res.Continent = continent.Continent
+	}
+	country, err := find(city.Country.GeoNameID, entity.GeoNameTypeCountry)
+	if err != nil {
+		return nil, err
+	}
+	if country != nil {
+		res.Country = country.Country
+	}
+	for _, subdiv := range city.Subdivisions {
+		item, err := find(subdiv.GeoNameID, entity.GeoNameTypeSubdivision, entity.GeoNameTypeSubdivision2)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			res.Subdivisions = append(res.Subdivisions, item)
+		}
+	}
+	cityItem, err := find(city.City.GeoNameID, entity.GeoNameTypeCity)
+	if err != nil {
+		return nil, err
+	}
+	if cityItem != nil {
+		res.City = cityItem.City
+	}
+	return res, nil
 }
 
 func (s *GeoIpService) CityLite(ctx context.Context, address string, lang string) (*entity.CityLite, error) {
@@ -89,6 +209,32 @@ func (s *GeoIpService) Hosting(ctx context.Context, address string) (*entity.Hos
 	return s.rep.Hosting(ctx, ip)
 }
 
+func (s *GeoIpService) CountryAll(ctx context.Context, address string) (map[string]*entity.Country, error) {
+	return lookupAll(ctx, s, address, s.rep.Country)
+}
+
+func (s *GeoIpService) CityAll(ctx context.Context, address string, includeISP bool, enrich ...entity.CityEnrichment) (map[string]*entity.City, error) {
+	if err := validateEnrichments(enrich); err != nil {
+		return nil, err
+	}
+	return lookupAll(ctx, s, address, func(ctx context.Context, ip net.IP) (*entity.City, error) {
+		return s.city(ctx, ip, includeISP, enrich)
+	})
+}
+
+func (s *GeoIpService) CityLiteAll(ctx context.Context, address string, lang string) (map[string]*entity.CityLite, error) {
+	if len(lang) == 0 {
+		lang = "en"
+	}
+	return lookupAll(ctx, s, address, func(ctx context.Context, ip net.IP) (*entity.CityLite, error) {
+		return s.rep.CityLite(ctx, ip, lang)
+	})
+}
+
+func (s *GeoIpService) HostingAll(ctx context.Context, address string) (map[string]*entity.Hosting, error) {
+	return lookupAll(ctx, s, address, s.rep.Hosting)
+}
+
 func (r *GeoIpService) MetaData(ctx context.Context, dbType DBType) (*entity.MetaData, error) {
 	return r.rep.MetaData(ctx, dbType)
 }
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testGeoRepository struct {
+	GeoRepository
+}
+
+func (r testGeoRepository) City(ctx context.Context, ip net.IP, includeISP bool) (*entity.City, error) {
+	var city entity.City
+	city.Country.GeoNameID = 630336
+	city.City.GeoNameID = 625144
+	return &city, nil
+}
+
+type testResolver []net.IP
+
+func (r testResolver) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	return r, nil
+}
+
+// testGeoNames finds the countries and the cities, the other types aren't found
+type testGeoNames struct {
+	err error
+}
+
+func (f testGeoNames) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	switch filter.Type {
+	case entity.GeoNameTypeCountry:
+		return &entity.GeoNameItem{Country: &entity.GeoNameCountry{}}, nil
+	case entity.GeoNameTypeCity:
+		return &entity.GeoNameItem{City: &entity.GeoName{}}, nil
+	}
+	return nil, utils.ErrNotFound
+}
+
+func TestCityEnrichment(t *testing.T) {
+	ctx := context.Background()
+	resolver := testResolver{net.ParseIP("192.0.2.1"), net.ParseIP("192.0.2.2")}
+	s := NewGeoIpService(testGeoRepository{}, resolver, testGeoNames{})
+
+	city, err := s.City(ctx, "192.0.2.1", false)
+	require.NoError(t, err)
+	assert.Nil(t, city.GeoNames, "not requested")
+
+	city, err = s.City(ctx, "192.0.2.1", false, entity.CityEnrichmentGeoNames)
+	require.NoError(t, err)
+	require.NotNil(t, city.GeoNames)
+	assert.NotNil(t, city.GeoNames.Country)
+	assert.NotNil(t, city.GeoNames.City)
+	assert.Nil(t, city.GeoNames.Continent, "no GeoNameID")
+
+	cities, err := s.CityAll(ctx, "example.test", false, entity.CityEnrichmentGeoNames)
+	require.NoError(t, err)
+	require.Len(t, cities, 2)
+	for ip, city := range cities {
+		assert.NotNil(t, city.GeoNames, ip)
+	}
+
+	_, err = s.City(ctx, "192.0.2.1", false, "unknown")
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+	_, err = s.CityAll(ctx, "example.test", false, "unknown")
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+}
+
+func TestCityEnrichmentError(t *testing.T) {
+	ctx := context.Background()
+	s := NewGeoIpService(testGeoRepository{}, testResolver{net.ParseIP("192.0.2.1")}, testGeoNames{err: utils.ErrNotReady})
+
+	_, err := s.City(ctx, "192.0.2.1", false, entity.CityEnrichmentGeoNames)
+	assert.ErrorIs(t, err, utils.ErrNotReady, "the requested enrichment isn't silently dropped")
+	_, err = s.CityAll(ctx, "example.test", false, entity.CityEnrichmentGeoNames)
+	assert.ErrorIs(t, err, utils.ErrNotReady)
+
+	city, err := s.City(ctx, "192.0.2.1", false)
+	require.NoError(t, err, "not requested")
+	assert.Nil(t, city.GeoNames)
+}
@@ -7,10 +7,16 @@ import (
 )
 
 type GeoNameRepository interface {
-	Continents(ctx context.Context) []*entity.GeoNameContinent
-	Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error)
-	Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error)
-	Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error)
+	Continents(ctx context.Context, lang string) []*entity.GeoNameContinent
+	Countries(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error)
+	Subdivisions(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error)
+	Subdivisions2(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error)
+	Cities(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error)
+	PostalCodes(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error)
+	Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error)
+	GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error)
+	Children(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
+	Ancestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error)
 	Dump(ctx context.Context, format DumpFormat) ([]byte, error)
 
 	StartUpdate(ctx context.Context) error
@@ -33,22 +39,46 @@ func (s *GeoNameService) StartUpdate(ctx context.Context) error {
 	return s.GeoNameRepository.StartUpdate(ctx)
 }
 
-func (s *GeoNameService) Continents(ctx context.Context) []*entity.GeoNameContinent {
-	return s.GeoNameRepository.Continents(ctx)
+func (s *GeoNameService) Continents(ctx context.Context, lang string) []*entity.GeoNameContinent {
+	return s.GeoNameRepository.Continents(ctx, lang)
 }
 
-func (s *GeoNameService) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+func (s *GeoNameService) Countries(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
 	return s.GeoNameRepository.Countries(ctx, filter)
 }
 
-func (s *GeoNameService) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+func (s *GeoNameService) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
 	return s.GeoNameRepository.Subdivisions(ctx, filter)
 }
 
-func (s *GeoNameService) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+func (s *GeoNameService) Subdivisions2(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return s.GeoNameRepository.Subdivisions2(ctx, filter)
+}
+
+func (s *GeoNameService) Cities(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
 	return s.GeoNameRepository.Cities(ctx, filter)
 }
 
+func (s *GeoNameService) PostalCodes(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return s.GeoNameRepository.PostalCodes(ctx, filter)
+}
+
+func (s *GeoNameService) Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	return s.GeoNameRepository.Nearest(ctx, filter)
+}
+
+func (s *GeoNameService) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	return s.GeoNameRepository.GeoName(ctx, geoNameID, filter)
+}
+
+func (s *GeoNameService) Children(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return s.GeoNameRepository.Children(ctx, geoNameID, filter)
+}
+
+func (s *GeoNameService) Ancestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	return s.GeoNameRepository.Ancestors(ctx, geoNameID, filter)
+}
+
 func (s *GeoNameService) Dump(ctx context.Context, format DumpFormat) ([]byte, error) {
 	return s.GeoNameRepository.Dump(ctx, format)
 }
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/gost/log"
+	"golang.org/x/sync/singleflight"
+)
+
+// maxValidationIssues limits the report, the summary counts all the issues
+const maxValidationIssues = 1000
+
+type CityNetworkWalker interface {
+	WalkCityNetworks(ctx context.Context, f func(network *net.IPNet, city *entity.City) error) error
+}
+
+type GeoNameValidator interface {
+	GeoNameFinder
+	CustomIDCollisions(ctx context.Context) ([]*entity.GeoNameIDCollision, error)
+}
+
+// ValidationService checks that the GeoIP city db (with the patches) is consistent with GeoNames (with the patches)
+type ValidationService struct {
+	geoIP      CityNetworkWalker
+	geoNames   GeoNameValidator
+	validateSF singleflight.Group
+}
+
+func NewValidationService(geoIP CityNetworkWalker, geoNames GeoNameValidator) *ValidationService {
+	return &ValidationService{geoIP: geoIP, geoNames: geoNames}
+}
+
+// Validate walks the city db networks, it takes a while. Concurrent calls share the result.
+func (s *ValidationService) Validate(ctx context.Context) (*entity.ValidationReport, error) {
+	res, err, _ := s.validateSF.Do("validate", func() (interface{}, error) {
+		return s.validate(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*entity.ValidationReport), nil
+}
+
+// ValidateInBackground validates and logs the report, it's called after the updates
+func (s *ValidationService) ValidateInBackground(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		report, err := s.Validate(ctx)
+		if err != nil {
+			log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to validate GeoIP and GeoNames consistency")
+			return
+		}
+		fields := log.Fields{"networks": report.Networks, "geonameIDs": report.GeoNameIDs, "duration": report.Duration}
+		for typ, n := range report.Summary {
+			fields[string(typ)] = n
+		}
+		if len(report.Issues) == 0 {
+			log.FromContext(ctx).InfoWithFields(fields, "GeoIP and GeoNames are consistent")
+			return
+		}
+		log.FromContext(ctx).WarnWithFields(fields, "GeoIP and GeoNames are inconsistent, see /dump/validate")
+	}()
+}
+
+type validationIssueKey struct {
+	typ        entity.ValidationIssueType
+	geoNameID  uint32
+	entityType entity.GeoNameType
+	message    string
+}
+
+type validation struct {
+	issues  map[validationIssueKey]*entity.ValidationIssue
+	checked map[validationIssueKey]*entity.GeoNameItem // by the ID and the entity type, nil if missing
+}
+
+func (v *validation) add(issue *entity.ValidationIssue, network *net.IPNet) {
+	key := validationIssueKey{issue.Type, issue.GeoNameID, issue.EntityType, issue.Message}
+	if existing, ok := v.issues[key]; ok {
+		existing.Networks++
+		return
+	}
+	if network != nil {
+		issue.Networks, issue.Network = 1, network.String()
+	}
+	v.issues[key] = issue
+}
+
+func (s *ValidationService) validate(ctx context.Context) (*entity.ValidationReport, error) {
+	report := &entity.ValidationReport{StartedAt: time.Now()}
+	v := &validation{
+		issues:  make(map[validationIssueKey]*entity.ValidationIssue),
+		checked: make(map[validationIssueKey]*entity.GeoNameItem),
+	}
+
+	err := s.geoIP.WalkCityNetworks(ctx, func(network *net.IPNet, city *entity.City) error {
+		report.Networks++
+		countryCode := city.Country.IsoCode
+		if err := s.check(ctx, v, network, city.Continent.GeoNameID, entity.GeoNameTypeContinent, ""); err != nil {
+			return err
+		}
+		if err := s.check(ctx, v, network, city.Country.GeoNameID, entity.GeoNameTypeCountry, countryCode); err != nil {
+			return err
+		}
+		for _, subdivision := range city.Subdivisions {
+			if err := s.check(ctx, v, network, subdivision.GeoNameID, entity.GeoNameTypeSubdivision, countryCode); err != nil {
+				return err
+			}
+		}
+		return s.check(ctx, v, network, city.City.GeoNameID, entity.GeoNameTypeCity, countryCode)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk the city db: %w", err)
+	}
+	report.GeoNameIDs = len(v.checked)
+
+	collisions, err := s.geoNames.CustomIDCollisions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the GeoNames patches: %w", err)
+	}
+	for _, collision := range collisions {
+		message := fmt.Sprintf("the patch %s %q uses the ID reserved for GeoNames (below %d)", collision.CustomType, collision.CustomName, entity.FirstCustomGeonameID)
+		if len(collision.OriginalType) > 0 {
+			message = fmt.Sprintf("the patch %s %q uses the ID of the GeoNames %s %q", collision.CustomType, collision.CustomName, collision.OriginalType, collision.OriginalName)
+		}
+		v.add(&entity.ValidationIssue{
+			Type:       entity.ValidationIssueCustomIDCollision,
+			GeoNameID:  collision.GeoNameID,
+			EntityType: collision.CustomType,
+			Message:    message,
+		}, nil)
+	}
+
+	report.Summary = make(map[entity.ValidationIssueType]int)
+	report.Issues = make([]*entity.ValidationIssue, 0, len(v.issues))
+	for _, issue := range v.issues {
+		report.Summary[issue.Type]++
+		report.Issues = append(report.Issues, issue)
+	}
+	slices.SortFunc(report.Issues, func(a, b *entity.ValidationIssue) int {
+		return cmp.Or(
+			strings.Compare(string(a.Type), string(b.Type)),
+			cmp.Compare(b.Networks, a.Networks),
+			cmp.Compare(a.GeoNameID, b.GeoNameID),
+			strings.Compare(string(a.EntityType), string(b.EntityType)),
+		)
+	})
+	if len(report.Issues) > maxValidationIssues {
+		report.Issues, report.Truncated = report.Issues[:maxValidationIssues], true
+	}
+	report.Duration = time.Since(report.StartedAt).Round(time.Millisecond).String()
+	return report, nil
+}
+
+// check looks up the entity of the city db in GeoNames and compares the country.
+// The subdivisions of the city db are either subdivisions or subdivisions2 in GeoNames.
+func (s *ValidationService) check(
+	ctx context.Context,
+	v *validation,
+	network *net.IPNet,
+	geoNameID uint,
+	typ entity.GeoNameType,
+	countryCode string,
+) error {
+	if geoNameID == 0 {
+		return nil
+	}
+	key := validationIssueKey{geoNameID: uint32(geoNameID), entityType: typ}
+	item, ok := v.checked[key]
+	if !ok {
+		types := []entity.GeoNameType{typ}
+		if typ == entity.GeoNameTypeSubdivision {
+			types = append(types, entity.GeoNameTypeSubdivision2)
+		}
+		var err error
+		if item, err = findGeoName(ctx, s.geoNames, geoNameID, types...); err != nil {
+			return err
+		}
+		v.checked[key] = item
+	}
+
+	if item == nil {
+		v.add(&entity.ValidationIssue{
+			Type:       entity.ValidationIssueDanglingGeoNameID,
+			GeoNameID:  uint32(geoNameID),
+			EntityType: typ,
+			Message:    fmt.Sprintf("the %s isn't in GeoNames", typ),
+		}, network)
+		return nil
+	}
+
+	geoNamesCountryCode := item.Entity().GetCountryCode()
+	if len(countryCode) == 0 || len(geoNamesCountryCode) == 0 || strings.EqualFold(geoNamesCountryCode, countryCode) {
+		return nil
+	}
+	v.add(&entity.ValidationIssue{
+		Type:       entity.ValidationIssueCountryMismatch,
+		GeoNameID:  uint32(geoNameID),
+		EntityType: item.Type,
+		Message:    fmt.Sprintf("the %s %q is in %s in GeoNames, but in %s in the city db", item.Type, item.Entity().GetName(), geoNamesCountryCode, countryCode),
+	}, network)
+	return nil
+}
@@ -0,0 +1,113 @@
+package geonames
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/mkrou/geonames"
+	"github.com/mkrou/geonames/models"
+)
+
+// city is the value of a loaded city. The cities are allocated in a single slice instead of two objects per city.
+type city struct {
+	entity.GeoName
+	model models.Geoname
+}
+
+// loadCities reads the city dumps of the source. A city may be in several dumps (cities500 contains cities1000),
+// it's loaded once: the first dump wins. The alternate names are dropped unless alternateNames is set.
+func loadCities(ctx context.Context, files []source.GeoNamesCityFile, featureClasses []string, alternateNames bool) ([]*entity.GeoName, error) {
+	var values []city
+	ids := make(map[int]struct{})
+	strs := make(stringPool)
+	for _, file := range files {
+		parser := geonames.Parser(func(filename string) (io.ReadCloser, error) {
+			return file.Reader(ctx)
+		})
+		err := parser.GetGeonames(file.Dump, func(c *models.Geoname) error {
+			if len(featureClasses) > 0 && !slices.Contains(featureClasses, c.Class) {
+				return nil
+			}
+			if _, ok := ids[c.Id]; ok {
+				return nil
+			}
+			ids[c.Id] = struct{}{}
+			values = append(values, city{model: compactGeoname(c, strs, alternateNames)})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file.LocalPath), err)
+		}
+	}
+
+	values = slices.Clip(values)
+	cities := make([]*entity.GeoName, len(values))
+	for i := range values {
+		values[i].Geoname = &values[i].model
+		cities[i] = &values[i].GeoName
+	}
+	return cities, nil
+}
+
+// compactGeoname makes the city independent of the parser buffers. The fields of a csv record share
+// the memory of the whole line, so a single field would keep the line alive. The codes are repeated
+// across the cities and are shared through the pool, that matters for allCountries with millions of records.
+// The alternate names are the most of the line, they're only kept for the name search.
+func compactGeoname(c *models.Geoname, strs stringPool, alternateNames bool) models.Geoname {
+	res := *c
+	if !alternateNames {
+		res.AlternateNames = ""
+	}
+	if res.AsciiName == res.Name {
+		cloneTogether(&res.Name, &res.AlternateNames)
+		res.AsciiName = res.Name
+	} else {
+		cloneTogether(&res.Name, &res.AsciiName, &res.AlternateNames)
+	}
+	res.Class = strs.get(c.Class)
+	res.Code = strs.get(c.Code)
+	res.CountryCode = strs.get(c.CountryCode)
+	res.AlternateCountryCodes = strs.get(c.AlternateCountryCodes)
+	res.Admin1Code = strs.get(c.Admin1Code)
+	res.Admin2Code = strs.get(c.Admin2Code)
+	res.Admin3Code = strs.get(c.Admin3Code)
+	res.Admin4Code = strs.get(c.Admin4Code)
+	res.Timezone = strs.get(c.Timezone)
+	return res
+}
+
+// cloneTogether copies the strings to a single allocation
+func cloneTogether(strs ...*string) {
+	var b strings.Builder
+	for _, s := range strs {
+		b.Grow(len(*s))
+	}
+	for _, s := range strs {
+		b.WriteString(*s)
+	}
+	all := b.String()
+	for _, s := range strs {
+		*s, all = all[:len(*s)], all[len(*s):]
+	}
+}
+
+// stringPool deduplicates the strings
+type stringPool map[string]string
+
+func (p stringPool) get(s string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	if res, ok := p[s]; ok {
+		return res
+	}
+	s = strings.Clone(s)
+	p[s] = s
+	return s
+}
@@ -0,0 +1,134 @@
+package geonames
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/mkrou/geonames"
+	"github.com/mkrou/geonames/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCitiesDump writes a cities500.zip of n cities with the field sizes of the real dump:
+// short names and ~10 alternate names per city
+func writeCitiesDump(t testing.TB, n int) source.GeoNamesCityFile {
+	path := filepath.Join(t.TempDir(), string(geonames.Cities500))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(models.DumpFile(geonames.Cities500).TextFilename())
+	require.NoError(t, err)
+
+	rnd := rand.New(rand.NewSource(1))
+	word := func() string {
+		b := make([]byte, 6+rnd.Intn(8))
+		for i := range b {
+			b[i] = byte('a' + rnd.Intn(26))
+		}
+		return string(b)
+	}
+	for i := 1; i <= n; i++ {
+		name := word()
+		altNames := make([]string, 10)
+		for j := range altNames {
+			altNames[j] = word()
+		}
+		_, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%.5f\t%.5f\tP\tPPL\tBY\t\t0%d\t\t\t\t%d\t\t200\tEurope/Minsk\t2024-01-01\n",
+			i, name, name, strings.Join(altNames, ","), rnd.Float64()*180-90, rnd.Float64()*360-180, rnd.Intn(7), rnd.Intn(100000))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return source.GeoNamesCityFile{Dump: geonames.Cities500, UpdatableFile: source.NewTSUpdatableFile(path, "")}
+}
+
+func TestLoadCities(t *testing.T) {
+	file := writeCitiesDump(t, 100)
+	ctx := context.Background()
+
+	cities, err := loadCities(ctx, []source.GeoNamesCityFile{file, file}, []string{"P"}, true)
+	require.NoError(t, err)
+	require.Len(t, cities, 100, "the cities of several dumps are merged by ID")
+	assert.Equal(t, 1, cities[0].GetGeoNameID())
+	assert.NotEmpty(t, cities[0].AlternateNames)
+	assert.Len(t, cities[0].GetAlternateNames(), 11)
+	assert.Same(t, unsafe.StringData(cities[0].Timezone), unsafe.StringData(cities[1].Timezone), "the codes are shared")
+
+	cities, err = loadCities(ctx, []source.GeoNamesCityFile{file}, []string{"P"}, false)
+	require.NoError(t, err)
+	require.Len(t, cities, 100)
+	assert.Empty(t, cities[0].AlternateNames)
+	assert.Equal(t, []string{cities[0].AsciiName}, cities[0].GetAlternateNames())
+
+	cities, err = loadCities(ctx, []source.GeoNamesCityFile{file}, []string{"A"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, cities, "filtered by the feature class")
+}
+
+// BenchmarkLoadCities reports the heap retained by the loaded cities. "parser records" keeps the records
+// as they're parsed, like the cities were loaded before they were compacted.
+func BenchmarkLoadCities(b *testing.B) {
+	const n = 50_000
+	file := writeCitiesDump(b, n)
+	ctx := context.Background()
+
+	parserRecords := func() (any, error) {
+		var cities []*entity.GeoName
+		parser := geonames.Parser(func(filename string) (io.ReadCloser, error) {
+			return file.Reader(ctx)
+		})
+		err := parser.GetGeonames(file.Dump, func(c *models.Geoname) error {
+			cities = append(cities, &entity.GeoName{Geoname: c})
+			return nil
+		})
+		return cities, err
+	}
+	compact := func(alternateNames bool) func() (any, error) {
+		return func() (any, error) {
+			return loadCities(ctx, []source.GeoNamesCityFile{file}, nil, alternateNames)
+		}
+	}
+
+	for _, bb := range []struct {
+		name string
+		load func() (any, error)
+	}{
+		{"parser records", parserRecords},
+		{"compact", compact(true)},
+		{"compact without alternate names", compact(false)},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			var retained, objects uint64
+			for i := 0; i < b.N; i++ {
+				before := heapInUse()
+				cities, err := bb.load()
+				require.NoError(b, err)
+				after := heapInUse()
+				retained += after.HeapAlloc - before.HeapAlloc
+				objects += after.HeapObjects - before.HeapObjects
+				runtime.KeepAlive(cities)
+			}
+			b.ReportMetric(float64(retained)/float64(b.N)/n, "B/city")
+			b.ReportMetric(float64(objects)/float64(b.N)/n, "objects/city")
+		})
+	}
+}
+
+func heapInUse() runtime.MemStats {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats
+}
@@ -11,8 +11,14 @@ import (
 )
 
 type CustomStorage struct {
-	base       atomic.Pointer[MultiStorage]
-	source     *source.TSUpdatableFile
+	state  atomic.Pointer[customStorageState]
+	source *source.TSUpdatableFile
+}
+
+// customStorageState is replaced as a whole on each update
+type customStorageState struct {
+	base       *MultiStorage
+	links      *hierarchy
 	lastUpdate source.ModTimeVersion
 }
 
@@ -22,7 +28,10 @@ func NewCustomStorage(ctx context.Context, source *source.TSUpdatableFile) *Cust
 	res := &CustomStorage{
 		source: source,
 	}
-	res.base.Store(NewMultiStorage())
+	res.state.Store(&customStorageState{
+		base:  NewMultiStorage(),
+		links: newHierarchy(nil, nil, nil, nil, nil),
+	})
 
 	if err := res.update(ctx); err != nil {
 		log.FromContext(ctx).Errorf("failed to get patches: %v", err)
@@ -35,7 +44,7 @@ func (s *CustomStorage) CheckUpdates(ctx context.Context) (entity.Update[source.
 	if err != nil {
 		return entity.Update[source.ModTimeVersion]{}, err
 	}
-	update.CurrentVersion = s.lastUpdate
+	update.CurrentVersion = s.state.Load().lastUpdate
 	return update, nil
 }
 
@@ -51,7 +60,7 @@ func (s *CustomStorage) Update(ctx context.Context, force bool) error {
 		}
 	}
 
-	if update.RemoteVersion.Compare(s.lastUpdate) > 0 {
+	if update.RemoteVersion.Compare(s.state.Load().lastUpdate) > 0 {
 		if err := s.update(ctx); err != nil {
 			return err
 		}
@@ -80,7 +89,51 @@ func (s *CustomStorage) update(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	s.base.Store(NewMultiStorage(patches...))
-	s.lastUpdate = version
+	base := NewMultiStorage(patches...)
+	links, err := customHierarchy(ctx, base)
+	if err != nil {
+		return err
+	}
+	s.state.Store(&customStorageState{base: base, links: links, lastUpdate: version})
 	return nil
 }
+
+func customHierarchy(ctx context.Context, base *MultiStorage) (*hierarchy, error) {
+	countries, err := base.Countries(ctx, entity.GeoNameFilter{})
+	if err != nil {
+		return nil, err
+	}
+	subdivisions, err := base.Subdivisions(ctx, entity.GeoNameFilter{})
+	if err != nil {
+		return nil, err
+	}
+	cities, err := base.Cities(ctx, entity.GeoNameFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return newHierarchy(base.Continents(ctx), countries, subdivisions, nil, cities), nil
+}
+
+func (s *CustomStorage) Continents(ctx context.Context) []*entity.GeoNameContinent {
+	return s.state.Load().base.Continents(ctx)
+}
+
+func (s *CustomStorage) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+	return s.state.Load().base.Countries(ctx, filter)
+}
+
+func (s *CustomStorage) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+	return s.state.Load().base.Subdivisions(ctx, filter)
+}
+
+func (s *CustomStorage) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+	return s.state.Load().base.Cities(ctx, filter)
+}
+
+func (s *CustomStorage) hierarchy() (*hierarchy, error) {
+	return s.state.Load().links, nil
+}
+
+func (s *CustomStorage) dataVersion() source.ModTimeVersion {
+	return s.state.Load().lastUpdate
+}
@@ -28,41 +28,53 @@ type geonameIndex[T entity.GeoNameEntity] interface {
 type geonameEntityStorage[T entity.GeoNameEntity] struct {
 	collection []T
 	index      geonameIndex[T]
-
-	fillCollectionCallback func(parser geonames.Parser) ([]T, error)
 }
 
 func newGeonameEntityStorage[T entity.GeoNameEntity](ctx context.Context, file *source.TSUpdatableFile, fillCollectionCallback func(parser geonames.Parser) ([]T, error)) *geonameEntityStorage[T] {
-	s := &geonameEntityStorage[T]{
-		index:                  &index[T]{},
-		fillCollectionCallback: fillCollectionCallback,
-	}
-	s.init(ctx, file)
-	return s
-}
-
-func (s *geonameEntityStorage[T]) init(ctx context.Context, file *source.TSUpdatableFile) {
 	parser := geonames.Parser(func(filename string) (io.ReadCloser, error) {
 		return file.Reader(ctx)
 	})
+	return loadGeonameEntityStorage(ctx, func() ([]T, error) {
+		return fillCollectionCallback(parser)
+	})
+}
+
+// loadGeonameEntityStorage loads the collection, retrying until it succeeds
+func loadGeonameEntityStorage[T entity.GeoNameEntity](ctx context.Context, load func() ([]T, error)) *geonameEntityStorage[T] {
+	s := &geonameEntityStorage[T]{
+		index: &index[T]{},
+	}
 
 	ticker := time.NewTicker(initRetryInterval)
 	defer ticker.Stop()
 	for ; true; <-ticker.C {
 		var err error
-		s.collection, err = s.fillCollectionCallback(parser)
+		s.collection, err = load()
 		if err == nil {
 			s.index.Init(s.collection)
 			break
 		}
 		log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to load GeoNames dump")
 	}
+	return s
+}
+
+// disabledGeonameEntityStorage is used for the optional dumps that aren't loaded
+func disabledGeonameEntityStorage[T entity.GeoNameEntity]() *geonameEntityStorage[T] {
+	s := &geonameEntityStorage[T]{
+		index: &index[T]{},
+	}
+	s.index.Init(nil)
+	return s
 }
 
 func (s *geonameEntityStorage[T]) GetEntities(ctx context.Context, filter entity.GeoNameFilter) ([]T, error) {
 	if len(s.collection) == 0 {
 		return nil, ErrGeoNameDisabled
 	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
 	filtered := s.index.GetFiltered(filter)
 	if filter.Limit != 0 && len(filtered) > int(filter.Limit) {
 		return filtered[:filter.Limit], nil
@@ -0,0 +1,143 @@
+package geonames
+
+import (
+	"github.com/bldsoft/geos/pkg/entity"
+)
+
+// hierarchy links the entities by their codes: continent -> country -> subdivision -> subdivision2 -> city.
+// The subdivision codes are "<country code>.<admin1 code>" and "<country code>.<admin1 code>.<admin2 code>".
+type hierarchy struct {
+	continents    map[string]*entity.GeoNameContinent
+	countries     map[string]*entity.GeoNameCountry
+	subdivisions  map[string]*entity.GeoNameAdminSubdivision
+	subdivisions2 map[string]*entity.GeoNameAdminSubdivision2
+
+	continentCountries  map[string][]*entity.GeoNameCountry           // continent code -> countries
+	countrySubdivisions map[string][]*entity.GeoNameAdminSubdivision  // country code -> subdivisions
+	subdivisionSubdivs2 map[string][]*entity.GeoNameAdminSubdivision2 // subdivision code -> subdivisions2
+	subdivisionCities   map[string][]*entity.GeoName                  // subdivision or subdivision2 code -> cities
+}
+
+func newHierarchy(
+	continents []*entity.GeoNameContinent,
+	countries []*entity.GeoNameCountry,
+	subdivisions []*entity.GeoNameAdminSubdivision,
+	subdivisions2 []*entity.GeoNameAdminSubdivision2,
+	cities []*entity.GeoName,
+) *hierarchy {
+	h := &hierarchy{
+		continents:    make(map[string]*entity.GeoNameContinent, len(continents)),
+		countries:     make(map[string]*entity.GeoNameCountry, len(countries)),
+		subdivisions:  make(map[string]*entity.GeoNameAdminSubdivision, len(subdivisions)),
+		subdivisions2: make(map[string]*entity.GeoNameAdminSubdivision2, len(subdivisions2)),
+
+		continentCountries:  make(map[string][]*entity.GeoNameCountry),
+		countrySubdivisions: make(map[string][]*entity.GeoNameAdminSubdivision),
+		subdivisionSubdivs2: make(map[string][]*entity.GeoNameAdminSubdivision2),
+		subdivisionCities:   make(map[string][]*entity.GeoName),
+	}
+	for _, continent := range continents {
+		if _, ok := h.continents[continent.Code()]; !ok {
+			h.continents[continent.Code()] = continent
+		}
+	}
+	for _, country := range countries {
+		h.countries[country.GetCountryCode()] = country
+		h.continentCountries[country.Continent] = append(h.continentCountries[country.Continent], country)
+	}
+	for _, subdiv := range subdivisions {
+		h.subdivisions[subdiv.Code] = subdiv
+		h.countrySubdivisions[subdiv.GetCountryCode()] = append(h.countrySubdivisions[subdiv.GetCountryCode()], subdiv)
+	}
+	for _, subdiv := range subdivisions2 {
+		h.subdivisions2[subdiv.Code] = subdiv
+		h.subdivisionSubdivs2[subdiv.SubdivisionCode()] = append(h.subdivisionSubdivs2[subdiv.SubdivisionCode()], subdiv)
+	}
+	// a city is a child of both its subdivision and subdivision2
+	for _, city := range cities {
+		subdivCode := city.GetCountryCode() + "." + city.Admin1Code
+		h.subdivisionCities[subdivCode] = append(h.subdivisionCities[subdivCode], city)
+		if len(city.Admin2Code) > 0 {
+			subdiv2Code := subdivCode + "." + city.Admin2Code
+			h.subdivisionCities[subdiv2Code] = append(h.subdivisionCities[subdiv2Code], city)
+		}
+	}
+	return h
+}
+
+// hierarchyKey identifies a node of the hierarchy
+type hierarchyKey struct {
+	typ  entity.GeoNameType
+	code string
+}
+
+// parentKeys returns the possible parents of the item, the closest first.
+// E.g. the parent of a city is its subdivision2, but only if the second-level subdivisions are loaded.
+func parentKeys(item *entity.GeoNameItem) []hierarchyKey {
+	switch item.Type {
+	case entity.GeoNameTypeCountry:
+		return []hierarchyKey{{entity.GeoNameTypeContinent, item.Country.Continent}}
+	case entity.GeoNameTypeSubdivision:
+		return []hierarchyKey{{entity.GeoNameTypeCountry, item.Subdivision.GetCountryCode()}}
+	case entity.GeoNameTypeSubdivision2:
+		return []hierarchyKey{
+			{entity.GeoNameTypeSubdivision, item.Subdivision2.SubdivisionCode()},
+			{entity.GeoNameTypeCountry, item.Subdivision2.GetCountryCode()},
+		}
+	case entity.GeoNameTypeCity:
+		subdivCode := item.City.GetCountryCode() + "." + item.City.Admin1Code
+		return []hierarchyKey{
+			{entity.GeoNameTypeSubdivision2, subdivCode + "." + item.City.Admin2Code},
+			{entity.GeoNameTypeSubdivision, subdivCode},
+			{entity.GeoNameTypeCountry, item.City.GetCountryCode()},
+		}
+	}
+	return nil
+}
+
+// get returns the entity by its type and code, nil if there is no such entity
+func (h *hierarchy) get(key hierarchyKey) *entity.GeoNameItem {
+	var e entity.GeoNameEntity
+	var ok bool
+	switch key.typ {
+	case entity.GeoNameTypeContinent:
+		e, ok = h.continents[key.code]
+	case entity.GeoNameTypeCountry:
+		e, ok = h.countries[key.code]
+	case entity.GeoNameTypeSubdivision:
+		e, ok = h.subdivisions[key.code]
+	case entity.GeoNameTypeSubdivision2:
+		e, ok = h.subdivisions2[key.code]
+	}
+	if !ok {
+		return nil
+	}
+	return entity.NewGeoNameItem(e)
+}
+
+// children returns the entities one level down the hierarchy. The children of a subdivision are
+// its subdivisions2 followed by all its cities.
+func (h *hierarchy) children(item *entity.GeoNameItem) []*entity.GeoNameItem {
+	switch item.Type {
+	case entity.GeoNameTypeContinent:
+		return geoNameItems(h.continentCountries[item.Continent.Code()])
+	case entity.GeoNameTypeCountry:
+		return geoNameItems(h.countrySubdivisions[item.Country.GetCountryCode()])
+	case entity.GeoNameTypeSubdivision:
+		return append(
+			geoNameItems(h.subdivisionSubdivs2[item.Subdivision.Code]),
+			geoNameItems(h.subdivisionCities[item.Subdivision.Code])...,
+		)
+	case entity.GeoNameTypeSubdivision2:
+		return geoNameItems(h.subdivisionCities[item.Subdivision2.Code])
+	}
+	return nil
+}
+
+func geoNameItems[T entity.GeoNameEntity](entities []T) []*entity.GeoNameItem {
+	res := make([]*entity.GeoNameItem, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, entity.NewGeoNameItem(e))
+	}
+	return res
+}
@@ -0,0 +1,141 @@
+package geonames
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func itemKeys(items ...*entity.GeoNameItem) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		res = append(res, fmt.Sprintf("%s:%d", item.Type, item.Entity().GetGeoNameID()))
+	}
+	return res
+}
+
+func TestPatchedStorageGeoName(t *testing.T) {
+	storage := newTestStorage(t, t.TempDir(), testPatches)
+	tests := []struct {
+		id      uint32
+		typ     entity.GeoNameType
+		want    string
+		wantErr error
+	}{
+		{id: 6255148, want: "continent:6255148"},
+		{id: 630336, want: "country:630336"},
+		{id: 625143, want: "subdivision:625143"},
+		{id: 629633, want: "subdivision2:629633"},
+		{id: 629634, want: "city:629634"},
+		{id: 1000000001, want: "city:1000000001"},
+		{id: 1000000002, want: "subdivision:1000000002"},
+		{id: 1000000013, want: "continent:1000000013"},
+		{id: 1000000012, typ: entity.GeoNameTypeCountry, want: "country:1000000012"},
+		{id: 625144, typ: entity.GeoNameTypeSubdivision, wantErr: utils.ErrNotFound},
+		{id: 1, wantErr: utils.ErrNotFound},
+		{id: 630336, typ: "region", wantErr: utils.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d %s", tt.id, tt.typ), func(t *testing.T) {
+			item, err := storage.GeoName(context.Background(), tt.id, entity.GeoNameHierarchyFilter{Type: tt.typ})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, itemKeys(item))
+		})
+	}
+}
+
+func TestPatchedStorageChildren(t *testing.T) {
+	storage := newTestStorage(t, t.TempDir(), testPatches)
+	tests := []struct {
+		name  string
+		id    uint32
+		limit uint32
+		want  []string
+	}{
+		{
+			name: "continent, the patch country of a GeoNames code is listed too", id: 6255148,
+			want: []string{"country:630336", "country:2921044", "country:630336"},
+		},
+		{
+			name: "country with a patch subdivision", id: 630336,
+			want: []string{"subdivision:629631", "subdivision:625143", "subdivision:1000000002"},
+		},
+		{name: "limit", id: 630336, limit: 1, want: []string{"subdivision:629631"}},
+		{
+			name: "subdivision, the subdivisions2 then all the cities", id: 629631,
+			want: []string{"subdivision2:629633", "city:629634", "city:623549"},
+		},
+		{name: "subdivision2", id: 629633, want: []string{"city:629634"}},
+		{name: "patch subdivision", id: 1000000002, want: []string{"city:1000000001"}},
+		{name: "patch continent", id: 1000000013, want: []string{"country:1000000012"}},
+		{name: "city", id: 625144, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			children, err := storage.Children(context.Background(), tt.id, entity.GeoNameHierarchyFilter{Limit: tt.limit})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, itemKeys(children...))
+		})
+	}
+}
+
+func TestPatchedStorageAncestors(t *testing.T) {
+	storage := newTestStorage(t, t.TempDir(), testPatches)
+	tests := []struct {
+		name string
+		id   uint32
+		want []string
+	}{
+		{
+			name: "city with a subdivision2", id: 629634,
+			want: []string{"subdivision2:629633", "subdivision:629631", "country:630336", "continent:6255148"},
+		},
+		{
+			name: "city without a subdivision2", id: 623549,
+			want: []string{"subdivision:629631", "country:630336", "continent:6255148"},
+		},
+		{
+			name: "city of a subdivision2 that isn't loaded", id: 2867714,
+			want: []string{"subdivision:2951839", "country:2921044", "continent:6255148"},
+		},
+		{
+			name: "patch city of a GeoNames country", id: 1000000001,
+			want: []string{"subdivision:1000000002", "country:630336", "continent:6255148"},
+		},
+		{
+			name: "patch city", id: 1000000010,
+			want: []string{"subdivision:1000000011", "country:1000000012", "continent:1000000013"},
+		},
+		{name: "subdivision2", id: 629633, want: []string{"subdivision:629631", "country:630336", "continent:6255148"}},
+		{name: "continent", id: 6255148, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ancestors, err := storage.Ancestors(context.Background(), tt.id, entity.GeoNameHierarchyFilter{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, itemKeys(ancestors...))
+		})
+	}
+}
+
+func TestGeoNameStorageFillsParentNames(t *testing.T) {
+	storage := newTestStorage(t, t.TempDir(), "")
+	item, err := storage.GeoName(context.Background(), 629634, entity.GeoNameHierarchyFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "Belarus", item.City.CountryName)
+	assert.Equal(t, "Europe", item.City.ContinentName)
+	assert.Equal(t, "Brest", item.City.SubdivisionName)
+	assert.Equal(t, "Brest District", item.City.Subdivision2Name)
+
+	_, err = storage.Children(context.Background(), 1000000002, entity.GeoNameHierarchyFilter{})
+	assert.ErrorIs(t, err, utils.ErrNotFound, "without the patches")
+}
@@ -1,111 +1,360 @@
 package geonames
 
 import (
+	"cmp"
+	"slices"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/bldsoft/geos/pkg/entity"
-	"github.com/derekparker/trie"
+	"github.com/bldsoft/geos/pkg/utils"
 )
 
-type indexRange struct {
-	begin int
-	end   int
+// nameKey is a folded name of the collection item
+type nameKey struct {
+	name      string
+	item      int
+	alternate bool
 }
 
-func (r *indexRange) contains(i int) bool {
-	return r.begin <= i && i < r.end
+// postings are the ascending indexes of the collection items with the same attribute value
+type postings map[string][]int
+
+func (p postings) add(key string, i int) {
+	if len(key) > 0 {
+		p[key] = append(p[key], i)
+	}
+}
+
+func (p postings) size(keys []string, normalize func(string) string) int {
+	n := 0
+	for _, key := range keys {
+		n += len(p[normalize(key)])
+	}
+	return n
+}
+
+func (p postings) union(keys []string, normalize func(string) string) []int {
+	var res []int
+	for _, key := range keys {
+		res = append(res, p[normalize(key)]...)
+	}
+	if len(keys) > 1 {
+		slices.Sort(res)
+		res = slices.Compact(res)
+	}
+	return res
 }
 
 type index[T entity.GeoNameEntity] struct {
-	collection []T
+	collection  []T
+	populations []int
 
 	geoNameIDToCollectionIndex map[uint32]int
-	trie                       *trie.Trie
-	countryCodeToRange         map[string]*indexRange
+	names                      []nameKey          // sorted by name
+	nameBigrams                map[uint64][]int32 // bigram -> the ascending positions in names of the distinct names with it
+
+	countries    postings // upper-case country code -> items
+	subdivisions postings // upper-case subdivision code -> items
+	featureCodes postings // upper-case feature code -> items
+	timeZones    postings // lower-case time zone -> items
+	byPopulation []int    // the most populated first, only the items with population
+	byLatitude   []int    // ascending latitude, only the items with coordinates
 }
 
 func (idx *index[T]) Init(collection []T) {
 	idx.collection = collection
+	idx.populations = make([]int, len(collection))
 
-	idx.trie = trie.New()
-
-	idx.countryCodeToRange = make(map[string]*indexRange)
+	idx.names = idx.names[:0]
 	idx.geoNameIDToCollectionIndex = make(map[uint32]int)
+	idx.countries = make(postings)
+	idx.subdivisions = make(postings)
+	idx.featureCodes = make(postings)
+	idx.timeZones = make(postings)
+	idx.byPopulation = idx.byPopulation[:0]
+	idx.byLatitude = idx.byLatitude[:0]
 
 	for i, item := range collection {
-		// search by name prefix
-		idx.trie.Add(strings.ToLower(item.GetName()), i)
+		// search by name
+		idx.addNames(i, item)
+		if populator, ok := any(item).(entity.GeoNamePopulator); ok {
+			idx.populations[i] = populator.GetPopulation()
+			idx.byPopulation = append(idx.byPopulation, i)
+		}
 
 		// search by geoNameID
-		idx.geoNameIDToCollectionIndex[uint32(item.GetGeoNameID())] = i
-
-		// filter by country code
-		// expected collection sorted by country code
-		currentRange := idx.countryCodeToRange[item.GetCountryCode()]
-		if currentRange == nil {
-			currentRange = &indexRange{begin: i, end: i + 1}
-			idx.countryCodeToRange[item.GetCountryCode()] = currentRange
-		} else {
-			currentRange.end = i + 1
+		if id := item.GetGeoNameID(); id != 0 {
+			idx.geoNameIDToCollectionIndex[uint32(id)] = i
+		}
+
+		// filter by attributes
+		idx.countries.add(strings.ToUpper(item.GetCountryCode()), i)
+		idx.timeZones.add(strings.ToLower(item.GetTimeZone()), i)
+		if coder, ok := any(item).(entity.GeoNameSubdivisionCoder); ok {
+			idx.subdivisions.add(strings.ToUpper(coder.GetSubdivisionCode()), i)
+		}
+		if coder, ok := any(item).(entity.GeoNameFeatureCoder); ok {
+			idx.featureCodes.add(strings.ToUpper(coder.GetFeatureCode()), i)
+		}
+		if _, ok := any(item).(entity.GeoNameLocator); ok {
+			idx.byLatitude = append(idx.byLatitude, i)
+		}
+	}
+	slices.SortFunc(idx.names, func(a, b nameKey) int {
+		return strings.Compare(a.name, b.name)
+	})
+	idx.indexNameBigrams()
+	slices.SortStableFunc(idx.byPopulation, func(a, b int) int {
+		return cmp.Compare(idx.populations[b], idx.populations[a])
+	})
+	slices.SortStableFunc(idx.byLatitude, func(a, b int) int {
+		return cmp.Compare(idx.latitude(a), idx.latitude(b))
+	})
+}
+
+func (idx *index[T]) latitude(i int) float64 {
+	return any(idx.collection[i]).(entity.GeoNameLocator).GetLatitude()
+}
+
+func (idx *index[T]) addNames(i int, item T) {
+	seen := make(map[string]struct{})
+	add := func(name string, alternate bool) {
+		for _, folded := range utils.FoldNameVariants(name) {
+			if _, ok := seen[folded]; ok || len(folded) == 0 {
+				continue
+			}
+			seen[folded] = struct{}{}
+			idx.names = append(idx.names, nameKey{name: folded, item: i, alternate: alternate})
+		}
+	}
+	add(item.GetName(), false)
+	if namer, ok := any(item).(entity.GeoNameAlternateNamer); ok {
+		for _, name := range namer.GetAlternateNames() {
+			add(name, true)
+		}
+	}
+}
+
+// bigrams returns the distinct bigrams of the folded name, the name is padded on both sides
+func bigrams(name string) []uint64 {
+	const pad = 0
+	var res []uint64
+	prev := rune(pad)
+	for _, r := range name {
+		res = append(res, uint64(prev)<<32|uint64(r))
+		prev = r
+	}
+	res = append(res, uint64(prev)<<32|pad)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func (idx *index[T]) indexNameBigrams() {
+	idx.nameBigrams = make(map[uint64][]int32)
+	for i, key := range idx.names {
+		if i > 0 && idx.names[i-1].name == key.name {
+			continue
+		}
+		for _, bigram := range bigrams(key.name) {
+			idx.nameBigrams[bigram] = append(idx.nameBigrams[bigram], int32(i))
 		}
 	}
 }
 
+// fuzzyCandidates returns the positions in names of the distinct names that may be within maxEdits of the query.
+// An edit changes at most 3 bigram occurrences of the query (a transposition), so a matching name has
+// all but at most 3*maxEdits of the distinct bigrams of the query, and any 3*maxEdits+1 of them include
+// one of its bigrams. The rarest ones are taken, so the work depends on the query, not on the collection size.
+// The queries with too few distinct bigrams to be filtered, e.g. "aaaa", get no candidates.
+func (idx *index[T]) fuzzyCandidates(query string, maxEdits int) []int32 {
+	queryBigrams := bigrams(query)
+	n := 3*maxEdits + 1
+	if len(queryBigrams) < n {
+		return nil
+	}
+	slices.SortFunc(queryBigrams, func(a, b uint64) int {
+		return cmp.Compare(len(idx.nameBigrams[a]), len(idx.nameBigrams[b]))
+	})
+	var res []int32
+	for _, bigram := range queryBigrams[:n] {
+		res = append(res, idx.nameBigrams[bigram]...)
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// GetFiltered returns the items matching the filter. The ids or the name are looked up first,
+// otherwise the most selective of the attribute indexes is used. The rest of the predicates are checked item by item.
+// The name matches are ranked, the rest of the items keep the collection order.
 func (idx *index[T]) GetFiltered(filter entity.GeoNameFilter) (res []T) {
+	var candidates []int
 	switch {
 	case len(filter.GeoNameIDs) > 0:
-		collection := make([]T, 0, len(filter.GeoNameIDs))
+		candidates = make([]int, 0, len(filter.GeoNameIDs))
 		for _, geoNameID := range filter.GeoNameIDs {
 			if i, ok := idx.geoNameIDToCollectionIndex[geoNameID]; ok {
-				collection = append(collection, idx.collection[i])
+				candidates = append(candidates, i)
 			}
 		}
-		return collection
-	case len(filter.CountryCodes) == 0 && len(filter.NamePrefix) == 0:
-		return idx.collection
-	case len(filter.CountryCodes) > 0 && len(filter.NamePrefix) > 0:
-		for _, index := range idx.indexesByNamePrefix(filter.NamePrefix) {
-			for _, rng := range idx.rangesByCountryCodes(filter.CountryCodes...) {
-				if rng.contains(index) {
-					res = append(res, idx.collection[index])
-				}
-			}
+	case len(filter.NamePrefix) > 0:
+		candidates = idx.indexesByName(filter.NamePrefix, filter.MatchMode)
+	default:
+		var ok bool
+		if candidates, ok = idx.attributeCandidates(filter); !ok {
+			return idx.collection
 		}
-	case len(filter.CountryCodes) > 0:
-		collectionRanges := idx.rangesByCountryCodes(filter.CountryCodes...)
-		for _, rng := range collectionRanges {
-			res = append(res, idx.collection[rng.begin:rng.end]...)
+	}
+
+	for _, i := range candidates {
+		if filter.MatchAttributes(idx.collection[i]) {
+			res = append(res, idx.collection[i])
 		}
-	default:
-		for _, index := range idx.indexesByNamePrefix(filter.NamePrefix) {
-			res = append(res, idx.collection[index])
+	}
+	return res
+}
+
+// attributeCandidates returns the ascending indexes of the items from the smallest attribute index.
+// False is returned if there are no attribute predicates: the whole collection matches.
+func (idx *index[T]) attributeCandidates(filter entity.GeoNameFilter) ([]int, bool) {
+	type candidates struct {
+		size int
+		get  func() []int
+	}
+	var options []candidates
+	addPostings := func(p postings, keys []string, normalize func(string) string) {
+		if len(keys) > 0 {
+			options = append(options, candidates{p.size(keys, normalize), func() []int { return p.union(keys, normalize) }})
 		}
 	}
-	return
+	addPostings(idx.countries, filter.CountryCodes, strings.ToUpper)
+	addPostings(idx.countries, idx.continentCountries(filter.ContinentCodes), strings.ToUpper)
+	addPostings(idx.subdivisions, filter.SubdivisionCodes, strings.ToUpper)
+	addPostings(idx.featureCodes, filter.FeatureCodes, strings.ToUpper)
+	addPostings(idx.timeZones, filter.TimeZones, strings.ToLower)
+	if filter.MinPopulation > 0 {
+		n := sort.Search(len(idx.byPopulation), func(i int) bool {
+			return idx.populations[idx.byPopulation[i]] < filter.MinPopulation
+		})
+		options = append(options, candidates{n, func() []int { return sorted(idx.byPopulation[:n]) }})
+	}
+	if len(filter.BBox) == 4 {
+		south, north := filter.BBox[1], filter.BBox[3]
+		begin := sort.Search(len(idx.byLatitude), func(i int) bool { return idx.latitude(idx.byLatitude[i]) >= south })
+		end := sort.Search(len(idx.byLatitude), func(i int) bool { return idx.latitude(idx.byLatitude[i]) > north })
+		options = append(options, candidates{end - begin, func() []int { return sorted(idx.byLatitude[begin:end]) }})
+	}
+	if len(options) == 0 {
+		return nil, false
+	}
+	best := slices.MinFunc(options, func(a, b candidates) int { return cmp.Compare(a.size, b.size) })
+	return best.get(), true
 }
 
-func (idx *index[T]) indexesByNamePrefix(namePrefix string) []int {
-	namePrefix = strings.ToLower(namePrefix)
-	keys := idx.trie.PrefixSearch(namePrefix)
-	sort.Strings(keys)
-	res := make([]int, 0, len(keys))
-	for _, key := range keys {
-		node, _ := idx.trie.Find(key)
-		res = append(res, node.Meta().(int))
+// continentCountries returns the codes of the countries of the continents. The continent codes are filled
+// after the index is built, so the items are asked at query time.
+func (idx *index[T]) continentCountries(continentCodes []string) []string {
+	if len(continentCodes) == 0 {
+		return nil
+	}
+	var countryCodes []string
+	for countryCode, items := range idx.countries {
+		if containsFold(continentCodes, idx.collection[items[0]].GetContinentCode()) {
+			countryCodes = append(countryCodes, countryCode)
+		}
+	}
+	if len(countryCodes) == 0 {
+		// nothing matches, but the empty key list would disable the index
+		countryCodes = []string{""}
 	}
+	return countryCodes
+}
+
+func containsFold(values []string, s string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+func sorted(indexes []int) []int {
+	res := slices.Clone(indexes)
+	slices.Sort(res)
 	return res
 }
 
-func (idx *index[T]) rangesByCountryCodes(codes ...string) []*indexRange {
-	var ranges []*indexRange
-	for _, code := range codes {
-		code = strings.ToUpper(code)
-		idxRange := idx.countryCodeToRange[code]
-		if idxRange == nil {
-			continue
+type nameMatch struct {
+	quality   int
+	alternate bool
+}
+
+func (m nameMatch) better(other nameMatch) bool {
+	if m.quality != other.quality {
+		return m.quality < other.quality
+	}
+	return !m.alternate && other.alternate
+}
+
+// indexesByName returns the indexes of the items matching the name, ranked by match quality and population
+func (idx *index[T]) indexesByName(name string, match entity.GeoNameMatch) []int {
+	query := utils.FoldName(name)
+	if len(query) == 0 {
+		return nil
+	}
+
+	matches := make(map[int]nameMatch)
+	addMatch := func(key nameKey, quality int) {
+		m := nameMatch{quality: quality, alternate: key.alternate}
+		if prev, ok := matches[key.item]; !ok || m.better(prev) {
+			matches[key.item] = m
+		}
+	}
+
+	begin := sort.Search(len(idx.names), func(i int) bool { return idx.names[i].name >= query })
+	for _, key := range idx.names[begin:] {
+		if quality := match.Quality(key.name, query); quality == 0 || quality == 1 {
+			addMatch(key, quality)
+		} else {
+			break
+		}
+	}
+
+	if match == entity.GeoNameMatchFuzzy {
+		if maxEdits := entity.FuzzyMaxEdits(query); maxEdits > 0 {
+			queryLen := utf8.RuneCountInString(query)
+			for _, i := range idx.fuzzyCandidates(query, maxEdits) {
+				name := idx.names[i].name
+				if d := utf8.RuneCountInString(name) - queryLen; d > maxEdits || -d > maxEdits {
+					continue
+				}
+				dist := utils.EditDistance(name, query, maxEdits)
+				if dist > maxEdits {
+					continue
+				}
+				for _, key := range idx.names[i:] {
+					if key.name != name {
+						break
+					}
+					addMatch(key, 1+dist)
+				}
+			}
 		}
-		ranges = append(ranges, idxRange)
 	}
-	return ranges
+
+	res := make([]int, 0, len(matches))
+	for i := range matches {
+		res = append(res, i)
+	}
+	slices.SortFunc(res, func(a, b int) int {
+		ma, mb := matches[a], matches[b]
+		switch {
+		case ma.better(mb):
+			return -1
+		case mb.better(ma):
+			return 1
+		}
+		if c := cmp.Compare(idx.populations[b], idx.populations[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return res
 }
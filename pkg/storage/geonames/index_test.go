@@ -0,0 +1,108 @@
+package geonames
+
+import (
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/mkrou/geonames/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCityIndex() *index[*entity.GeoName] {
+	city := func(id int, name, altNames string, population int) *entity.GeoName {
+		return &entity.GeoName{Geoname: &models.Geoname{Id: id, Name: name, AlternateNames: altNames, Population: population}}
+	}
+	var idx index[*entity.GeoName]
+	idx.Init([]*entity.GeoName{
+		city(1, "Minsk", "Mensk,Минск", 2_000_000),
+		city(2, "Pinsk", "", 130_000),
+		city(3, "Minsker Hof", "", 50),
+		city(4, "São Paulo", "Sampa", 12_000_000),
+		city(5, "München", "Munich,Monaco di Baviera", 1_500_000),
+		city(6, "Minskaya", "", 1_000),
+		city(7, "Menskoe", "", 10),
+		city(8, "Mensk", "", 20),
+		city(9, "Pinsk", "", 5_000), // a namesake, less populated
+	})
+	return &idx
+}
+
+func geoNameIDs(cities []*entity.GeoName) (res []int) {
+	for _, c := range cities {
+		res = append(res, c.Id)
+	}
+	return res
+}
+
+func TestIndexNameFolding(t *testing.T) {
+	idx := newTestCityIndex()
+	find := func(name string, match entity.GeoNameMatch) []int {
+		return geoNameIDs(idx.GetFiltered(entity.GeoNameFilter{NamePrefix: name, MatchMode: match}))
+	}
+
+	assert.Equal(t, []int{4}, find("sao paulo", entity.GeoNameMatchExact), "accents are ignored")
+	assert.Equal(t, []int{4}, find("SÃO PAULO", entity.GeoNameMatchExact), "case is ignored")
+	assert.Equal(t, []int{4}, find("Sao-Paulo", entity.GeoNameMatchExact), "dashes are spaces")
+	assert.Equal(t, []int{5}, find("munchen", entity.GeoNameMatchExact))
+	assert.Equal(t, []int{5}, find("Muenchen", entity.GeoNameMatchExact), "umlaut transliteration")
+	assert.Empty(t, find("Mönchen", entity.GeoNameMatchExact))
+}
+
+func TestIndexAlternateNames(t *testing.T) {
+	idx := newTestCityIndex()
+	find := func(name string, match entity.GeoNameMatch) []int {
+		return geoNameIDs(idx.GetFiltered(entity.GeoNameFilter{NamePrefix: name, MatchMode: match}))
+	}
+
+	assert.Equal(t, []int{5}, find("Munich", entity.GeoNameMatchExact))
+	assert.Equal(t, []int{5}, find("monaco di", entity.GeoNameMatchPrefix))
+	assert.Equal(t, []int{1}, find("минск", entity.GeoNameMatchExact))
+	assert.Equal(t, []int{8, 1}, find("Mensk", entity.GeoNameMatchExact),
+		"the primary name goes before the alternate one of the same quality, despite the population")
+	assert.Equal(t, []int{8, 1, 7}, find("mensk", entity.GeoNameMatchPrefix),
+		"the alternate exact match goes before the prefix matches")
+}
+
+func TestIndexFuzzyRanking(t *testing.T) {
+	idx := newTestCityIndex()
+	find := func(name string, match entity.GeoNameMatch) []int {
+		return geoNameIDs(idx.GetFiltered(entity.GeoNameFilter{NamePrefix: name, MatchMode: match}))
+	}
+
+	assert.Equal(t, []int{1, 6, 3}, find("Minsk", entity.GeoNameMatchPrefix), "exact, then prefix by population")
+	assert.Equal(t, []int{1, 6, 3, 2, 9, 8}, find("Minsk", entity.GeoNameMatchFuzzy),
+		"exact, prefix, then one typo by population")
+	assert.Equal(t, []int{1}, find("Misnk", entity.GeoNameMatchFuzzy), "a transposition is a single typo")
+	assert.Empty(t, find("Misnk", entity.GeoNameMatchPrefix))
+	assert.Equal(t, []int{4}, find("Sao Pualo", entity.GeoNameMatchFuzzy))
+	assert.Empty(t, find("Sao Pxxxo", entity.GeoNameMatchFuzzy), "too many typos")
+	assert.Empty(t, find("Mnk", entity.GeoNameMatchFuzzy), "no typos in short queries")
+}
+
+func TestIndexFuzzyCandidates(t *testing.T) {
+	// the names and the queries within one or two edits of them, the transpositions included
+	names := []string{"minsk", "pinsk", "minskaya", "mensk", "warszawa", "sao paulo", "munchen", "muenchen", "ab", "abba", "anana"}
+	queries := []string{"misnk", "minks", "mnsk", "minskk", "warsawa", "wraszawa", "sao pualo", "munchne", "muenhcen", "bananas", "abab"}
+	var idx index[*entity.GeoName]
+	var collection []*entity.GeoName
+	for i, name := range names {
+		collection = append(collection, &entity.GeoName{Geoname: &models.Geoname{Id: i, Name: name}})
+	}
+	idx.Init(collection)
+
+	for _, query := range queries {
+		maxEdits := entity.FuzzyMaxEdits(query)
+		candidates := make(map[string]bool)
+		for _, i := range idx.fuzzyCandidates(query, maxEdits) {
+			candidates[idx.names[i].name] = true
+		}
+		for _, name := range names {
+			if utils.EditDistance(name, query, maxEdits) <= maxEdits {
+				assert.True(t, candidates[name], "%q is within %d edits of %q", name, maxEdits, query)
+			}
+		}
+	}
+	assert.Less(t, len(idx.fuzzyCandidates("misnk", 1)), len(names), "the names without the rare bigrams are skipped")
+	assert.Empty(t, idx.fuzzyCandidates("aaaa", 1), "too few distinct bigrams")
+}
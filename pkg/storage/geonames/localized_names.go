@@ -0,0 +1,128 @@
+package geonames
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/mkrou/geonames"
+	"github.com/mkrou/geonames/models"
+)
+
+// localizedNames are the names of the loaded entities from alternateNamesV2, one per language
+type localizedNames struct {
+	names          map[int]map[string]string // geoname id -> language -> name
+	continentIDs   map[string]int
+	countryIDs     map[string]int
+	subdivisionIDs map[string]int // both admin1 and admin2 codes
+}
+
+func newLocalizedNames(
+	countries []*entity.GeoNameCountry,
+	subdivisions []*entity.GeoNameAdminSubdivision,
+	subdivisions2 []*entity.GeoNameAdminSubdivision2,
+) *localizedNames {
+	n := &localizedNames{
+		names:          make(map[int]map[string]string),
+		continentIDs:   make(map[string]int),
+		countryIDs:     make(map[string]int, len(countries)),
+		subdivisionIDs: make(map[string]int, len(subdivisions)+len(subdivisions2)),
+	}
+	for _, continent := range GeoNameContinents() {
+		n.continentIDs[continent.Code()] = continent.GetGeoNameID()
+	}
+	for _, country := range countries {
+		n.countryIDs[country.GetCountryCode()] = country.GetGeoNameID()
+	}
+	for _, subdiv := range subdivisions {
+		n.subdivisionIDs[subdiv.Code] = subdiv.GetGeoNameID()
+	}
+	// admin2 codes have one more part, so they don't clash with admin1 codes
+	for _, subdiv := range subdivisions2 {
+		n.subdivisionIDs[subdiv.Code] = subdiv.GetGeoNameID()
+	}
+	return n
+}
+
+// alternateNameRank is used to choose one of the names in the language: the preferred name wins, then the short one
+func alternateNameRank(name *models.AlternateName) int {
+	rank := 0
+	if name.IsPreferred {
+		rank += 2
+	}
+	if name.IsShort {
+		rank++
+	}
+	return rank
+}
+
+// isLanguage filters out the pseudo languages of alternateNamesV2: postal codes, airport codes, links, etc.
+func isLanguage(code string) bool {
+	switch code {
+	case "", "post", "iata", "icao", "faac", "abbr", "link", "wkdt", "unlc", "tcid":
+		return false
+	}
+	return !strings.ContainsRune(code, '_') // fr_1793
+}
+
+// load reads the names of the entities from ids. All the languages are loaded if langs is empty.
+func (n *localizedNames) load(ctx context.Context, file *source.TSUpdatableFile, ids map[int]struct{}, langs []string) error {
+	parser := geonames.Parser(func(filename string) (io.ReadCloser, error) {
+		return file.Reader(ctx)
+	})
+	ranks := make(map[int]map[string]int)
+	return parser.GetAlternateNames(geonames.AlternateNames, func(name *models.AlternateName) error {
+		if _, ok := ids[name.GeonameId]; !ok || name.IsHistoric || name.IsColloquial || !isLanguage(name.IsoLanguage) {
+			return nil
+		}
+		lang := strings.ToLower(name.IsoLanguage)
+		if len(langs) > 0 && !slices.Contains(langs, lang) {
+			return nil
+		}
+		names := n.names[name.GeonameId]
+		if names == nil {
+			names = make(map[string]string)
+			n.names[name.GeonameId] = names
+			ranks[name.GeonameId] = make(map[string]int)
+		}
+		rank := alternateNameRank(name)
+		if prevRank, ok := ranks[name.GeonameId][lang]; !ok || rank > prevRank {
+			names[lang] = name.Name
+			ranks[name.GeonameId][lang] = rank
+		}
+		return nil
+	})
+}
+
+// baseLanguage cuts the region: "pt-br" -> "pt"
+func baseLanguage(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	return base
+}
+
+func (n *localizedNames) name(geoNameID int, lang string) string {
+	names := n.names[geoNameID]
+	if names == nil {
+		return ""
+	}
+	lang = strings.ToLower(lang)
+	if name, ok := names[lang]; ok {
+		return name
+	}
+	return names[baseLanguage(lang)]
+}
+
+func (n *localizedNames) continentName(code, lang string) string {
+	return n.name(n.continentIDs[code], lang)
+}
+
+func (n *localizedNames) countryName(code, lang string) string {
+	return n.name(n.countryIDs[strings.ToUpper(code)], lang)
+}
+
+func (n *localizedNames) subdivisionName(code, lang string) string {
+	return n.name(n.subdivisionIDs[code], lang)
+}
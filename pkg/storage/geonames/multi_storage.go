@@ -27,41 +27,38 @@ func (s *MultiStorage) Continents(ctx context.Context) []*entity.GeoNameContinen
 	return res
 }
 
-func (s *MultiStorage) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
-	var res []*entity.GeoNameCountry
-	for _, s := range s.storages {
-		countries, err := s.Countries(ctx, filter)
+// multiGet concatenates the entities of the storages, the limit is shared between them
+func multiGet[T any](
+	ctx context.Context,
+	storages []Storage,
+	filter entity.GeoNameFilter,
+	get func(s Storage, ctx context.Context, filter entity.GeoNameFilter) ([]T, error),
+) ([]T, error) {
+	var res []T
+	for _, s := range storages {
+		items, err := get(s, ctx, filter)
 		if err != nil {
 			return nil, err
 		}
-		filter.Limit -= uint32(len(countries))
-		res = append(res, countries...)
+		if filter.Limit != 0 && uint32(len(items)) >= filter.Limit {
+			return append(res, items[:filter.Limit]...), nil
+		}
+		if filter.Limit != 0 {
+			filter.Limit -= uint32(len(items))
+		}
+		res = append(res, items...)
 	}
 	return res, nil
 }
 
+func (s *MultiStorage) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+	return multiGet(ctx, s.storages, filter, Storage.Countries)
+}
+
 func (s *MultiStorage) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
-	var res []*entity.GeoNameAdminSubdivision
-	for _, s := range s.storages {
-		subdivisions, err := s.Subdivisions(ctx, filter)
-		if err != nil {
-			return nil, err
-		}
-		filter.Limit -= uint32(len(subdivisions))
-		res = append(res, subdivisions...)
-	}
-	return res, nil
+	return multiGet(ctx, s.storages, filter, Storage.Subdivisions)
 }
 
 func (s *MultiStorage) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
-	var res []*entity.GeoName
-	for _, s := range s.storages {
-		cities, err := s.Cities(ctx, filter)
-		if err != nil {
-			return nil, err
-		}
-		filter.Limit -= uint32(len(cities))
-		res = append(res, cities...)
-	}
-	return res, nil
+	return multiGet(ctx, s.storages, filter, Storage.Cities)
 }
@@ -0,0 +1,166 @@
+package geonames
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/jellydator/ttlcache/v3"
+)
+
+var ErrGeoNameCursorExpired = fmt.Errorf("the cursor is expired, the storage has been updated: %w", utils.ErrInvalidArgument)
+
+// geoNameCursor is the position of the next page. It's valid only for the same query and the same storage version.
+type geoNameCursor struct {
+	Version string `json:"v"`
+	Query   uint64 `json:"q"`
+	Offset  int    `json:"o"`
+}
+
+func (c geoNameCursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseGeoNameCursor(s string) (c geoNameCursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.Offset < 0 {
+		return c, fmt.Errorf("malformed cursor: %w", utils.ErrInvalidArgument)
+	}
+	return c, nil
+}
+
+// queryHash identifies the list of the entity type regardless of the page and the language of the names
+func queryHash[T entity.GeoNameEntity](filter entity.GeoNameFilter) uint64 {
+	filter.Lang, filter.Offset, filter.Cursor, filter.Limit = "", 0, "", 0
+	data, _ := json.Marshal(filter)
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%T", *new(T))
+	h.Write(data)
+	return h.Sum64()
+}
+
+const (
+	pageCacheSize = 256
+	pageCacheTTL  = 10 * time.Minute
+)
+
+type pageKey struct {
+	version string
+	query   uint64
+}
+
+// pageCache keeps the sorted lists of the queries with several pages, so the next pages are cut
+// without filtering and sorting the whole collection again
+type pageCache struct {
+	lists *ttlcache.Cache[pageKey, any]
+}
+
+func newPageCache() *pageCache {
+	return &pageCache{
+		lists: ttlcache.New(
+			ttlcache.WithCapacity[pageKey, any](pageCacheSize),
+			ttlcache.WithTTL[pageKey, any](pageCacheTTL),
+		),
+	}
+}
+
+func (c *pageCache) get(key pageKey) any {
+	if item := c.lists.Get(key); item != nil {
+		return item.Value()
+	}
+	return nil
+}
+
+func (c *pageCache) set(key pageKey, list any) {
+	c.lists.Set(key, list, ttlcache.DefaultTTL)
+}
+
+func sortGeoNameEntities[T entity.GeoNameEntity](entities []T, sort entity.GeoNameSort) {
+	population := func(e T) int {
+		if populator, ok := any(e).(entity.GeoNamePopulator); ok {
+			return populator.GetPopulation()
+		}
+		return 0
+	}
+	var compare func(a, b T) int
+	switch sort {
+	case entity.GeoNameSortName:
+		compare = func(a, b T) int { return strings.Compare(a.GetName(), b.GetName()) }
+	case entity.GeoNameSortPopulation:
+		compare = func(a, b T) int { return cmp.Compare(population(b), population(a)) }
+	case entity.GeoNameSortGeoNameID:
+		compare = func(a, b T) int { return cmp.Compare(a.GetGeoNameID(), b.GetGeoNameID()) }
+	default:
+		return
+	}
+	slices.SortStableFunc(entities, compare)
+}
+
+// paginate gets the whole list with getAll, sorts it and cuts the page. The pagination is done after
+// the merge of the storages, so the pages are consistent whichever storage the entities come from.
+// The lists of several pages are cached for the version, the storage update invalidates them with the cursors.
+func paginate[T entity.GeoNameEntity](
+	pages *pageCache,
+	version string,
+	filter entity.GeoNameFilter,
+	getAll func(filter entity.GeoNameFilter) ([]T, error),
+) (*entity.GeoNamePage[T], error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	query := queryHash[T](filter)
+	begin := int(filter.Offset)
+	if len(filter.Cursor) > 0 {
+		cursor, err := parseGeoNameCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Query != query {
+			return nil, fmt.Errorf("the cursor belongs to another query: %w", utils.ErrInvalidArgument)
+		}
+		if cursor.Version != version {
+			return nil, ErrGeoNameCursorExpired
+		}
+		begin = cursor.Offset
+	}
+
+	key := pageKey{version: version, query: query}
+	entities, cached := pages.get(key).([]T)
+	if !cached {
+		all := filter
+		all.Offset, all.Cursor, all.Limit = 0, "", 0
+		var err error
+		if entities, err = getAll(all); err != nil {
+			return nil, err
+		}
+		if filter.Sort != "" {
+			// the storages may return their own collections
+			entities = slices.Clone(entities)
+			sortGeoNameEntities(entities, filter.Sort)
+		}
+		if filter.Limit != 0 && len(entities) > int(filter.Limit) {
+			pages.set(key, entities)
+		}
+	}
+
+	page := &entity.GeoNamePage[T]{Total: len(entities)}
+	begin = min(begin, len(entities))
+	end := len(entities)
+	if filter.Limit != 0 && begin+int(filter.Limit) < end {
+		end = begin + int(filter.Limit)
+		page.NextCursor = geoNameCursor{Version: version, Query: query, Offset: end}.String()
+	}
+	page.Items = entities[begin:end:end] // the list may be cached, the appends mustn't overwrite it
+	return page, nil
+}
@@ -0,0 +1,208 @@
+package geonames
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/mkrou/geonames/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCities() []*entity.GeoName {
+	city := func(id int, name string, population int) *entity.GeoName {
+		return &entity.GeoName{Geoname: &models.Geoname{Id: id, Name: name, Population: population}}
+	}
+	return []*entity.GeoName{
+		city(3, "Pinsk", 130_000),
+		city(1, "Minsk", 2_000_000),
+		city(5, "Brest", 340_000),
+		city(2, "Munich", 1_500_000),
+		city(4, "Atlantis", 0),
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	cities := newTestCities()
+	getAll := func(entity.GeoNameFilter) ([]*entity.GeoName, error) { return cities, nil }
+	cursor := func(filter entity.GeoNameFilter, version string, offset int) string {
+		return geoNameCursor{Version: version, Query: queryHash[*entity.GeoName](filter), Offset: offset}.String()
+	}
+	byPopulation := entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 2}
+
+	tests := []struct {
+		name     string
+		filter   entity.GeoNameFilter
+		want     []int
+		wantNext int // the offset of the next cursor, 0 for the last page
+		wantErr  error
+	}{
+		{name: "no limit", want: []int{3, 1, 5, 2, 4}},
+		{name: "first page", filter: entity.GeoNameFilter{Limit: 2}, want: []int{3, 1}, wantNext: 2},
+		{name: "offset", filter: entity.GeoNameFilter{Offset: 2, Limit: 2}, want: []int{5, 2}, wantNext: 4},
+		{name: "last page", filter: entity.GeoNameFilter{Offset: 3, Limit: 2}, want: []int{2, 4}},
+		{name: "offset past the end", filter: entity.GeoNameFilter{Offset: 10, Limit: 2}},
+		{name: "sort by name", filter: entity.GeoNameFilter{Sort: entity.GeoNameSortName}, want: []int{4, 5, 1, 2, 3}},
+		{name: "sort by population", filter: entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation}, want: []int{1, 2, 5, 3, 4}},
+		{name: "sort by id", filter: entity.GeoNameFilter{Sort: entity.GeoNameSortGeoNameID}, want: []int{1, 2, 3, 4, 5}},
+		{
+			name:   "cursor",
+			filter: entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 2, Cursor: cursor(byPopulation, "v1", 2)},
+			want:   []int{5, 3}, wantNext: 4,
+		},
+		{
+			name:   "the cursor overrides the offset",
+			filter: entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 2, Offset: 1, Cursor: cursor(byPopulation, "v1", 4)},
+			want:   []int{4},
+		},
+		{
+			name:   "the page language isn't a part of the query",
+			filter: entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 2, Lang: "ru", Cursor: cursor(byPopulation, "v1", 2)},
+			want:   []int{5, 3}, wantNext: 4,
+		},
+		{
+			name:    "cursor of another query",
+			filter:  entity.GeoNameFilter{Sort: entity.GeoNameSortName, Limit: 2, Cursor: cursor(byPopulation, "v1", 2)},
+			wantErr: utils.ErrInvalidArgument,
+		},
+		{
+			name:    "expired cursor",
+			filter:  entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 2, Cursor: cursor(byPopulation, "v0", 2)},
+			wantErr: ErrGeoNameCursorExpired,
+		},
+		{name: "malformed cursor", filter: entity.GeoNameFilter{Cursor: "not a cursor"}, wantErr: utils.ErrInvalidArgument},
+		{name: "unknown sort", filter: entity.GeoNameFilter{Sort: "area"}, wantErr: utils.ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := paginate(newPageCache(), "v1", tt.filter, getAll)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, geoNameIDs(page.Items))
+			assert.Equal(t, len(cities), page.Total)
+			if tt.wantNext == 0 {
+				assert.Empty(t, page.NextCursor)
+			} else {
+				assert.Equal(t, cursor(tt.filter, "v1", tt.wantNext), page.NextCursor)
+			}
+		})
+	}
+	assert.Equal(t, []int{3, 1, 5, 2, 4}, geoNameIDs(cities), "the collection of the storage isn't sorted in place")
+}
+
+func TestPaginateCachesList(t *testing.T) {
+	var calls int
+	getAll := func(entity.GeoNameFilter) ([]*entity.GeoName, error) {
+		calls++
+		return newTestCities(), nil
+	}
+	pages := newPageCache()
+	filter := entity.GeoNameFilter{Sort: entity.GeoNameSortName, Limit: 2}
+
+	var ids []int
+	for {
+		page, err := paginate(pages, "v1", filter, getAll)
+		require.NoError(t, err)
+		ids = append(ids, geoNameIDs(page.Items)...)
+		if page.NextCursor == "" {
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []int{4, 5, 1, 2, 3}, ids)
+	assert.Equal(t, 1, calls, "the next pages are cut from the cached list")
+
+	_, err := paginate(pages, "v2", entity.GeoNameFilter{Sort: entity.GeoNameSortName, Limit: 2}, getAll)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "the list is cached for the version")
+
+	countries := func(entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) { return nil, nil }
+	_, err = paginate(pages, "v1", entity.GeoNameFilter{Sort: entity.GeoNameSortName, Limit: 2, Cursor: filter.Cursor}, countries)
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument, "the cursor of another entity type")
+}
+
+func TestMultiStorageSharesLimit(t *testing.T) {
+	dir := t.TempDir()
+	writeTestDumps(t, dir)
+	path := filepath.Join(dir, "patch.json")
+	writeTestFile(t, path, testPatches)
+	ctx := context.Background()
+	storage := NewMultiStorage(
+		NewStorage(ctx, source.NewGeoNamesSource(dir).WithAdmin2Codes(), true),
+		NewCustomStorage(ctx, source.NewTSUpdatableFile(path, "")),
+	)
+
+	tests := []struct {
+		limit uint32
+		want  []int
+	}{
+		{0, []int{625144, 629634, 623549, 2867714, 2950159, 1000000001, 1000000010}},
+		{3, []int{625144, 629634, 623549}},
+		{6, []int{625144, 629634, 623549, 2867714, 2950159, 1000000001}},
+	}
+	for _, tt := range tests {
+		cities, err := storage.Cities(ctx, entity.GeoNameFilter{Limit: tt.limit})
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, geoNameIDs(cities), "limit %d", tt.limit)
+	}
+}
+
+func TestPatchedStoragePages(t *testing.T) {
+	dir := t.TempDir()
+	storage := newTestStorage(t, dir, testPatches)
+	ctx := context.Background()
+
+	filter := entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 3}
+	page, err := storage.CitiesPage(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2950159, 625144, 2867714}, geoNameIDs(page.Items))
+	assert.Equal(t, 7, page.Total, "the GeoNames and the patch cities")
+
+	// another instance with the same files accepts the cursor
+	replica := NewPatchedStorage(NewStorage(ctx, source.NewGeoNamesSource(dir).WithAdmin2Codes(), true)).
+		Add(NewCustomStorage(ctx, source.NewTSUpdatableFile(filepath.Join(dir, "patches", "patch.json"), "")))
+	filter.Cursor = page.NextCursor
+	page, err = replica.CitiesPage(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []int{629634, 623549, 1000000001}, geoNameIDs(page.Items))
+	filter.Cursor = page.NextCursor
+	page, err = replica.CitiesPage(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1000000010}, geoNameIDs(page.Items), "the pages are cut after the merge")
+	assert.Empty(t, page.NextCursor)
+
+	countries, err := storage.CountriesPage(ctx, entity.GeoNameFilter{Sort: entity.GeoNameSortGeoNameID})
+	require.NoError(t, err)
+	var codes []string
+	for _, c := range countries.Items {
+		codes = append(codes, c.Iso2Code)
+	}
+	assert.Equal(t, []string{"BY", "BY", "DE", "XA"}, codes)
+
+	// the update of the patches expires the cursors
+	filter = entity.GeoNameFilter{Sort: entity.GeoNameSortPopulation, Limit: 3}
+	page, err = storage.CitiesPage(ctx, filter)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "patches", "patch.json")
+	writeTestFile(t, path, `[]`)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, later, later))
+	require.NoError(t, storage.custom.update(ctx))
+
+	filter.Cursor = page.NextCursor
+	_, err = storage.CitiesPage(ctx, filter)
+	assert.ErrorIs(t, err, ErrGeoNameCursorExpired)
+	filter.Cursor = ""
+	page, err = storage.CitiesPage(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 5, page.Total)
+}
@@ -1,29 +1,377 @@
 package geonames
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
 )
 
 type PatchedStorage struct {
 	MultiStorage
 	storage *GeoNameStorage
 	custom  *CustomStorage
+	pages   *pageCache
 }
 
 func NewPatchedStorage(storage *GeoNameStorage) *PatchedStorage {
 	return &PatchedStorage{
 		MultiStorage: *NewMultiStorage(storage),
 		storage:      storage,
+		pages:        newPageCache(),
 	}
 }
 
 func (s *PatchedStorage) Add(custom *CustomStorage) *PatchedStorage {
 	s.custom = custom
+	s.MultiStorage.Add(custom)
 	return s
 }
 
+type localizable[T any] interface {
+	Localized(l entity.GeoNameLocalizer, lang string) T
+}
+
+// localized translates the names of the entities, if the language is set
+func localized[T localizable[T]](l entity.GeoNameLocalizer, lang string, entities []T, err error) ([]T, error) {
+	if err != nil || len(lang) == 0 {
+		return entities, err
+	}
+	res := make([]T, 0, len(entities))
+	for _, e := range entities {
+		res = append(res, e.Localized(l, lang))
+	}
+	return res, nil
+}
+
+// Localizer returns the names of the GeoNames entities in other languages
+func (s *PatchedStorage) Localizer() entity.GeoNameLocalizer {
+	return s.storage
+}
+
+func (s *PatchedStorage) LocalizedContinents(ctx context.Context, lang string) []*entity.GeoNameContinent {
+	continents, _ := localized(s.storage, lang, s.Continents(ctx), nil)
+	return continents
+}
+
+// version is made of the modification times of the loaded files, so it's the same on the instances
+// with the same files and after restarts. It changes when any of the storages is updated, that
+// invalidates the page cursors.
+func (s *PatchedStorage) version() (string, error) {
+	dbVersion, err := s.storage.dataVersion()
+	if err != nil {
+		return "", err
+	}
+	version := strconv.FormatInt(dbVersion.Time().UnixNano(), 36)
+	if s.custom != nil {
+		version += "." + strconv.FormatInt(s.custom.dataVersion().Time().UnixNano(), 36)
+	}
+	return version, nil
+}
+
+// localizedPage paginates the entities and translates the names of the page
+func localizedPage[T interface {
+	entity.GeoNameEntity
+	localizable[T]
+}](s *PatchedStorage, filter entity.GeoNameFilter, getAll func(filter entity.GeoNameFilter) ([]T, error)) (*entity.GeoNamePage[T], error) {
+	version, err := s.version()
+	if err != nil {
+		return nil, err
+	}
+	page, err := paginate(s.pages, version, filter, getAll)
+	if err != nil {
+		return nil, err
+	}
+	page.Items, _ = localized(s.storage, filter.Lang, page.Items, nil)
+	return page, nil
+}
+
+func pageItems[T any](page *entity.GeoNamePage[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+func (s *PatchedStorage) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+	return pageItems(s.CountriesPage(ctx, filter))
+}
+
+func (s *PatchedStorage) CountriesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameCountry], error) {
+	return localizedPage(s, filter, func(filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
+		return s.MultiStorage.Countries(ctx, filter)
+	})
+}
+
+func (s *PatchedStorage) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+	return pageItems(s.SubdivisionsPage(ctx, filter))
+}
+
+func (s *PatchedStorage) SubdivisionsPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision], error) {
+	return localizedPage(s, filter, func(filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
+		return s.MultiStorage.Subdivisions(ctx, filter)
+	})
+}
+
+func (s *PatchedStorage) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+	return pageItems(s.CitiesPage(ctx, filter))
+}
+
+func (s *PatchedStorage) CitiesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoName], error) {
+	return localizedPage(s, filter, func(filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
+		return s.MultiStorage.Cities(ctx, filter)
+	})
+}
+
+// Subdivisions2Page returns the second-level subdivisions, they can't be patched
+func (s *PatchedStorage) Subdivisions2Page(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNameAdminSubdivision2], error) {
+	return localizedPage(s, filter, func(filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+		return s.storage.Subdivisions2(ctx, filter)
+	})
+}
+
+// PostalCodesPage returns the postal codes, they can't be patched
+func (s *PatchedStorage) PostalCodesPage(ctx context.Context, filter entity.GeoNameFilter) (*entity.GeoNamePage[*entity.GeoNamePostalCode], error) {
+	return localizedPage(s, filter, func(filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+		return s.storage.PostalCodes(ctx, filter)
+	})
+}
+
+func (s *PatchedStorage) Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	nearest, err := s.storage.Nearest(ctx, filter)
+	if err != nil || len(filter.Lang) == 0 {
+		return nearest, err
+	}
+	for i, n := range nearest {
+		nearest[i] = &entity.GeoNameNearestCity{City: n.City.Localized(s.storage, filter.Lang), DistanceKm: n.DistanceKm}
+	}
+	return nearest, nil
+}
+
+// GeoName returns the entity by its ID. The type is detected if it isn't set in the filter,
+// the most specific type wins: patch records have the same ID on every level.
+func (s *PatchedStorage) GeoName(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) (*entity.GeoNameItem, error) {
+	item, err := s.geoName(ctx, geoNameID, filter.Type)
+	if err != nil || len(filter.Lang) == 0 {
+		return item, err
+	}
+	return item.Localized(s.storage, filter.Lang), nil
+}
+
+// Children returns the entities one level down the hierarchy
+func (s *PatchedStorage) Children(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	item, err := s.geoName(ctx, geoNameID, filter.Type)
+	if err != nil {
+		return nil, err
+	}
+	hierarchies, err := s.hierarchies()
+	if err != nil {
+		return nil, err
+	}
+	var children []*entity.GeoNameItem
+	for _, h := range hierarchies {
+		children = append(children, h.children(item)...)
+	}
+	if filter.Limit != 0 && len(children) > int(filter.Limit) {
+		children = children[:filter.Limit]
+	}
+	return localized(s.storage, filter.Lang, children, nil)
+}
+
+// Ancestors returns the entities up the hierarchy, the parent first
+func (s *PatchedStorage) Ancestors(ctx context.Context, geoNameID uint32, filter entity.GeoNameHierarchyFilter) ([]*entity.GeoNameItem, error) {
+	item, err := s.geoName(ctx, geoNameID, filter.Type)
+	if err != nil {
+		return nil, err
+	}
+	hierarchies, err := s.hierarchies()
+	if err != nil {
+		return nil, err
+	}
+	var ancestors []*entity.GeoNameItem
+	for item = parent(hierarchies, item); item != nil; item = parent(hierarchies, item) {
+		ancestors = append(ancestors, item)
+	}
+	return localized(s.storage, filter.Lang, ancestors, nil)
+}
+
+// parent looks for the parent in all the hierarchies: a patch record can belong to a GeoNames country
+func parent(hierarchies []*hierarchy, item *entity.GeoNameItem) *entity.GeoNameItem {
+	for _, key := range parentKeys(item) {
+		for _, h := range hierarchies {
+			if parent := h.get(key); parent != nil {
+				return parent
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PatchedStorage) hierarchies() ([]*hierarchy, error) {
+	h, err := s.storage.hierarchy()
+	if err != nil {
+		return nil, err
+	}
+	hierarchies := []*hierarchy{h}
+	if s.custom != nil {
+		h, err := s.custom.hierarchy()
+		if err != nil {
+			return nil, err
+		}
+		hierarchies = append(hierarchies, h)
+	}
+	return hierarchies, nil
+}
+
+func (s *PatchedStorage) geoName(ctx context.Context, geoNameID uint32, typ entity.GeoNameType) (*entity.GeoNameItem, error) {
+	if err := typ.Validate(); err != nil {
+		return nil, err
+	}
+	types := entity.GeoNameTypes
+	if len(typ) > 0 {
+		types = []entity.GeoNameType{typ}
+	}
+
+	filter := entity.GeoNameFilter{GeoNameIDs: []uint32{geoNameID}, Limit: 1}
+	for _, typ := range types {
+		var e entity.GeoNameEntity
+		var err error
+		switch typ {
+		case entity.GeoNameTypeCity:
+			e, err = first(s.MultiStorage.Cities(ctx, filter))
+		case entity.GeoNameTypeSubdivision2:
+			e, err = first(s.storage.Subdivisions2(ctx, filter))
+		case entity.GeoNameTypeSubdivision:
+			e, err = first(s.MultiStorage.Subdivisions(ctx, filter))
+		case entity.GeoNameTypeCountry:
+			e, err = first(s.MultiStorage.Countries(ctx, filter))
+		case entity.GeoNameTypeContinent:
+			for _, continent := range s.MultiStorage.Continents(ctx) {
+				if continent.GetGeoNameID() == int(geoNameID) {
+					e = continent
+					break
+				}
+			}
+		}
+		switch {
+		case errors.Is(err, ErrGeoNameDisabled):
+			continue
+		case err != nil:
+			return nil, err
+		case e != nil:
+			return entity.NewGeoNameItem(e), nil
+		}
+	}
+	return nil, fmt.Errorf("geoname %d: %w", geoNameID, utils.ErrNotFound)
+}
+
+func first[T entity.GeoNameEntity](entities []T, err error) (entity.GeoNameEntity, error) {
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+	return entities[0], nil
+}
+
+// CustomIDCollisions returns the patch entities with the IDs of the original GeoNames entities or below
+// entity.FirstCustomGeonameID. A patch uses the same ID on every level, it isn't a collision.
+func (s *PatchedStorage) CustomIDCollisions(ctx context.Context) ([]*entity.GeoNameIDCollision, error) {
+	if s.custom == nil {
+		return nil, nil
+	}
+	custom := make(itemsByID)
+	if err := custom.add(existingItems(s.custom.Cities(ctx, entity.GeoNameFilter{}))); err != nil {
+		return nil, err
+	}
+	if err := custom.add(existingItems(s.custom.Subdivisions(ctx, entity.GeoNameFilter{}))); err != nil {
+		return nil, err
+	}
+	if err := custom.add(existingItems(s.custom.Countries(ctx, entity.GeoNameFilter{}))); err != nil {
+		return nil, err
+	}
+	if err := custom.add(existingItems(s.custom.Continents(ctx), nil)); err != nil {
+		return nil, err
+	}
+	if len(custom) == 0 {
+		return nil, nil
+	}
+
+	filter := entity.GeoNameFilter{}
+	for id := range custom {
+		filter.GeoNameIDs = append(filter.GeoNameIDs, id)
+	}
+	original := make(itemsByID)
+	if err := original.add(existingItems(s.storage.Cities(ctx, filter))); err != nil {
+		return nil, err
+	}
+	if err := original.add(existingItems(s.storage.Subdivisions2(ctx, filter))); err != nil {
+		return nil, err
+	}
+	if err := original.add(existingItems(s.storage.Subdivisions(ctx, filter))); err != nil {
+		return nil, err
+	}
+	if err := original.add(existingItems(s.storage.Countries(ctx, filter))); err != nil {
+		return nil, err
+	}
+	if err := original.add(existingItems(GeoNameContinents(), nil)); err != nil {
+		return nil, err
+	}
+
+	// the IDs below FirstCustomGeonameID are GeoNames ones, even if the entity isn't loaded (e.g. a smaller city dump)
+	var res []*entity.GeoNameIDCollision
+	for _, id := range filter.GeoNameIDs {
+		originals, ok := original[id]
+		if !ok && id >= entity.FirstCustomGeonameID {
+			continue
+		}
+		for _, item := range custom[id] {
+			collision := &entity.GeoNameIDCollision{
+				GeoNameID:  id,
+				CustomType: item.Type,
+				CustomName: item.Entity().GetName(),
+			}
+			if ok {
+				collision.OriginalType = originals[0].Type
+				collision.OriginalName = originals[0].Entity().GetName()
+			}
+			res = append(res, collision)
+		}
+	}
+	slices.SortFunc(res, func(a, b *entity.GeoNameIDCollision) int {
+		return cmp.Or(cmp.Compare(a.GeoNameID, b.GeoNameID), strings.Compare(string(a.CustomType), string(b.CustomType)))
+	})
+	return res, nil
+}
+
+type itemsByID map[uint32][]*entity.GeoNameItem
+
+func (m itemsByID) add(items []*entity.GeoNameItem, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		id := uint32(item.Entity().GetGeoNameID())
+		m[id] = append(m[id], item)
+	}
+	return nil
+}
+
+// existingItems skips the disabled collections
+func existingItems[T entity.GeoNameEntity](entities []T, err error) ([]*entity.GeoNameItem, error) {
+	if errors.Is(err, ErrGeoNameDisabled) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return geoNameItems(entities), nil
+}
+
 func (s *PatchedStorage) CheckUpdates(ctx context.Context) (entity.Update[entity.PatchedGeoNamesVersion], error) {
 	dbUpdate, err := s.storage.CheckUpdates(ctx)
 	if err != nil {
@@ -0,0 +1,51 @@
+package geonames
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/mkrou/geonames/models"
+	"github.com/mkrou/geonames/stream"
+)
+
+// loadPostalCodes reads the postal code dumps. They aren't supported by the geonames parser:
+// the format differs from the main dumps.
+func loadPostalCodes(ctx context.Context, files []*source.TSUpdatableFile) ([]*entity.GeoNamePostalCode, error) {
+	var postalCodes []*entity.GeoNamePostalCode
+	for _, file := range files {
+		if err := loadPostalCodesFile(ctx, file, func(postalCode *entity.GeoNamePostalCode) {
+			postalCodes = append(postalCodes, postalCode)
+		}); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file.LocalPath), err)
+		}
+	}
+	// the files may be given in any order, keep the collection grouped by country
+	slices.SortStableFunc(postalCodes, func(a, b *entity.GeoNamePostalCode) int {
+		return strings.Compare(a.CountryCode, b.CountryCode)
+	})
+	return postalCodes, nil
+}
+
+func loadPostalCodesFile(ctx context.Context, file *source.TSUpdatableFile, add func(*entity.GeoNamePostalCode)) error {
+	r, err := file.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// DE.zip contains DE.txt
+	textFilename := models.DumpFile(filepath.Base(file.LocalPath)).TextFilename()
+	return stream.StreamArchive(r, textFilename, func(decode func(v interface{}) error) error {
+		var postalCode entity.GeoNamePostalCode
+		if err := decode(&postalCode); err != nil {
+			return err
+		}
+		add(&postalCode)
+		return nil
+	}, entity.GeoNamePostalCodeHeaders)
+}
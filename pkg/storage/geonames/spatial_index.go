@@ -0,0 +1,170 @@
+package geonames
+
+import (
+	"container/heap"
+	"math"
+	"slices"
+
+	"github.com/bldsoft/geos/pkg/entity"
+)
+
+const earthRadiusKm = 6371.0088
+
+// point is a location on the unit sphere.
+// The straight-line (chord) distance between such points grows with the great-circle distance,
+// so a plain k-d tree finds the nearest cities without special cases for the poles and the antimeridian.
+type point [3]float64
+
+func newPoint(lat, lon float64) point {
+	lat, lon = lat*math.Pi/180, lon*math.Pi/180
+	return point{math.Cos(lat) * math.Cos(lon), math.Cos(lat) * math.Sin(lon), math.Sin(lat)}
+}
+
+func (p point) chordDist2(q point) float64 {
+	dx, dy, dz := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+func chordToKm(chord float64) float64 {
+	return 2 * earthRadiusKm * math.Asin(min(chord/2, 1))
+}
+
+func kmToChord(km float64) float64 {
+	if km >= math.Pi*earthRadiusKm {
+		return 2
+	}
+	return 2 * math.Sin(km/(2*earthRadiusKm))
+}
+
+type spatialNode struct {
+	point point
+	city  *entity.GeoName
+}
+
+// spatialIndex is a k-d tree over the city locations. The tree is implicit:
+// the node of a range is in its middle, the left and right subtrees are the halves.
+type spatialIndex struct {
+	nodes []spatialNode
+}
+
+func newSpatialIndex(cities []*entity.GeoName) *spatialIndex {
+	nodes := make([]spatialNode, 0, len(cities))
+	for _, city := range cities {
+		if city.Geoname == nil {
+			continue
+		}
+		nodes = append(nodes, spatialNode{point: newPoint(city.Latitude, city.Longitude), city: city})
+	}
+	idx := &spatialIndex{nodes: nodes}
+	idx.build(nodes, 0)
+	return idx
+}
+
+func (idx *spatialIndex) build(nodes []spatialNode, axis int) {
+	if len(nodes) <= 1 {
+		return
+	}
+	slices.SortFunc(nodes, func(a, b spatialNode) int {
+		switch {
+		case a.point[axis] < b.point[axis]:
+			return -1
+		case a.point[axis] > b.point[axis]:
+			return 1
+		}
+		return a.city.Id - b.city.Id
+	})
+	mid := len(nodes) / 2
+	idx.build(nodes[:mid], (axis+1)%3)
+	idx.build(nodes[mid+1:], (axis+1)%3)
+}
+
+func (idx *spatialIndex) Len() int {
+	return len(idx.nodes)
+}
+
+type neighbor struct {
+	node  *spatialNode
+	dist2 float64
+}
+
+// neighbors is a max-heap by distance, so the farthest found city is replaced first
+type neighbors []neighbor
+
+func (h neighbors) Len() int           { return len(h) }
+func (h neighbors) Less(i, j int) bool { return h[i].dist2 > h[j].dist2 }
+func (h neighbors) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighbors) Push(x any)        { *h = append(*h, x.(neighbor)) }
+func (h *neighbors) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+type nearestSearch struct {
+	target   point
+	limit    int
+	maxDist2 float64
+	found    neighbors
+}
+
+func (s *nearestSearch) bound() float64 {
+	if len(s.found) < s.limit {
+		return s.maxDist2
+	}
+	return s.found[0].dist2
+}
+
+func (s *nearestSearch) visit(nodes []spatialNode, axis int) {
+	if len(nodes) == 0 {
+		return
+	}
+	mid := len(nodes) / 2
+	node := &nodes[mid]
+	if dist2 := node.point.chordDist2(s.target); dist2 <= s.bound() {
+		if len(s.found) == s.limit {
+			heap.Pop(&s.found)
+		}
+		heap.Push(&s.found, neighbor{node: node, dist2: dist2})
+	}
+
+	near, far := nodes[:mid], nodes[mid+1:]
+	diff := s.target[axis] - node.point[axis]
+	if diff > 0 {
+		near, far = far, near
+	}
+	next := (axis + 1) % 3
+	s.visit(near, next)
+	// the distance to the split plane is a lower bound of the distance to any point behind it
+	if diff*diff <= s.bound() {
+		s.visit(far, next)
+	}
+}
+
+// Nearest returns the cities closest to the location, nearest first
+func (idx *spatialIndex) Nearest(filter entity.GeoNameNearestFilter) []*entity.GeoNameNearestCity {
+	limit := int(filter.Limit)
+	if limit == 0 {
+		limit = entity.DefaultGeoNameNearestLimit
+	}
+	maxChord := 2.0
+	if filter.RadiusKm > 0 {
+		maxChord = kmToChord(filter.RadiusKm)
+	}
+	s := &nearestSearch{
+		target:   newPoint(filter.Latitude, filter.Longitude),
+		limit:    limit,
+		maxDist2: maxChord * maxChord,
+	}
+	s.visit(idx.nodes, 0)
+
+	res := make([]*entity.GeoNameNearestCity, len(s.found))
+	for i := len(res) - 1; i >= 0; i-- {
+		n := heap.Pop(&s.found).(neighbor)
+		res[i] = &entity.GeoNameNearestCity{
+			City:       n.node.city,
+			DistanceKm: chordToKm(math.Sqrt(n.dist2)),
+		}
+	}
+	return res
+}
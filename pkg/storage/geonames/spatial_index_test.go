@@ -0,0 +1,129 @@
+package geonames
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/mkrou/geonames/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// haversineKm is the great-circle distance computed independently of the chord math of the index
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(min(a, 1)))
+}
+
+func bruteForceNearest(cities []*entity.GeoName, filter entity.GeoNameNearestFilter) []*entity.GeoNameNearestCity {
+	var res []*entity.GeoNameNearestCity
+	for _, city := range cities {
+		dist := haversineKm(filter.Latitude, filter.Longitude, city.Latitude, city.Longitude)
+		if filter.RadiusKm > 0 && dist > filter.RadiusKm {
+			continue
+		}
+		res = append(res, &entity.GeoNameNearestCity{City: city, DistanceKm: dist})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].DistanceKm < res[j].DistanceKm })
+	limit := max(int(filter.Limit), entity.DefaultGeoNameNearestLimit)
+	return res[:min(limit, len(res))]
+}
+
+// randomCities spreads the cities over the globe and packs some of them around the poles and the antimeridian
+func randomCities(rnd *rand.Rand, n int) []*entity.GeoName {
+	cities := make([]*entity.GeoName, 0, n)
+	for i := 1; i <= n; i++ {
+		var lat, lon float64
+		switch i % 4 {
+		case 0: // uniform on the sphere
+			lat, lon = math.Asin(2*rnd.Float64()-1)*180/math.Pi, rnd.Float64()*360-180
+		case 1: // near a pole
+			lat, lon = 89-rnd.Float64()*2, rnd.Float64()*360-180
+			if rnd.Intn(2) == 0 {
+				lat = -lat
+			}
+		default: // near the antimeridian, on both sides
+			lat, lon = rnd.Float64()*20-10, 179+rnd.Float64()
+			if rnd.Intn(2) == 0 {
+				lon = -lon
+			}
+		}
+		cities = append(cities, &entity.GeoName{Geoname: &models.Geoname{Id: i, Latitude: lat, Longitude: lon}})
+	}
+	return cities
+}
+
+func TestSpatialIndexNearest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	cities := randomCities(rnd, 3000)
+	idx := newSpatialIndex(cities)
+	require.Equal(t, len(cities), idx.Len())
+
+	var filters []entity.GeoNameNearestFilter
+	for _, location := range [][2]float64{
+		{90, 0}, {-90, 0}, {90, 123}, {89.9, -45}, {-89.95, 170},
+		{0, 180}, {0, -180}, {5, 179.99}, {-5, -179.99},
+		{53.9, 27.56}, {0, 0},
+	} {
+		filters = append(filters, entity.GeoNameNearestFilter{Latitude: location[0], Longitude: location[1]})
+	}
+	for i := 0; i < 200; i++ {
+		filters = append(filters, entity.GeoNameNearestFilter{Latitude: rnd.Float64()*180 - 90, Longitude: rnd.Float64()*360 - 180})
+	}
+
+	for _, filter := range filters {
+		for _, limit := range []uint32{0, 1, 5, 50} {
+			for _, radius := range []float64{0, 1, 50, 300, 5000, 30000} {
+				filter := filter
+				filter.Limit, filter.RadiusKm = limit, radius
+				name := fmt.Sprintf("%.2f,%.2f limit %d radius %v", filter.Latitude, filter.Longitude, limit, radius)
+
+				got := idx.Nearest(filter)
+				want := bruteForceNearest(cities, filter)
+				require.Len(t, got, len(want), name)
+				for i := range want {
+					assert.InDelta(t, want[i].DistanceKm, got[i].DistanceKm, 1e-6, name)
+					assert.Equal(t, want[i].City.Id, got[i].City.Id, name)
+				}
+			}
+		}
+	}
+}
+
+func TestSpatialIndexNearestAcrossAntimeridianAndPole(t *testing.T) {
+	city := func(id int, lat, lon float64) *entity.GeoName {
+		return &entity.GeoName{Geoname: &models.Geoname{Id: id, Latitude: lat, Longitude: lon}}
+	}
+	idx := newSpatialIndex([]*entity.GeoName{
+		city(1, 0, 179.9),
+		city(2, 0, -179.7),
+		city(3, 0, 178),
+		city(4, 89.9, 0),
+		city(5, 89.9, 180),
+		city(6, 88, 90),
+		{}, // a city without a location isn't indexed
+	})
+	assert.Equal(t, 6, idx.Len())
+
+	ids := func(res []*entity.GeoNameNearestCity) (ids []int) {
+		for _, c := range res {
+			ids = append(ids, c.City.Id)
+		}
+		return ids
+	}
+	res := idx.Nearest(entity.GeoNameNearestFilter{Latitude: 0, Longitude: -179.95, Limit: 3})
+	assert.Equal(t, []int{1, 2, 3}, ids(res), "the closest city is across the antimeridian")
+	assert.InDelta(t, 0.15*math.Pi/180*earthRadiusKm, res[0].DistanceKm, 1e-6)
+
+	res = idx.Nearest(entity.GeoNameNearestFilter{Latitude: 90, Longitude: -90, Limit: 3, RadiusKm: 100})
+	assert.ElementsMatch(t, []int{4, 5}, ids(res), "the longitude doesn't matter at the pole")
+	assert.InDelta(t, res[0].DistanceKm, res[1].DistanceKm, 1e-6)
+
+	assert.Empty(t, idx.Nearest(entity.GeoNameNearestFilter{Latitude: 45, Longitude: 0, RadiusKm: 100}))
+}
@@ -2,10 +2,12 @@ package geonames
 
 import (
 	"context"
+	"strings"
 	"sync/atomic"
 
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/bldsoft/gost/log"
 	"github.com/bldsoft/gost/utils/errgroup"
 	"github.com/mkrou/geonames"
 	"github.com/mkrou/geonames/models"
@@ -37,12 +39,25 @@ func GeoNameContinents() []*entity.GeoNameContinent {
 
 type GeoNameStorage struct {
 	source *source.GeoNamesSource
+	state  atomic.Pointer[geoNameStorageState] // nil until the first fill
+}
 
-	countries    atomic.Pointer[geonameEntityStorage[*entity.GeoNameCountry]]
-	subdivisions atomic.Pointer[geonameEntityStorage[*entity.GeoNameAdminSubdivision]]
-	cities       atomic.Pointer[geonameEntityStorage[*entity.GeoName]]
+// geoNameStorageState is the loaded data with its indexes, it's replaced as a whole on each update,
+// so a request never sees the collections of different versions
+type geoNameStorageState struct {
+	countries     *geonameEntityStorage[*entity.GeoNameCountry]
+	subdivisions  *geonameEntityStorage[*entity.GeoNameAdminSubdivision]
+	subdivisions2 *geonameEntityStorage[*entity.GeoNameAdminSubdivision2]
+	cities        *geonameEntityStorage[*entity.GeoName]
+	postalCodes   *geonameEntityStorage[*entity.GeoNamePostalCode]
+	cityLocations *spatialIndex
+	names         *localizedNames
+	links         *hierarchy
+	version       source.ModTimeVersion // the version of the loaded files
 }
 
+var _ entity.GeoNameLocalizer = &GeoNameStorage{}
+
 func NewStorage(ctx context.Context, source *source.GeoNamesSource, syncInit ...bool) *GeoNameStorage {
 	s := &GeoNameStorage{
 		source: source,
@@ -86,77 +101,177 @@ func (s *GeoNameStorage) fill(ctx context.Context) {
 		return nil
 	})
 
+	subdivisions2 := disabledGeonameEntityStorage[*entity.GeoNameAdminSubdivision2]()
+	if s.source.Admin2CodesFile != nil {
+		eg.Go(func() error {
+			subdivisions2 = newGeonameEntityStorage(ctx, s.source.Admin2CodesFile, func(parser geonames.Parser) ([]*entity.GeoNameAdminSubdivision2, error) {
+				var subdivisions2 []*entity.GeoNameAdminSubdivision2
+				err := parser.GetAdminSubdivisions(func(division *models.AdminSubdivision) error {
+					subdivisions2 = append(subdivisions2, &entity.GeoNameAdminSubdivision2{AdminSubdivision: division})
+					return nil
+				})
+				return subdivisions2, err
+			})
+			return nil
+		})
+	}
+
+	postalCodes := disabledGeonameEntityStorage[*entity.GeoNamePostalCode]()
+	if len(s.source.PostalCodesFiles) > 0 {
+		eg.Go(func() error {
+			postalCodes = loadGeonameEntityStorage(ctx, func() ([]*entity.GeoNamePostalCode, error) {
+				return loadPostalCodes(ctx, s.source.PostalCodesFiles)
+			})
+			return nil
+		})
+	}
+
 	var cities *geonameEntityStorage[*entity.GeoName]
 	eg.Go(func() error {
-		cities = newGeonameEntityStorage(ctx, s.source.Cities500File, func(parser geonames.Parser) ([]*entity.GeoName, error) {
-			var cities []*entity.GeoName
-			err := parser.GetGeonames(geonames.Cities500, func(c *models.Geoname) error {
-				cities = append(cities, &entity.GeoName{Geoname: c})
-				return nil
-			})
-			return cities, err
+		cities = loadGeonameEntityStorage(ctx, func() ([]*entity.GeoName, error) {
+			return loadCities(ctx, s.source.CitiesFiles, s.source.FeatureClasses, s.source.CityAlternateNames)
 		})
 		return nil
 	})
 
 	_ = eg.Wait()
-	s.fillAdditionalFields(countries, subdivisions, cities)
+	version, err := s.source.Version(ctx)
+	if err != nil {
+		log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to get the GeoNames dump version")
+	}
+	links := s.fillAdditionalFields(countries, subdivisions, subdivisions2, cities, postalCodes)
+	s.state.Store(&geoNameStorageState{
+		countries:     countries,
+		subdivisions:  subdivisions,
+		subdivisions2: subdivisions2,
+		cities:        cities,
+		postalCodes:   postalCodes,
+		cityLocations: newSpatialIndex(cities.collection),
+		names:         s.loadLocalizedNames(ctx, countries, subdivisions, subdivisions2, cities),
+		links:         links,
+		version:       version,
+	})
+}
+
+// loadLocalizedNames loads the names of the entities from alternateNamesV2, if it's in the source.
+// The names are optional: the error is logged and the entities are served untranslated.
+func (s *GeoNameStorage) loadLocalizedNames(
+	ctx context.Context,
+	countries *geonameEntityStorage[*entity.GeoNameCountry],
+	subdivisions *geonameEntityStorage[*entity.GeoNameAdminSubdivision],
+	subdivisions2 *geonameEntityStorage[*entity.GeoNameAdminSubdivision2],
+	cities *geonameEntityStorage[*entity.GeoName],
+) *localizedNames {
+	names := newLocalizedNames(countries.collection, subdivisions.collection, subdivisions2.collection)
+	if s.source.AlternateNamesFile == nil {
+		return names
+	}
 
-	s.countries.Store(countries)
-	s.subdivisions.Store(subdivisions)
-	s.cities.Store(cities)
+	ids := make(map[int]struct{}, len(countries.collection)+len(subdivisions.collection)+len(subdivisions2.collection)+len(cities.collection))
+	for _, continent := range GeoNameContinents() {
+		ids[continent.GetGeoNameID()] = struct{}{}
+	}
+	for _, country := range countries.collection {
+		ids[country.GetGeoNameID()] = struct{}{}
+	}
+	for _, subdiv := range subdivisions.collection {
+		ids[subdiv.GetGeoNameID()] = struct{}{}
+	}
+	for _, subdiv := range subdivisions2.collection {
+		ids[subdiv.GetGeoNameID()] = struct{}{}
+	}
+	for _, city := range cities.collection {
+		ids[city.GetGeoNameID()] = struct{}{}
+	}
+
+	// the names in the base language are the fallback: "pt" for "pt-BR"
+	var langs []string
+	for _, lang := range s.source.Languages {
+		lang = strings.ToLower(lang)
+		langs = append(langs, lang, baseLanguage(lang))
+	}
+	if err := names.load(ctx, s.source.AlternateNamesFile, ids, langs); err != nil {
+		log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to load GeoNames alternate names")
+		return newLocalizedNames(countries.collection, subdivisions.collection, subdivisions2.collection)
+	}
+	return names
 }
 
+// fillAdditionalFields fills the names of the parent entities and returns the links between the entities
 func (r *GeoNameStorage) fillAdditionalFields(
 	countries *geonameEntityStorage[*entity.GeoNameCountry],
 	subdivisions *geonameEntityStorage[*entity.GeoNameAdminSubdivision],
+	subdivisions2 *geonameEntityStorage[*entity.GeoNameAdminSubdivision2],
 	cities *geonameEntityStorage[*entity.GeoName],
-) {
-	countryCodeToContinent := make(map[string]*entity.GeoNameContinent)
-	countryCodeToCountry := make(map[string]*entity.GeoNameCountry)
-	subdivisionCodeToSubdivision := make(map[string]*entity.GeoNameAdminSubdivision)
+	postalCodes *geonameEntityStorage[*entity.GeoNamePostalCode],
+) *hierarchy {
+	h := newHierarchy(r.Continents(context.Background()), countries.collection, subdivisions.collection, subdivisions2.collection, cities.collection)
+	countryContinent := func(countryCode string) (*entity.GeoNameContinent, bool) {
+		country, ok := h.countries[countryCode]
+		if !ok {
+			return nil, false
+		}
+		continent, ok := h.continents[country.Continent]
+		return continent, ok
+	}
 
 	for _, country := range countries.collection {
-		var continent *entity.GeoNameContinent
-		for _, c := range r.Continents(context.Background()) {
-			if c.Code() == country.Continent {
-				continent = c
-				break
-			}
+		if continent, ok := h.continents[country.Continent]; ok {
+			country.ContinentName = continent.GetContinentName()
 		}
-
-		country.ContinentName = continent.GetContinentName()
-
-		countryCodeToCountry[country.GetCountryCode()] = country
-		countryCodeToContinent[country.GetCountryCode()] = continent
 	}
 
 	for _, subdiv := range subdivisions.collection {
-		if country, ok := countryCodeToCountry[subdiv.GetCountryCode()]; ok {
+		if country, ok := h.countries[subdiv.GetCountryCode()]; ok {
 			subdiv.CountryName = country.GetName()
 		}
 
-		if continent, ok := countryCodeToContinent[subdiv.GetCountryCode()]; ok {
+		if continent, ok := countryContinent(subdiv.GetCountryCode()); ok {
 			subdiv.ContinentCode = continent.GetContinentCode()
 			subdiv.ContinentName = continent.GetName()
 		}
+	}
 
-		subdivisionCodeToSubdivision[subdiv.Code] = subdiv
+	for _, subdiv := range subdivisions2.collection {
+		if country, ok := h.countries[subdiv.GetCountryCode()]; ok {
+			subdiv.CountryName = country.GetName()
+		}
+		if continent, ok := countryContinent(subdiv.GetCountryCode()); ok {
+			subdiv.ContinentCode = continent.GetContinentCode()
+			subdiv.ContinentName = continent.GetName()
+		}
+		if parent, ok := h.subdivisions[subdiv.SubdivisionCode()]; ok {
+			subdiv.SubdivisionName = parent.GetName()
+		}
 	}
 
 	for _, city := range cities.collection {
-		if country, ok := countryCodeToCountry[city.GetCountryCode()]; ok {
+		if country, ok := h.countries[city.GetCountryCode()]; ok {
 			city.CountryCode = country.GetCountryCode()
 			city.CountryName = country.GetCountryName()
 		}
-		if continent, ok := countryCodeToContinent[city.GetCountryCode()]; ok {
+		if continent, ok := countryContinent(city.GetCountryCode()); ok {
 			city.ContinentCode = continent.GetContinentCode()
 			city.ContinentName = continent.GetName()
 		}
-		if subdiv, ok := subdivisionCodeToSubdivision[city.CountryCode+"."+city.Admin1Code]; ok {
+		if subdiv, ok := h.subdivisions[city.CountryCode+"."+city.Admin1Code]; ok {
 			city.SubdivisionName = subdiv.GetSubdivisionName()
 		}
+		if subdiv, ok := h.subdivisions2[city.CountryCode+"."+city.Admin1Code+"."+city.Admin2Code]; ok {
+			city.Subdivision2Name = subdiv.GetName()
+		}
+	}
+
+	for _, postalCode := range postalCodes.collection {
+		if country, ok := h.countries[postalCode.GetCountryCode()]; ok {
+			postalCode.CountryName = country.GetName()
+		}
+		if continent, ok := countryContinent(postalCode.GetCountryCode()); ok {
+			postalCode.ContinentCode = continent.GetContinentCode()
+			postalCode.ContinentName = continent.GetName()
+		}
 	}
+	return h
 }
 
 func (r *GeoNameStorage) Continents(ctx context.Context) []*entity.GeoNameContinent {
@@ -164,27 +279,103 @@ func (r *GeoNameStorage) Continents(ctx context.Context) []*entity.GeoNameContin
 }
 
 func (r *GeoNameStorage) Countries(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameCountry, error) {
-	countries := r.countries.Load()
-	if countries == nil {
+	state := r.state.Load()
+	if state == nil {
 		return nil, ErrGeoNameNotReady
 	}
-	return countries.GetEntities(ctx, filter)
+	return state.countries.GetEntities(ctx, filter)
 }
 
 func (r *GeoNameStorage) Subdivisions(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision, error) {
-	subdivisions := r.subdivisions.Load()
-	if subdivisions == nil {
+	state := r.state.Load()
+	if state == nil {
+		return nil, ErrGeoNameNotReady
+	}
+	return state.subdivisions.GetEntities(ctx, filter)
+}
+
+func (r *GeoNameStorage) Subdivisions2(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNameAdminSubdivision2, error) {
+	state := r.state.Load()
+	if state == nil {
 		return nil, ErrGeoNameNotReady
 	}
-	return subdivisions.GetEntities(ctx, filter)
+	return state.subdivisions2.GetEntities(ctx, filter)
 }
 
 func (r *GeoNameStorage) Cities(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoName, error) {
-	cities := r.cities.Load()
-	if cities == nil {
+	state := r.state.Load()
+	if state == nil {
+		return nil, ErrGeoNameNotReady
+	}
+	return state.cities.GetEntities(ctx, filter)
+}
+
+func (r *GeoNameStorage) PostalCodes(ctx context.Context, filter entity.GeoNameFilter) ([]*entity.GeoNamePostalCode, error) {
+	state := r.state.Load()
+	if state == nil {
+		return nil, ErrGeoNameNotReady
+	}
+	return state.postalCodes.GetEntities(ctx, filter)
+}
+
+// Nearest returns the cities closest to the location. Patches aren't taken into account, their cities have no coordinates.
+func (r *GeoNameStorage) Nearest(ctx context.Context, filter entity.GeoNameNearestFilter) ([]*entity.GeoNameNearestCity, error) {
+	state := r.state.Load()
+	if state == nil {
+		return nil, ErrGeoNameNotReady
+	}
+	if state.cityLocations.Len() == 0 {
+		return nil, ErrGeoNameDisabled
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return state.cityLocations.Nearest(filter), nil
+}
+
+// dataVersion is the version of the loaded files, it changes only when the files are replaced
+func (r *GeoNameStorage) dataVersion() (source.ModTimeVersion, error) {
+	state := r.state.Load()
+	if state == nil {
+		return source.ModTimeVersion{}, ErrGeoNameNotReady
+	}
+	return state.version, nil
+}
+
+func (r *GeoNameStorage) hierarchy() (*hierarchy, error) {
+	state := r.state.Load()
+	if state == nil {
 		return nil, ErrGeoNameNotReady
 	}
-	return cities.GetEntities(ctx, filter)
+	return state.links, nil
+}
+
+func (r *GeoNameStorage) LocalizedName(geoNameID int, lang string) string {
+	if state := r.state.Load(); state != nil {
+		return state.names.name(geoNameID, lang)
+	}
+	return ""
+}
+
+func (r *GeoNameStorage) LocalizedContinentName(continentCode, lang string) string {
+	if state := r.state.Load(); state != nil {
+		return state.names.continentName(continentCode, lang)
+	}
+	return ""
+}
+
+func (r *GeoNameStorage) LocalizedCountryName(countryCode, lang string) string {
+	if state := r.state.Load(); state != nil {
+		return state.names.countryName(countryCode, lang)
+	}
+	return ""
+}
+
+func (r *GeoNameStorage) LocalizedSubdivisionName(subdivisionCode, lang string) string {
+	if state := r.state.Load(); state != nil {
+		return state.names.subdivisionName(subdivisionCode, lang)
+	}
+	return ""
 }
 
 func (s *GeoNameStorage) CheckUpdates(ctx context.Context) (entity.Update[source.ModTimeVersion], error) {
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"path/filepath"
+	"slices"
 
 	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/storage/source"
@@ -105,7 +107,9 @@ func NewStoragePatchesFromTarGz(source *source.TSUpdatableFile) ([]Storage, erro
 		return nil, fmt.Errorf("failed to unpack custom geonames storage archive: %w", err)
 	}
 
-	for filename, data := range content {
+	// the files are ordered by name, so the later one wins the same way on each update
+	for _, filename := range slices.Sorted(maps.Keys(content)) {
+		data := content[filename]
 		if filepath.Ext(filename) != ".json" {
 			log.WarnWithFields(log.Fields{"name": filename}, "skipping non-json file in custom geonames storage archive")
 			continue
@@ -0,0 +1,80 @@
+package geonames
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/mkrou/geonames"
+	"github.com/mkrou/geonames/models"
+	"github.com/stretchr/testify/require"
+)
+
+// The fixture dumps: continent -> country -> subdivision -> subdivision2 -> city
+const (
+	testCountries = `#ISO	ISO3	ISO-Numeric	fips	Country	Capital	Area(in sq km)	Population	Continent	tld	CurrencyCode	CurrencyName	Phone	Postal Code Format	Postal Code Regex	Languages	geonameid	neighbours	EquivalentFipsCode
+BY	BLR	112	BO	Belarus	Minsk	207600	9485386	EU	.by	BYN	Ruble	375	######	^(\d{6})$	be,ru	630336	LT,LV,PL,RU,UA	
+DE	DEU	276	GM	Germany	Berlin	357021	82927922	EU	.de	EUR	Euro	49	#####	^(\d{5})$	de	2921044	CH,PL,NL,DK,BE,CZ,LU,FR,AT	
+`
+	testSubdivisions = `BY.02	Brest	Brest	629631
+BY.04	Minsk City	Minsk City	625143
+DE.02	Bavaria	Bavaria	2951839
+DE.16	Berlin	Berlin	2950157
+`
+	testSubdivisions2 = `BY.02.123	Brest District	Brest District	629633
+`
+	testCities = `625144	Minsk	Minsk	Mensk,Минск	53.9	27.56667	P	PPLC	BY		04				2000000		222	Europe/Minsk	2024-01-01
+629634	Brest	Brest	Brest-Litovsk	52.09755	23.68775	P	PPLA	BY		02	123			340000		140	Europe/Minsk	2024-01-01
+623549	Pinsk	Pinsk		52.1229	26.0951	P	PPL	BY		02				130000		142	Europe/Minsk	2024-01-01
+2867714	Munich	Munich	München,Muenchen	48.13743	11.57549	P	PPLA	DE		02	091			1500000		524	Europe/Berlin	2024-01-01
+2950159	Berlin	Berlin		52.52437	13.41053	P	PPLC	DE		16	00			3400000		43	Europe/Berlin	2024-01-01
+`
+	// a patch city in a GeoNames country and a patch continent with its own country
+	testPatches = `[
+		{"city": {"geoNameID": 1000000001, "name": "Hi-Tech Park"}, "subdivision": {"geoNameID": 1000000002, "name": "HTP"},
+			"country": {"geoNameID": 630336, "name": "BY"}, "continent": {"geoNameID": 6255148, "name": "EU"}},
+		{"city": {"geoNameID": 1000000010, "name": "Atlantis"}, "subdivision": {"geoNameID": 1000000011, "name": "AT"},
+			"country": {"geoNameID": 1000000012, "name": "XA"}, "continent": {"geoNameID": 1000000013, "name": "XC"}}
+	]`
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// writeTestDumps writes the fixture dumps to the directory of a GeoNames source
+func writeTestDumps(t *testing.T, dir string) {
+	writeTestFile(t, filepath.Join(dir, geonames.Countries.String()), testCountries)
+	writeTestFile(t, filepath.Join(dir, geonames.AdminDivisions.String()), testSubdivisions)
+	writeTestFile(t, filepath.Join(dir, geonames.AdminSubDivisions.String()), testSubdivisions2)
+
+	f, err := os.Create(filepath.Join(dir, string(geonames.Cities500)))
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(models.DumpFile(geonames.Cities500).TextFilename())
+	require.NoError(t, err)
+	_, err = w.Write([]byte(testCities))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+}
+
+// newTestStorage loads the fixture dumps and the patches, if any, from the directory
+func newTestStorage(t *testing.T, dir, patches string) *PatchedStorage {
+	ctx := context.Background()
+	if _, err := os.Stat(filepath.Join(dir, geonames.Countries.String())); err != nil {
+		writeTestDumps(t, dir)
+	}
+	storage := NewPatchedStorage(NewStorage(ctx, source.NewGeoNamesSource(dir).WithAdmin2Codes(), true))
+	if len(strings.TrimSpace(patches)) == 0 {
+		return storage
+	}
+	path := filepath.Join(dir, "patches", "patch.json")
+	writeTestFile(t, path, patches)
+	return storage.Add(NewCustomStorage(ctx, source.NewTSUpdatableFile(path, "")))
+}
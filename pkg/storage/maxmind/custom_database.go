@@ -5,26 +5,44 @@ import (
 	"io"
 	"net"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/storage/source"
 	"github.com/bldsoft/gost/log"
 	"github.com/oschwald/maxminddb-golang"
 )
 
 type CustomDatabase struct {
-	base       atomic.Pointer[MultiMaxMindDB]
-	source     *source.TSUpdatableFile
+	state  atomic.Pointer[customDatabaseState]
+	source *source.TSUpdatableFile
+	// mtx serializes the updates, the readers use the state
+	mtx sync.Mutex
+	// readPatches reads the records of the db entity type
+	readPatches func(source *source.TSUpdatableFile) ([]Database, error)
+}
+
+// customDatabaseState is replaced as a whole on each update
+type customDatabaseState struct {
+	db         *MultiMaxMindDB
 	lastUpdate source.ModTimeVersion
+	// version is the last update or the last change of the record validity, whichever is later.
+	// The patches are rebuilt on the update after nextChange.
+	version    source.ModTimeVersion
+	nextChange time.Time
 }
 
-func NewCustomDatabase(ctx context.Context, source *source.TSUpdatableFile) *CustomDatabase {
+// NewCustomDatabase reads the patches of a single file or a .tar.gz archive, the records are of type T
+func NewCustomDatabase[T PatchEntity](ctx context.Context, source *source.TSUpdatableFile) *CustomDatabase {
 	ctx = context.WithValue(ctx, log.LoggerCtxKey, log.FromContext(ctx).WithFields(log.Fields{"type": "patch"}))
 
 	res := &CustomDatabase{
-		source: source,
+		source:      source,
+		readPatches: readPatches[T],
 	}
-	res.base.Store(NewMultiMaxMindDB())
+	res.state.Store(&customDatabaseState{db: NewMultiMaxMindDB()})
 
 	if err := res.update(ctx); err != nil {
 		log.FromContext(ctx).Errorf("Failed to get patches: %v", err)
@@ -34,13 +52,17 @@ func NewCustomDatabase(ctx context.Context, source *source.TSUpdatableFile) *Cus
 }
 
 func (db *CustomDatabase) db() *MultiMaxMindDB {
-	return db.base.Load()
+	return db.state.Load().db
 }
 
 func (db *CustomDatabase) Lookup(ctx context.Context, ip net.IP, result interface{}) error {
 	return db.db().Lookup(ctx, ip, result)
 }
 
+func (db *CustomDatabase) MergesAt(ctx context.Context, ip net.IP) bool {
+	return db.db().MergesAt(ctx, ip)
+}
+
 func (db *CustomDatabase) Networks(ctx context.Context, options ...maxminddb.NetworksOption) (*maxminddb.Networks, error) {
 	return db.db().Networks(ctx, options...)
 }
@@ -54,6 +76,9 @@ func (db *CustomDatabase) MetaData(ctx context.Context) (*maxminddb.Metadata, er
 }
 
 func (db *CustomDatabase) Update(ctx context.Context, force bool) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
 	update, err := db.source.CheckUpdates(ctx)
 	if err != nil {
 		return err
@@ -65,7 +90,7 @@ func (db *CustomDatabase) Update(ctx context.Context, force bool) error {
 		}
 	}
 
-	if update.RemoteVersion.Compare(db.lastUpdate) > 0 {
+	if update.RemoteVersion.Compare(db.state.Load().lastUpdate) > 0 || db.ValidityChanged() {
 		if err := db.update(ctx); err != nil {
 			return err
 		}
@@ -73,20 +98,19 @@ func (db *CustomDatabase) Update(ctx context.Context, force bool) error {
 	return nil
 }
 
+// ValidityChanged reports whether a patch record has become valid or expired since the last update
+func (db *CustomDatabase) ValidityChanged() bool {
+	return db.state.Load().validityChanged(time.Now())
+}
+
+func (s *customDatabaseState) validityChanged(now time.Time) bool {
+	return !s.nextChange.IsZero() && !now.Before(s.nextChange)
+}
+
 func (db *CustomDatabase) update(ctx context.Context) error {
-	var patches []Database
-	var err error
-	if filepath.Ext(db.source.LocalPath) == ".json" {
-		patch, err := NewDatabasePatchFromJSON(db.source)
-		if err != nil {
-			return err
-		}
-		patches = []Database{patch}
-	} else {
-		patches, err = NewDatabasePatchesFromTarGz(db.source)
-		if err != nil {
-			return err
-		}
+	patches, err := db.readPatches(db.source)
+	if err != nil {
+		return err
 	}
 
 	version, err := db.source.Version(ctx)
@@ -94,16 +118,77 @@ func (db *CustomDatabase) update(ctx context.Context) error {
 		return err
 	}
 
-	db.base.Store(NewMultiMaxMindDB(patches...))
-	db.lastUpdate = version
+	var lastChange, nextChange time.Time
+	for _, patch := range patches {
+		patch, ok := patch.(*DatabasePatch)
+		if !ok {
+			continue
+		}
+		if patch.lastChange.After(lastChange) {
+			lastChange = patch.lastChange
+		}
+		if !patch.nextChange.IsZero() && (nextChange.IsZero() || patch.nextChange.Before(nextChange)) {
+			nextChange = patch.nextChange
+		}
+	}
+
+	state := &customDatabaseState{
+		db:         NewMultiMaxMindDB(patches...),
+		lastUpdate: version,
+		version:    version,
+		nextChange: nextChange,
+	}
+	if source.ModTimeVersion(lastChange).Compare(version) > 0 {
+		state.version = source.ModTimeVersion(lastChange)
+	}
+	db.state.Store(state)
 	return nil
 }
 
+// readPatches reads a single .json, .csv or .mmdb patch, anything else is a .tar.gz archive of them
+func readPatches[T PatchEntity](source *source.TSUpdatableFile) ([]Database, error) {
+	switch filepath.Ext(source.LocalPath) {
+	case ".json", ".csv", ".mmdb":
+		patch, err := NewDatabasePatchFromFile[T](source)
+		if err != nil {
+			return nil, err
+		}
+		return []Database{patch}, nil
+	default:
+		return NewDatabasePatchesFromTarGz[T](source)
+	}
+}
+
+func (db *CustomDatabase) patches() []*DatabasePatch {
+	var res []*DatabasePatch
+	for _, patch := range db.db().dbs {
+		if patch, ok := patch.(*DatabasePatch); ok {
+			res = append(res, patch)
+		}
+	}
+	return res
+}
+
+// Expiring returns the applied patch records with the end of the validity period before the time
+func (db *CustomDatabase) Expiring(until time.Time) []*entity.ExpiringGeoIPRecord {
+	var res []*entity.ExpiringGeoIPRecord
+	for _, patch := range db.patches() {
+		res = append(res, patch.Expiring(until)...)
+	}
+	return res
+}
+
+// CheckUpdates compares the patch file versions, the change of the record validity is reported by ValidityChanged
 func (db *CustomDatabase) CheckUpdates(ctx context.Context) (source.Update[source.ModTimeVersion], error) {
 	update, err := db.source.CheckUpdates(ctx)
 	if err != nil {
 		return source.Update[source.ModTimeVersion]{}, err
 	}
-	update.CurrentVersion = db.lastUpdate
+	state := db.state.Load()
+	update.CurrentVersion = state.version
+	if update.RemoteVersion.Compare(state.lastUpdate) <= 0 {
+		// the validity change doesn't make the file the patches were read from older
+		update.RemoteVersion = state.version
+	}
 	return update, nil
 }
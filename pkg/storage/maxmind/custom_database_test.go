@@ -0,0 +1,99 @@
+package maxmind
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddValidityBound(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	tests := []struct {
+		name                   string
+		bounds                 []*time.Time
+		lastChange, nextChange time.Time
+	}{
+		{name: "no bounds", bounds: []*time.Time{nil, nil}},
+		{name: "past", bounds: []*time.Time{at(-2 * time.Hour), at(-time.Hour)}, lastChange: *at(-time.Hour)},
+		{name: "future", bounds: []*time.Time{at(2 * time.Hour), at(time.Hour)}, nextChange: *at(time.Hour)},
+		{name: "now is past", bounds: []*time.Time{at(0)}, lastChange: now},
+		{
+			name:       "both",
+			bounds:     []*time.Time{at(-time.Hour), nil, at(time.Hour), at(-2 * time.Hour), at(2 * time.Hour)},
+			lastChange: *at(-time.Hour),
+			nextChange: *at(time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch DatabasePatch
+			for _, bound := range tt.bounds {
+				patch.addValidityBound(bound, now)
+			}
+			assert.Equal(t, tt.lastChange, patch.lastChange)
+			assert.Equal(t, tt.nextChange, patch.nextChange)
+		})
+	}
+}
+
+func TestValidityChanged(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (&customDatabaseState{}).validityChanged(now), "no bounds")
+	assert.False(t, (&customDatabaseState{nextChange: now.Add(time.Second)}).validityChanged(now))
+	assert.True(t, (&customDatabaseState{nextChange: now}).validityChanged(now))
+	assert.True(t, (&customDatabaseState{nextChange: now.Add(-time.Second)}).validityChanged(now))
+}
+
+func TestCustomDatabaseRebuildsOnValidityChange(t *testing.T) {
+	ctx := context.Background()
+	bound := time.Now().Add(300 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+	path := filepath.Join(t.TempDir(), "patch.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"1.2.3.0/24": {"country": {"isoCode": "BY"}, "valid_until": "`+bound+`"},
+		"5.6.7.0/24": {"country": {"isoCode": "PL"}, "valid_from": "`+bound+`"}
+	}`), 0644))
+	db := NewCustomDatabase[entity.CityPatchRecord](ctx, source.NewTSUpdatableFile(path, ""))
+
+	country := func(ip string) string {
+		var record struct {
+			Country struct {
+				IsoCode string `maxminddb:"iso_code"`
+			} `maxminddb:"country"`
+		}
+		_ = db.Lookup(ctx, net.ParseIP(ip), &record)
+		return record.Country.IsoCode
+	}
+	assert.Equal(t, "BY", country("1.2.3.4"))
+	assert.Equal(t, "", country("5.6.7.8"))
+	assert.False(t, db.ValidityChanged())
+
+	update, err := db.CheckUpdates(ctx)
+	require.NoError(t, err)
+	versionBefore := update.CurrentVersion
+
+	require.Eventually(t, db.ValidityChanged, 3*time.Second, 20*time.Millisecond)
+	update, err = db.CheckUpdates(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, update.RemoteVersion.Compare(update.CurrentVersion), "the validity change isn't a new version of the file")
+
+	require.NoError(t, db.Update(ctx, false))
+	assert.False(t, db.ValidityChanged())
+	assert.Equal(t, "", country("1.2.3.4"))
+	assert.Equal(t, "PL", country("5.6.7.8"))
+
+	update, err = db.CheckUpdates(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, update.CurrentVersion.Compare(versionBefore), "the version is the validity bound")
+}
@@ -36,6 +36,10 @@ func (db *MaxmindDatabase) Lookup(ctx context.Context, ip net.IP, result interfa
 	return db.reader.Load().Lookup(ip, result)
 }
 
+func (db *MaxmindDatabase) LookupNetwork(ctx context.Context, ip net.IP, result interface{}) (*net.IPNet, bool, error) {
+	return db.reader.Load().LookupNetwork(ip, result)
+}
+
 func (db *MaxmindDatabase) RawData(ctx context.Context) (io.Reader, error) {
 	return bytes.NewBuffer(*db.dbRaw.Load()), nil
 }
@@ -45,7 +49,7 @@ func (db *MaxmindDatabase) MetaData(ctx context.Context) (*maxminddb.Metadata, e
 }
 
 func (db *MaxmindDatabase) Networks(ctx context.Context, options ...maxminddb.NetworksOption) (*maxminddb.Networks, error) {
-	return db.reader.Load().Networks(), nil
+	return db.reader.Load().Networks(options...), nil
 }
 
 func (db *MaxmindDatabase) Update(ctx context.Context, force bool) error {
@@ -3,12 +3,18 @@ package maxmind
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/bldsoft/geos/pkg/entity"
 	"github.com/bldsoft/geos/pkg/storage/source"
 	"github.com/bldsoft/geos/pkg/utils"
 	"github.com/maxmind/mmdbwriter"
@@ -20,6 +26,7 @@ import (
 type MMDBRecord struct {
 	Network *net.IPNet
 	Data    mmdbtype.Map
+	entity.PatchRecordMeta
 }
 
 type MMDBRecordReader interface {
@@ -30,9 +37,29 @@ type DatabasePatch struct {
 	tree  *mmdbwriter.Tree
 	dbRaw []byte
 	db    *maxminddb.Reader
+	// merges has true for the networks of the partial records, it's nil if there are no such records
+	merges *maxminddb.Reader
+
+	name string // the patch file
+	// expiring are the applied records with the end of the validity period, without the data
+	expiring []MMDBRecord
+	// lastChange and nextChange are the nearest bounds of the record validity periods
+	// in the past and in the future, zero if there are no such bounds
+	lastChange, nextChange time.Time
+}
+
+// PatchManifestName is the optional file of the patch archive that sets the order of the patches
+const PatchManifestName = "manifest.json"
+
+// PatchManifest lists the patch files of the archive from the bottom layer to the top one,
+// the record of the later file wins. Without the manifest the files are ordered by name.
+type PatchManifest struct {
+	Files []string `json:"files"`
 }
 
-func NewDatabasePatchesFromTarGz(source *source.TSUpdatableFile) ([]Database, error) {
+// NewDatabasePatchesFromTarGz reads the patches of the archive in the order of PatchManifest,
+// see NewDatabasePatchFromContent for the formats
+func NewDatabasePatchesFromTarGz[T PatchEntity](source *source.TSUpdatableFile) ([]Database, error) {
 	ctx := context.Background()
 	r, err := source.Reader(ctx)
 	if err != nil {
@@ -44,26 +71,21 @@ func NewDatabasePatchesFromTarGz(source *source.TSUpdatableFile) ([]Database, er
 	if err != nil {
 		return nil, err
 	}
+	fileNames, err := patchFileOrder(contents)
+	if err != nil {
+		return nil, err
+	}
 
-	var customDBs []Database
-	for fileName, content := range contents {
-		if filepath.Ext(fileName) != ".json" {
-			return nil, utils.ErrUnknownFormat
-		}
-
-		jsonReader, err := NewJSONRecordReader(bytes.NewReader(content))
-		if err != nil {
-			return nil, err
-		}
-
-		db, err := NewDatabasePatch(jsonReader)
-		if err != nil {
-			return nil, err
-		}
+	ver, err := source.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		ver, err := source.Version(ctx)
+	var customDBs []Database
+	for _, fileName := range fileNames {
+		db, err := NewDatabasePatchFromContent[T](fileName, contents[fileName])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s: %w", fileName, err)
 		}
 
 		customDBs = append(customDBs, db.WithMetadata(maxminddb.Metadata{
@@ -82,7 +104,38 @@ func NewDatabasePatchesFromTarGz(source *source.TSUpdatableFile) ([]Database, er
 	return customDBs, nil
 }
 
-func NewDatabasePatchFromJSON(source *source.TSUpdatableFile) (*DatabasePatch, error) {
+// patchFileOrder returns the patch files of the archive in the order of the manifest or by name.
+// The manifest must list each patch file once.
+func patchFileOrder(contents map[string][]byte) ([]string, error) {
+	manifestContent, ok := contents[PatchManifestName]
+	if !ok {
+		return slices.Sorted(maps.Keys(contents)), nil
+	}
+
+	var manifest PatchManifest
+	if err := json.Unmarshal(manifestContent, &manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", PatchManifestName, utils.ErrInvalidArgument, err)
+	}
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, fileName := range manifest.Files {
+		if _, ok := contents[fileName]; !ok || fileName == PatchManifestName {
+			return nil, fmt.Errorf("%s: %s isn't in the archive: %w", PatchManifestName, fileName, utils.ErrInvalidArgument)
+		}
+		if listed[fileName] {
+			return nil, fmt.Errorf("%s: %s is listed twice: %w", PatchManifestName, fileName, utils.ErrInvalidArgument)
+		}
+		listed[fileName] = true
+	}
+	for fileName := range contents {
+		if fileName != PatchManifestName && !listed[fileName] {
+			return nil, fmt.Errorf("%s: %s isn't listed: %w", PatchManifestName, fileName, utils.ErrInvalidArgument)
+		}
+	}
+	return manifest.Files, nil
+}
+
+// NewDatabasePatchFromFile reads the single patch file, see NewDatabasePatchFromContent for the formats
+func NewDatabasePatchFromFile[T PatchEntity](source *source.TSUpdatableFile) (*DatabasePatch, error) {
 	ctx := context.Background()
 	r, err := source.Reader(ctx)
 	if err != nil {
@@ -94,32 +147,109 @@ func NewDatabasePatchFromJSON(source *source.TSUpdatableFile) (*DatabasePatch, e
 	if err != nil {
 		return nil, err
 	}
+	return NewDatabasePatchFromContent[T](filepath.Base(source.LocalPath), content)
+}
+
+// NewDatabasePatchFromContent builds the patch by the file extension:
+// .json is the map of the networks to the records of type T,
+// .csv is the rows of the network and the columns of T, see NewCSVRecordReader,
+// .mmdb is used as is.
+func NewDatabasePatchFromContent[T PatchEntity](fileName string, content []byte) (*DatabasePatch, error) {
+	var reader MMDBRecordReader
+	var err error
+	switch filepath.Ext(fileName) {
+	case ".json":
+		reader, err = NewJSONRecordReader[T](bytes.NewReader(content))
+	case ".csv":
+		reader, err = NewCSVRecordReader[T](bytes.NewReader(content))
+	case ".mmdb":
+		return NewDatabasePatchFromMMDB(fileName, content, patchDatabaseType[T]())
+	default:
+		return nil, utils.ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	jsonReader, err := NewJSONRecordReader(bytes.NewReader(content))
+	db, err := NewDatabasePatch(reader)
 	if err != nil {
 		return nil, err
 	}
-	return NewDatabasePatch(jsonReader)
+	db.name = fileName
+	return db, nil
 }
 
+// NewDatabasePatchFromMMDB uses the MMDB file as the patch, its records have no validity period.
+// The database type of the file must contain databaseType ignoring case (City matches GeoIP2-City and GeoLite2-City),
+// any type is accepted if databaseType is empty.
+func NewDatabasePatchFromMMDB(name string, content []byte, databaseType string) (*DatabasePatch, error) {
+	db, err := maxminddb.FromBytes(content)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(strings.ToLower(db.Metadata.DatabaseType), strings.ToLower(databaseType)) {
+		return nil, fmt.Errorf("the database type is %q, %s is expected: %w", db.Metadata.DatabaseType, databaseType, utils.ErrInvalidArgument)
+	}
+	return &DatabasePatch{dbRaw: content, db: db, name: name}, nil
+}
+
+// patchDatabaseType is the part of the MMDB database type of the patched db, see NewDatabasePatchFromMMDB
+func patchDatabaseType[T PatchEntity]() string {
+	switch any(*new(T)).(type) {
+	case entity.City, entity.CityPatchRecord:
+		return "City"
+	case entity.ISP:
+		return "ISP"
+	case entity.Hosting:
+		return "Hosting"
+	default:
+		return ""
+	}
+}
+
+// NewDatabasePatch builds the patch of the records valid now, the others are skipped
 func NewDatabasePatch(reader MMDBRecordReader) (*DatabasePatch, error) {
 	tree, err := mmdbwriter.New(mmdbwriter.Options{IncludeReservedNetworks: true})
 	if err != nil {
 		return nil, err
 	}
 
+	mergeTree, err := mmdbwriter.New(mmdbwriter.Options{IncludeReservedNetworks: true})
+	if err != nil {
+		return nil, err
+	}
+	hasMerges := false
+
+	res := &DatabasePatch{tree: tree}
+	now := time.Now()
 	for {
 		rec, err := reader.ReadMMDBRecord()
-		if err != nil {
+		if errors.Is(err, io.EOF) {
 			break
 		}
-		if err := tree.InsertFunc(rec.Network, inserter.TopLevelMergeWith(rec.Data)); err != nil {
+		if err != nil {
 			return nil, err
 		}
-	}
-
-	if err != nil && !errors.Is(err, io.EOF) {
-		return nil, err
+		res.addValidityBound(rec.ValidFrom, now)
+		res.addValidityBound(rec.ValidUntil, now)
+		if !rec.ValidAt(now) {
+			continue
+		}
+		insert := inserter.TopLevelMergeWith(rec.Data)
+		if rec.Merge {
+			insert = mergeWith(rec.Data)
+		}
+		if err := tree.InsertFunc(rec.Network, insert); err != nil {
+			return nil, err
+		}
+		if err := markMerges(mergeTree, rec); err != nil {
+			return nil, err
+		}
+		hasMerges = hasMerges || rec.Merge
+		if rec.ValidUntil != nil {
+			rec.Data = nil
+			res.expiring = append(res.expiring, rec)
+		}
 	}
 
 	var buf bytes.Buffer
@@ -132,11 +262,70 @@ func NewDatabasePatch(reader MMDBRecordReader) (*DatabasePatch, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &DatabasePatch{
-		tree:  tree,
-		dbRaw: dbRaw,
-		db:    db,
-	}, nil
+	res.dbRaw, res.db = dbRaw, db
+
+	if hasMerges {
+		var mergesBuf bytes.Buffer
+		if _, err := mergeTree.WriteTo(&mergesBuf); err != nil {
+			return nil, err
+		}
+		if res.merges, err = maxminddb.FromBytes(mergesBuf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// markMerges marks the network of the partial record, unless it's on top of a full record of the patch.
+// The full record resets the mark.
+func markMerges(mergeTree *mmdbwriter.Tree, rec MMDBRecord) error {
+	if !rec.Merge {
+		return mergeTree.Insert(rec.Network, mmdbtype.Bool(false))
+	}
+	return mergeTree.InsertFunc(rec.Network, func(existing mmdbtype.DataType) (mmdbtype.DataType, error) {
+		if existing == nil {
+			return mmdbtype.Bool(true), nil
+		}
+		return existing, nil
+	})
+}
+
+func (db *DatabasePatch) MergesAt(ctx context.Context, ip net.IP) bool {
+	if db.merges == nil {
+		return false
+	}
+	var merge bool
+	_, ok, err := db.merges.LookupNetwork(ip, &merge)
+	return err == nil && ok && merge
+}
+
+func (db *DatabasePatch) addValidityBound(bound *time.Time, now time.Time) {
+	switch {
+	case bound == nil:
+	case bound.After(now):
+		if db.nextChange.IsZero() || bound.Before(db.nextChange) {
+			db.nextChange = *bound
+		}
+	case bound.After(db.lastChange):
+		db.lastChange = *bound
+	}
+}
+
+// Expiring returns the applied records with the end of the validity period before the time
+func (db *DatabasePatch) Expiring(until time.Time) []*entity.ExpiringGeoIPRecord {
+	var res []*entity.ExpiringGeoIPRecord
+	for _, rec := range db.expiring {
+		if rec.ValidUntil.After(until) {
+			continue
+		}
+		res = append(res, &entity.ExpiringGeoIPRecord{
+			Network:    rec.Network.String(),
+			ValidUntil: *rec.ValidUntil,
+			Comment:    rec.Comment,
+			Patch:      db.name,
+		})
+	}
+	return res
 }
 
 func (db *DatabasePatch) WithMetadata(meta maxminddb.Metadata) *DatabasePatch {
@@ -159,6 +348,10 @@ func (db *DatabasePatch) Lookup(ctx context.Context, ip net.IP, result interface
 	return nil
 }
 
+func (db *DatabasePatch) LookupNetwork(ctx context.Context, ip net.IP, result interface{}) (*net.IPNet, bool, error) {
+	return db.db.LookupNetwork(ip, result)
+}
+
 func (db *DatabasePatch) Networks(ctx context.Context, options ...maxminddb.NetworksOption) (*maxminddb.Networks, error) {
 	return db.db.Networks(options...), nil
 }
@@ -0,0 +1,44 @@
+package maxmind
+
+import (
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatchFileOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		manifest string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "by name without the manifest", files: []string{"b.json", "a.csv", "c.mmdb"}, want: []string{"a.csv", "b.json", "c.mmdb"}},
+		{name: "manifest order", files: []string{"a.json", "b.json", "c.json"}, manifest: `{"files": ["c.json", "a.json", "b.json"]}`, want: []string{"c.json", "a.json", "b.json"}},
+		{name: "missing file", files: []string{"a.json"}, manifest: `{"files": ["a.json", "b.json"]}`, wantErr: true},
+		{name: "duplicate file", files: []string{"a.json", "b.json"}, manifest: `{"files": ["a.json", "b.json", "a.json"]}`, wantErr: true},
+		{name: "unlisted file", files: []string{"a.json", "b.json"}, manifest: `{"files": ["b.json"]}`, wantErr: true},
+		{name: "the manifest itself", files: []string{"a.json"}, manifest: `{"files": ["a.json", "manifest.json"]}`, wantErr: true},
+		{name: "malformed manifest", files: []string{"a.json"}, manifest: `["a.json"]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := make(map[string][]byte)
+			for _, file := range tt.files {
+				contents[file] = []byte("{}")
+			}
+			if len(tt.manifest) > 0 {
+				contents[PatchManifestName] = []byte(tt.manifest)
+			}
+			got, err := patchFileOrder(contents)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package maxmind
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// GenericRecord is the record of any schema, the JSON numbers are stored as the integer or the float types
+type GenericRecord map[string]interface{}
+
+// genericRecordReservedKeys are the keys of the network and PatchRecordMeta in the JSON records
+var genericRecordReservedKeys = []string{"network", csvColumnValidFrom, csvColumnValidUntil, csvColumnComment, csvColumnMerge}
+
+func (r *GenericRecord) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		return err
+	}
+	for _, key := range genericRecordReservedKeys {
+		delete(m, key)
+	}
+	*r = m
+	return nil
+}
+
+func (r GenericRecord) ToMMDBType() mmdbtype.Map {
+	res, _ := toMMDBType(map[string]interface{}(r)).(mmdbtype.Map)
+	if res == nil {
+		res = mmdbtype.Map{}
+	}
+	return res
+}
+
+// NewGenericCSVRecordReader reads the CSV with the header of the network and the field columns,
+// the nested fields are separated by dots, e.g. "location.time_zone". The values are strings, the empty ones are skipped.
+// The valid_from, valid_until, comment and merge columns are PatchRecordMeta.
+func NewGenericCSVRecordReader(r io.Reader) (MMDBRecordReader, error) {
+	csvReader := csv.NewReader(r)
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 || rows[0][0] != "network" {
+		return nil, fmt.Errorf("header: the first column must be network: %w", utils.ErrInvalidArgument)
+	}
+	header := rows[0]
+
+	records := make([]MMDBRecord, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		record, err := genericCSVRecord(header, row)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		records = append(records, record)
+	}
+	return newSortedRecordReader(records), nil
+}
+
+func genericCSVRecord(header, row []string) (record MMDBRecord, err error) {
+	if _, record.Network, err = net.ParseCIDR(row[0]); err != nil {
+		return record, err
+	}
+	value := make(GenericRecord)
+	for i := 1; i < len(header); i++ {
+		if len(row[i]) == 0 {
+			continue
+		}
+		switch header[i] {
+		case csvColumnValidFrom:
+			record.ValidFrom, err = parseCSVTime(header[i], row[i])
+		case csvColumnValidUntil:
+			record.ValidUntil, err = parseCSVTime(header[i], row[i])
+		case csvColumnComment:
+			record.Comment = row[i]
+		case csvColumnMerge:
+			if record.Merge, err = strconv.ParseBool(row[i]); err != nil {
+				err = fmt.Errorf("%s: %w: %w", header[i], utils.ErrInvalidArgument, err)
+			}
+		default:
+			err = setGenericField(value, strings.Split(header[i], "."), row[i])
+		}
+		if err != nil {
+			return record, err
+		}
+	}
+	record.Data = patchRecordData(value, record.Merge)
+	return record, record.PatchRecordMeta.Validate()
+}
+
+func setGenericField(record map[string]interface{}, path []string, value string) error {
+	if len(path) == 1 {
+		record[path[0]] = value
+		return nil
+	}
+	nested, ok := record[path[0]].(map[string]interface{})
+	if !ok {
+		if _, exists := record[path[0]]; exists {
+			return fmt.Errorf("%s is a value and a map: %w", path[0], utils.ErrInvalidArgument)
+		}
+		nested = make(map[string]interface{})
+		record[path[0]] = nested
+	}
+	return setGenericField(nested, path[1:], value)
+}
+
+// WriteGenericCSV writes the CSV of the db records of any schema, the columns are the paths of the record values
+// sorted by name, the path of a slice element has its index. It's the inverse of NewGenericCSVRecordReader for the string values.
+func WriteGenericCSV(ctx context.Context, db Database, w io.Writer) error {
+	var paths []string
+	seen := make(map[string]bool)
+	err := walkGenericRecords(ctx, db, func(_ *net.IPNet, fields map[string]string) error {
+		for path := range fields {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	slices.Sort(paths)
+
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(append([]string{"network"}, paths...)); err != nil {
+		return err
+	}
+	row := make([]string, len(paths)+1)
+	err = walkGenericRecords(ctx, db, func(network *net.IPNet, fields map[string]string) error {
+		row[0] = network.String()
+		for i, path := range paths {
+			row[i+1] = fields[path]
+		}
+		return csvWriter.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func walkGenericRecords(ctx context.Context, db Database, f func(network *net.IPNet, fields map[string]string) error) error {
+	networks, err := db.Networks(ctx, maxminddb.SkipAliasedNetworks)
+	if err != nil {
+		return err
+	}
+	for networks.Next() {
+		var record map[string]interface{}
+		network, err := networks.Network(&record)
+		if err != nil {
+			return err
+		}
+		fields := make(map[string]string)
+		flattenGenericRecord("", record, fields)
+		if err := f(network, fields); err != nil {
+			return err
+		}
+	}
+	return networks.Err()
+}
+
+func flattenGenericRecord(path string, value interface{}, res map[string]string) {
+	join := func(key string) string {
+		if len(path) == 0 {
+			return key
+		}
+		return path + "." + key
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			flattenGenericRecord(join(key), v, res)
+		}
+	case []interface{}:
+		for i, v := range value {
+			flattenGenericRecord(join(strconv.Itoa(i)), v, res)
+		}
+	default:
+		res[path] = fmt.Sprint(value)
+	}
+}
@@ -21,6 +21,11 @@ type Database interface {
 	MetaData(ctx context.Context) (*maxminddb.Metadata, error)
 }
 
+// NetworkDatabase returns the network of the record too, ok is false if there is no record of the IP
+type NetworkDatabase interface {
+	LookupNetwork(ctx context.Context, ip net.IP, result interface{}) (network *net.IPNet, ok bool, err error)
+}
+
 type CSVDumper interface {
 	Database
 	WriteCSVTo(ctx context.Context, w io.Writer) error
@@ -30,3 +35,14 @@ type CSVDumper interface {
 type CSVEntity interface {
 	MarshalCSV() (names, row []string, err error)
 }
+
+// CSVUnmarshaler is implemented by the pointers to the CSV entities that can be read from the CSV patches
+type CSVUnmarshaler interface {
+	UnmarshalCSV(row []string) error
+}
+
+// PatchEntity is the record type of a patched db: City, ISP or Hosting
+type PatchEntity interface {
+	CSVEntity
+	MMDBEntity
+}
@@ -0,0 +1,113 @@
+package maxmind
+
+import (
+	"context"
+	"maps"
+	"net"
+
+	"github.com/maxmind/mmdbwriter/inserter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+)
+
+// MergingDatabase has the partial records that are merged onto the record of the lower layers instead of replacing it
+type MergingDatabase interface {
+	// MergesAt reports whether the record of the IP is partial
+	MergesAt(ctx context.Context, ip net.IP) bool
+}
+
+func mergesAt(ctx context.Context, db Database, ip net.IP) bool {
+	merging, ok := db.(MergingDatabase)
+	return ok && merging.MergesAt(ctx, ip)
+}
+
+// mergeWith merges the maps recursively and replaces the other values including the slices.
+// It's the same as decoding the partial records on top of each other, so the lookups and the dumps are consistent.
+func mergeWith(value mmdbtype.DataType) inserter.Func {
+	return func(existing mmdbtype.DataType) (mmdbtype.DataType, error) {
+		return mergeData(existing, value), nil
+	}
+}
+
+func mergeData(existing, value mmdbtype.DataType) mmdbtype.DataType {
+	existingMap, ok := existing.(mmdbtype.Map)
+	if !ok {
+		return value
+	}
+	valueMap, ok := value.(mmdbtype.Map)
+	if !ok {
+		return value
+	}
+	res := maps.Clone(existingMap)
+	for key, v := range valueMap {
+		res[key] = mergeData(res[key], v)
+	}
+	return res
+}
+
+// withoutZeroValues drops the zero values of the partial record, they aren't set by the merge
+func withoutZeroValues(value mmdbtype.DataType) mmdbtype.DataType {
+	switch value := value.(type) {
+	case mmdbtype.Map:
+		res := make(mmdbtype.Map, len(value))
+		for key, v := range value {
+			if v = withoutZeroValues(v); v != nil {
+				res[key] = v
+			}
+		}
+		if len(res) == 0 {
+			return nil
+		}
+		return res
+	case mmdbtype.Slice:
+		if len(value) == 0 {
+			return nil
+		}
+		return value
+	case mmdbtype.String:
+		if len(value) == 0 {
+			return nil
+		}
+	case mmdbtype.Bool:
+		if !value {
+			return nil
+		}
+	case mmdbtype.Float64:
+		if value == 0 {
+			return nil
+		}
+	case mmdbtype.Float32:
+		if value == 0 {
+			return nil
+		}
+	case mmdbtype.Int32:
+		if value == 0 {
+			return nil
+		}
+	case mmdbtype.Uint16:
+		if value == 0 {
+			return nil
+		}
+	case mmdbtype.Uint32:
+		if value == 0 {
+			return nil
+		}
+	case mmdbtype.Uint64:
+		if value == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+// patchRecordData converts the patch record value, the partial record is without the zero values
+func patchRecordData(value MMDBEntity, merge bool) mmdbtype.Map {
+	data := value.ToMMDBType()
+	if !merge {
+		return data
+	}
+	res, _ := withoutZeroValues(data).(mmdbtype.Map)
+	if res == nil {
+		res = mmdbtype.Map{}
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package maxmind
+
+import (
+	"testing"
+
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeData(t *testing.T) {
+	type m = mmdbtype.Map
+	type s = mmdbtype.Slice
+	type str = mmdbtype.String
+
+	tests := []struct {
+		name            string
+		existing, value mmdbtype.DataType
+		want            mmdbtype.DataType
+	}{
+		{
+			name:     "partial over full",
+			existing: m{"country": m{"iso_code": str("BY")}, "city": m{"geoname_id": mmdbtype.Uint64(625144)}},
+			value:    m{"city": m{"geoname_id": mmdbtype.Uint64(756135)}},
+			want:     m{"country": m{"iso_code": str("BY")}, "city": m{"geoname_id": mmdbtype.Uint64(756135)}},
+		},
+		{
+			name:     "nested maps",
+			existing: m{"city": m{"geoname_id": mmdbtype.Uint64(625144), "names": m{"en": str("Minsk"), "de": str("Minsk")}}},
+			value:    m{"city": m{"names": m{"ru": str("Минск"), "de": str("Minsk (BY)")}}},
+			want: m{"city": m{"geoname_id": mmdbtype.Uint64(625144),
+				"names": m{"en": str("Minsk"), "de": str("Minsk (BY)"), "ru": str("Минск")}}},
+		},
+		{
+			name:     "arrays are replaced",
+			existing: m{"subdivisions": s{m{"iso_code": str("HM"), "geoname_id": mmdbtype.Uint64(625143)}, m{"iso_code": str("MI")}}},
+			value:    m{"subdivisions": s{m{"iso_code": str("XX")}}},
+			want:     m{"subdivisions": s{m{"iso_code": str("XX")}}},
+		},
+		{
+			name:     "value over map",
+			existing: m{"location": m{"latitude": mmdbtype.Float64(53.9)}},
+			value:    m{"location": str("unknown")},
+			want:     m{"location": str("unknown")},
+		},
+		{
+			name:     "map over value",
+			existing: m{"location": str("unknown")},
+			value:    m{"location": m{"latitude": mmdbtype.Float64(53.9)}},
+			want:     m{"location": m{"latitude": mmdbtype.Float64(53.9)}},
+		},
+		{
+			name:  "no existing record",
+			value: m{"country": m{"iso_code": str("BY")}},
+			want:  m{"country": m{"iso_code": str("BY")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeData(tt.existing, tt.value))
+		})
+	}
+}
+
+func TestMergeDataKeepsExisting(t *testing.T) {
+	existing := mmdbtype.Map{"city": mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String("Minsk")}}}
+	mergeData(existing, mmdbtype.Map{"city": mmdbtype.Map{"names": mmdbtype.Map{"ru": mmdbtype.String("Минск")}}})
+	assert.Equal(t, mmdbtype.Map{"city": mmdbtype.Map{"names": mmdbtype.Map{"en": mmdbtype.String("Minsk")}}}, existing,
+		"the record of the lower layer may be shared by other networks")
+}
+
+func TestWithoutZeroValues(t *testing.T) {
+	value := mmdbtype.Map{
+		"city":         mmdbtype.Map{"geoname_id": mmdbtype.Uint64(0), "names": mmdbtype.Map{}},
+		"country":      mmdbtype.Map{"iso_code": mmdbtype.String("BY"), "geoname_id": mmdbtype.Uint64(0)},
+		"subdivisions": mmdbtype.Slice{},
+		"traits":       mmdbtype.Map{"is_anonymous_proxy": mmdbtype.Bool(false)},
+		"location":     mmdbtype.Map{"latitude": mmdbtype.Float64(-1.5), "accuracy_radius": mmdbtype.Uint16(0)},
+	}
+	assert.Equal(t, mmdbtype.Map{
+		"country":  mmdbtype.Map{"iso_code": mmdbtype.String("BY")},
+		"location": mmdbtype.Map{"latitude": mmdbtype.Float64(-1.5)},
+	}, withoutZeroValues(value))
+	assert.Nil(t, withoutZeroValues(mmdbtype.Map{"city": mmdbtype.Map{}}))
+}
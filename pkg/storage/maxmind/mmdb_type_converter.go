@@ -1,7 +1,11 @@
 package maxmind
 
 import (
+	"encoding/json"
+	"math"
+	"math/big"
 	"reflect"
+	"strconv"
 
 	"github.com/maxmind/mmdbwriter/mmdbtype"
 )
@@ -24,6 +28,13 @@ func toMMDBType(val interface{}) mmdbtype.DataType {
 		return mmdbtype.Float32(v)
 	case float64:
 		return mmdbtype.Float64(v)
+	case int:
+		return mmdbtype.Int32(v)
+	case *big.Int:
+		u := mmdbtype.Uint128(*v)
+		return &u
+	case json.Number:
+		return jsonNumberToMMDBType(v)
 	}
 
 	vof := reflect.ValueOf(val)
@@ -43,3 +54,22 @@ func toMMDBType(val interface{}) mmdbtype.DataType {
 	}
 	return nil
 }
+
+// jsonNumberToMMDBType converts the integers to the smallest of Uint32, Uint64 and Int32 they fit, the other numbers to Float64
+func jsonNumberToMMDBType(v json.Number) mmdbtype.DataType {
+	if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+		switch {
+		case i >= 0 && i <= math.MaxUint32:
+			return mmdbtype.Uint32(i)
+		case i >= 0:
+			return mmdbtype.Uint64(i)
+		case i >= math.MinInt32:
+			return mmdbtype.Int32(i)
+		}
+	}
+	if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+		return mmdbtype.Uint64(u)
+	}
+	f, _ := v.Float64()
+	return mmdbtype.Float64(f)
+}
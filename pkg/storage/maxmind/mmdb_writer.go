@@ -0,0 +1,81 @@
+package maxmind
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/inserter"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// WriteMMDB writes the MMDB of the records of the readers and returns the number of the records.
+// The smaller networks are inserted on top of the larger ones regardless of the order, the partial records
+// are merged onto them. The validity periods of the records are ignored.
+func WriteMMDB(w io.Writer, options mmdbwriter.Options, readers ...MMDBRecordReader) (int, error) {
+	var records []MMDBRecord
+	for _, reader := range readers {
+		for {
+			rec, err := reader.ReadMMDBRecord()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return 0, err
+			}
+			records = append(records, rec)
+		}
+	}
+
+	tree, err := mmdbwriter.New(options)
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range newSortedRecordReader(records).records {
+		insert := inserter.ReplaceWith(rec.Data)
+		if rec.Merge {
+			insert = mergeWith(rec.Data)
+		}
+		if err := tree.InsertFunc(rec.Network, insert); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := tree.WriteTo(w); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// WriteNDJSON writes the JSON lines of the db records decoded as T with the network field,
+// NewNDJSONRecordReader reads them back
+func WriteNDJSON[T any](ctx context.Context, db Database, w io.Writer) error {
+	networks, err := db.Networks(ctx, maxminddb.SkipAliasedNetworks)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	for networks.Next() {
+		var record T
+		network, err := networks.Network(&record)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		line := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(data, &line); err != nil {
+			return err
+		}
+		if line["network"], err = json.Marshal(network.String()); err != nil {
+			return err
+		}
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+	}
+	return networks.Err()
+}
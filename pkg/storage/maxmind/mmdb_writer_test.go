@@ -0,0 +1,141 @@
+package maxmind
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/maxmind/mmdbwriter"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCityRecords = `{
+	"1.2.0.0/16": {"city": {"geoNameID": 625144, "names": {"en": "Minsk"}}, "country": {"geoNameID": 630336, "isoCode": "BY"},
+		"location": {"latitude": 53.9, "longitude": 27.56667, "accuracyRadius": 50}, "subdivisions": [{"geoNameID": 625143, "isoCode": "HM"}]},
+	"1.2.3.0/24": {"city": {"geoNameID": 756135, "names": {"en": "Warsaw"}}, "country": {"geoNameID": 798544, "isoCode": "PL"}},
+	"1.2.3.128/25": {"location": {"accuracyRadius": 10}, "merge": true},
+	"2001:db8::/32": {"country": {"geoNameID": 6252001, "isoCode": "US"}, "traits": {"isAnonymousProxy": true}}
+}`
+
+func buildTestDB(t *testing.T, readers ...MMDBRecordReader) Database {
+	var buf bytes.Buffer
+	_, err := WriteMMDB(&buf, mmdbwriter.Options{IncludeReservedNetworks: true}, readers...)
+	require.NoError(t, err)
+	db, err := NewDatabasePatchFromMMDB("test.mmdb", buf.Bytes(), "")
+	require.NoError(t, err)
+	return db
+}
+
+func testDBRecords(t *testing.T, db Database) map[string]map[string]interface{} {
+	networks, err := db.Networks(context.Background(), maxminddb.SkipAliasedNetworks)
+	require.NoError(t, err)
+	res := make(map[string]map[string]interface{})
+	for networks.Next() {
+		var record map[string]interface{}
+		network, err := networks.Network(&record)
+		require.NoError(t, err)
+		res[network.String()] = record
+	}
+	require.NoError(t, networks.Err())
+	return res
+}
+
+func TestWriteMMDB(t *testing.T) {
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(testCityRecords))
+	require.NoError(t, err)
+	db := buildTestDB(t, reader)
+
+	var city entity.City
+	require.NoError(t, db.Lookup(context.Background(), net.ParseIP("1.2.3.200"), &city))
+	assert.Equal(t, "Warsaw", city.City.Names["en"], "the smaller network wins")
+	assert.Equal(t, uint16(10), city.Location.AccuracyRadius, "the partial record is merged")
+	require.NoError(t, db.Lookup(context.Background(), net.ParseIP("1.2.4.1"), &city))
+	assert.Equal(t, "BY", city.Country.IsoCode)
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(testCityRecords))
+	require.NoError(t, err)
+	db := buildTestDB(t, reader)
+
+	var dump bytes.Buffer
+	require.NoError(t, NewCSVDumper[entity.City](db).WriteCSVTo(ctx, &dump))
+	reader, err = NewCSVRecordReader[entity.City](bytes.NewReader(dump.Bytes()))
+	require.NoError(t, err)
+	var roundTrip bytes.Buffer
+	require.NoError(t, NewCSVDumper[entity.City](buildTestDB(t, reader)).WriteCSVTo(ctx, &roundTrip))
+
+	assert.Equal(t, dump.String(), roundTrip.String())
+}
+
+func TestNDJSONRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(testCityRecords))
+	require.NoError(t, err)
+	db := buildTestDB(t, reader)
+
+	var dump bytes.Buffer
+	require.NoError(t, WriteNDJSON[entity.City](ctx, db, &dump))
+	reader, err = NewNDJSONRecordReader[entity.City](bytes.NewReader(dump.Bytes()))
+	require.NoError(t, err)
+
+	assert.Equal(t, testDBRecords(t, db), testDBRecords(t, buildTestDB(t, reader)))
+}
+
+func TestGenericRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	reader, err := NewNDJSONRecordReader[GenericRecord](strings.NewReader(`
+		{"network": "10.0.0.0/8", "owner": {"name": "office", "floor": 3}, "tags": ["lan", "wifi"], "weight": 0.5, "offset": -2}
+		{"network": "10.1.0.0/16", "owner": {"name": "lab"}, "comment": "not stored"}
+	`))
+	require.NoError(t, err)
+	db := buildTestDB(t, reader)
+	records := testDBRecords(t, db)
+	assert.Equal(t, map[string]interface{}{"name": "lab"}, records["10.1.0.0/16"]["owner"])
+	var office map[string]interface{}
+	require.NoError(t, db.Lookup(ctx, net.ParseIP("10.0.0.1"), &office))
+	assert.Equal(t, -2, office["offset"])
+	assert.Equal(t, []interface{}{"lan", "wifi"}, office["tags"])
+
+	var dump bytes.Buffer
+	require.NoError(t, WriteNDJSON[map[string]interface{}](ctx, db, &dump))
+	reader, err = NewNDJSONRecordReader[GenericRecord](bytes.NewReader(dump.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, records, testDBRecords(t, buildTestDB(t, reader)))
+
+	csvReader, err := NewGenericCSVRecordReader(strings.NewReader("network,owner.name,country.iso_code\n10.0.0.0/8,office,BY\n10.1.0.0/16,lab,\n"))
+	require.NoError(t, err)
+	db = buildTestDB(t, csvReader)
+	var csvDump bytes.Buffer
+	require.NoError(t, WriteGenericCSV(ctx, db, &csvDump))
+	csvReader, err = NewGenericCSVRecordReader(bytes.NewReader(csvDump.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, testDBRecords(t, db), testDBRecords(t, buildTestDB(t, csvReader)))
+}
+
+func TestMMDBPatchDatabaseType(t *testing.T) {
+	mmdb := func(databaseType string) []byte {
+		reader, err := NewJSONRecordReader[entity.City](strings.NewReader(testCityRecords))
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, err = WriteMMDB(&buf, mmdbwriter.Options{DatabaseType: databaseType, IncludeReservedNetworks: true}, reader)
+		require.NoError(t, err)
+		return buf.Bytes()
+	}
+
+	for _, databaseType := range []string{"GeoIP2-City", "GeoLite2-City"} {
+		_, err := NewDatabasePatchFromContent[entity.City]("patch.mmdb", mmdb(databaseType))
+		assert.NoError(t, err, databaseType)
+	}
+	_, err := NewDatabasePatchFromContent[entity.ISP]("patch.mmdb", mmdb("GeoIP2-City"))
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+	_, err = NewDatabasePatchFromContent[entity.City]("patch.mmdb", mmdb(""))
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+}
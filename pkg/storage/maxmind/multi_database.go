@@ -33,20 +33,54 @@ func (db *MultiMaxMindDB) Add(dbs ...Database) *MultiMaxMindDB {
 	return db
 }
 
+// Lookup decodes the record of the top layer that has the IP.
+// The partial records of the layers above it are decoded on top of it, see MergingDatabase.
 func (db *MultiMaxMindDB) Lookup(ctx context.Context, ip net.IP, result interface{}) error {
 	var multiErr error
+	var partial []Database
 	for i := len(db.dbs) - 1; i >= 0; i-- {
+		if mergesAt(ctx, db.dbs[i], ip) {
+			partial = append(partial, db.dbs[i])
+			continue
+		}
 		err := db.dbs[i].Lookup(ctx, ip, result)
 		if err == nil {
-			return nil
+			return db.lookupPartial(ctx, partial, ip, result)
 		}
 		multiErr = errors.Join(multiErr, err)
 	}
+	if len(partial) > 0 {
+		return db.lookupPartial(ctx, partial, ip, result)
+	}
 	return errors.Join(utils.ErrNotFound, multiErr)
 }
 
-func (db *MultiMaxMindDB) dbReader(ctx context.Context, index int) (*maxminddb.Reader, error) {
-	database := db.dbs[index]
+// lookupPartial decodes the partial records from the bottom layer to the top one
+func (db *MultiMaxMindDB) lookupPartial(ctx context.Context, partial []Database, ip net.IP, result interface{}) error {
+	for i := len(partial) - 1; i >= 0; i-- {
+		if err := partial[i].Lookup(ctx, ip, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergesAt reports whether the layers have only the partial records of the IP, so the result is partial too
+func (db *MultiMaxMindDB) MergesAt(ctx context.Context, ip net.IP) bool {
+	merges := false
+	for i := len(db.dbs) - 1; i >= 0; i-- {
+		if mergesAt(ctx, db.dbs[i], ip) {
+			merges = true
+			continue
+		}
+		if err := db.dbs[i].Lookup(ctx, ip, &struct{}{}); err == nil {
+			return false
+		}
+	}
+	return merges
+}
+
+func (db *MultiMaxMindDB) dbReader(ctx context.Context, database Database) (*maxminddb.Reader, error) {
 	reader, err := database.RawData(ctx)
 	if err != nil {
 		return nil, err
@@ -101,6 +135,9 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 	}
 
 	opts := mmdbwriter.Options{IncludeReservedNetworks: true, DisableIPv4Aliasing: true}
+	if meta, err := db.MetaData(ctx); err == nil {
+		opts.DatabaseType, opts.Description, opts.Languages = meta.DatabaseType, meta.Description, meta.Languages
+	}
 	tree, err := mmdbwriter.New(opts)
 	if err != nil {
 		return nil, err
@@ -112,6 +149,7 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 	type networkNode struct {
 		network *net.IPNet
 		data    map[string]interface{}
+		merge   bool
 	}
 	const bufSize = 1000
 	readedNodeC := make(chan networkNode, bufSize)
@@ -120,8 +158,8 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 	var eg errgroup.Group
 	eg.Go(func() (err error) {
 		defer close(readedNodeC)
-		for i := range db.dbs {
-			dbReader, err := db.dbReader(ctx, i)
+		for _, database := range nonEmtpyDbs {
+			dbReader, err := db.dbReader(ctx, database)
 			if err != nil {
 				return err
 			}
@@ -133,6 +171,7 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 				if err != nil {
 					return err
 				}
+				node.merge = mergesAt(ctx, database, node.network.IP)
 				readedNodeC <- node
 			}
 			if err := networks.Err(); err != nil {
@@ -148,6 +187,7 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 			var rec MMDBRecord
 			rec.Network = node.network
 			rec.Data, _ = toMMDBType(node.data).(mmdbtype.Map)
+			rec.Merge = node.merge
 			convertedNodeC <- rec
 		}
 		return nil
@@ -155,7 +195,11 @@ func (db *MultiMaxMindDB) RawData(ctx context.Context) (io.Reader, error) {
 
 	eg.Go(func() error {
 		for convertedNode := range convertedNodeC {
-			err = tree.InsertFunc(convertedNode.Network, inserter.ReplaceWith(convertedNode.Data))
+			insert := inserter.ReplaceWith(convertedNode.Data)
+			if convertedNode.Merge {
+				insert = mergeWith(convertedNode.Data)
+			}
+			err = tree.InsertFunc(convertedNode.Network, insert)
 			if err != nil {
 				log.FromContext(ctx).WarnWithFields(log.Fields{"err": err}, "failed to insert network")
 				continue
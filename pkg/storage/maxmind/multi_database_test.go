@@ -0,0 +1,105 @@
+package maxmind
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPatch(t *testing.T, records string) Database {
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(records))
+	require.NoError(t, err)
+	patch, err := NewDatabasePatch(reader)
+	require.NoError(t, err)
+	return patch
+}
+
+func newTestMultiDB(t *testing.T) *MultiMaxMindDB {
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(`{
+		"1.2.0.0/16": {"city": {"geoNameID": 625144, "names": {"en": "Minsk", "de": "Minsk"}}, "country": {"geoNameID": 630336, "isoCode": "BY"},
+			"location": {"latitude": 53.9, "longitude": 27.56667, "accuracyRadius": 50},
+			"subdivisions": [{"geoNameID": 625143, "isoCode": "HM"}, {"geoNameID": 625144, "isoCode": "MI"}]},
+		"5.6.0.0/16": {"country": {"geoNameID": 798544, "isoCode": "PL"}, "location": {"accuracyRadius": 100}}
+	}`))
+	require.NoError(t, err)
+	base := buildTestDB(t, reader)
+
+	partial := newTestPatch(t, `{
+		"1.2.3.0/24": {"city": {"names": {"ru": "Минск"}}, "subdivisions": [{"isoCode": "XX"}], "merge": true},
+		"9.9.9.0/24": {"country": {"isoCode": "US"}, "merge": true}
+	}`)
+	full := newTestPatch(t, `{
+		"5.6.7.0/24": {"country": {"geoNameID": 2921044, "isoCode": "DE"}},
+		"1.2.3.128/25": {"location": {"accuracyRadius": 5}, "merge": true}
+	}`)
+	return NewMultiMaxMindDB(base, partial, full)
+}
+
+func TestMultiDatabaseLookup(t *testing.T) {
+	db := newTestMultiDB(t)
+	ctx := context.Background()
+	lookup := func(ip string) entity.City {
+		var city entity.City
+		require.NoError(t, db.Lookup(ctx, net.ParseIP(ip), &city))
+		return city
+	}
+
+	city := lookup("1.2.3.4")
+	assert.Equal(t, map[string]string{"en": "Minsk", "de": "Minsk", "ru": "Минск"}, city.City.Names, "nested maps are merged")
+	assert.Equal(t, uint(625144), city.City.GeoNameID, "the partial record keeps the rest of the full one")
+	assert.Equal(t, "BY", city.Country.IsoCode)
+	assert.Equal(t, uint16(50), city.Location.AccuracyRadius)
+	require.Len(t, city.Subdivisions, 1, "arrays are replaced")
+	assert.Equal(t, "XX", city.Subdivisions[0].IsoCode)
+	assert.Zero(t, city.Subdivisions[0].GeoNameID)
+
+	city = lookup("1.2.3.200")
+	assert.Equal(t, uint16(5), city.Location.AccuracyRadius, "the partial records of several layers are merged")
+	assert.Equal(t, "Минск", city.City.Names["ru"])
+	assert.Equal(t, 53.9, city.Location.Latitude)
+
+	city = lookup("1.2.4.1")
+	assert.Len(t, city.Subdivisions, 2, "outside of the patches")
+	assert.NotContains(t, city.City.Names, "ru")
+
+	city = lookup("5.6.7.8")
+	assert.Equal(t, "DE", city.Country.IsoCode, "the full record replaces the record")
+	assert.Zero(t, city.Location.AccuracyRadius)
+
+	city = lookup("9.9.9.9")
+	assert.Equal(t, "US", city.Country.IsoCode, "a partial record without a full one")
+	assert.True(t, db.MergesAt(ctx, net.ParseIP("9.9.9.9")))
+	assert.False(t, db.MergesAt(ctx, net.ParseIP("1.2.3.4")))
+
+	var record map[string]interface{}
+	assert.ErrorIs(t, db.Lookup(ctx, net.ParseIP("8.8.8.8"), &record), utils.ErrNotFound)
+}
+
+func TestMultiDatabaseRawDataMatchesLookup(t *testing.T) {
+	db := newTestMultiDB(t)
+	ctx := context.Background()
+	raw, err := db.RawData(ctx)
+	require.NoError(t, err)
+	reader, err := maxminddb.FromBytes(raw.(*bytes.Buffer).Bytes())
+	require.NoError(t, err)
+
+	for _, ip := range []string{"1.2.0.1", "1.2.3.4", "1.2.3.127", "1.2.3.128", "1.2.3.255", "1.2.4.1", "5.6.0.1", "5.6.7.8", "9.9.9.9"} {
+		var want, got entity.City
+		require.NoError(t, db.Lookup(ctx, net.ParseIP(ip), &want), ip)
+		require.NoError(t, reader.Lookup(net.ParseIP(ip), &got), ip)
+		assert.Equal(t, want, got, ip)
+	}
+
+	var record map[string]interface{}
+	_, ok, err := reader.LookupNetwork(net.ParseIP("8.8.8.8"), &record)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
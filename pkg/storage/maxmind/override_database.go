@@ -0,0 +1,491 @@
+package maxmind
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/bldsoft/gost/log"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+type MMDBEntity interface {
+	ToMMDBType() mmdbtype.Map
+}
+
+type overrideJournalOp string
+
+const (
+	overrideJournalPut    overrideJournalOp = "put"
+	overrideJournalDelete overrideJournalOp = "delete"
+	// overrideJournalCompact starts the compacted journal, it keeps the version
+	overrideJournalCompact overrideJournalOp = "compact"
+)
+
+type overrideJournalEntry struct {
+	Op       overrideJournalOp     `json:"op"`
+	At       time.Time             `json:"at"` // the version of the overrides after the change
+	ID       string                `json:"id,omitempty"`
+	Override *entity.GeoIPOverride `json:"override,omitempty"` // put only
+}
+
+// overridesPatchName is the name of the overrides layer in the patch analysis
+const overridesPatchName = "overrides"
+
+// overrideExpiryRetryPeriod is the delay of the next removal of the expired overrides if it fails
+const overrideExpiryRetryPeriod = time.Minute
+
+// OverrideDatabase is the top layer of the patched db: the network records set at runtime.
+// The changes are appended to the journal file, it's replayed and compacted when the service starts.
+// The overrides are removed when they expire, see RemoveExpired.
+type OverrideDatabase struct {
+	journalPath string
+	toMMDB      func(record json.RawMessage) (mmdbtype.Map, error)
+
+	mtx       sync.Mutex
+	journal   *os.File
+	overrides map[string]*entity.GeoIPOverride // by ID
+	version   source.ModTimeVersion            // the last change
+	expiry    *time.Timer                      // removes the overrides that expire first
+	closed    bool
+
+	db atomic.Pointer[DatabasePatch] // nil if there are no active overrides
+}
+
+func NewOverrideDatabase[T MMDBEntity](ctx context.Context, journalPath string) (*OverrideDatabase, error) {
+	db := &OverrideDatabase{
+		journalPath: journalPath,
+		toMMDB: func(record json.RawMessage) (mmdbtype.Map, error) {
+			var obj T
+			dec := json.NewDecoder(bytes.NewReader(record))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&obj); err != nil {
+				return nil, fmt.Errorf("record: %w: %w", utils.ErrInvalidArgument, err)
+			}
+			return obj.ToMMDBType(), nil
+		},
+		overrides: make(map[string]*entity.GeoIPOverride),
+	}
+	if err := db.replay(ctx); err != nil {
+		return nil, err
+	}
+	if err := db.compact(); err != nil {
+		return nil, err
+	}
+	patch, err := db.build(db.overrides, db.version)
+	if err != nil {
+		return nil, err
+	}
+	db.swap(db.overrides, db.version, patch)
+	return db, nil
+}
+
+// replay reads the journal. A broken entry (e.g. the last one written partially) stops the replay.
+func (db *OverrideDatabase) replay(ctx context.Context) error {
+	f, err := os.Open(db.journalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var e overrideJournalEntry
+		if err := dec.Decode(&e); err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.FromContext(ctx).WarnWithFields(log.Fields{"err": err, "journal": db.journalPath}, "The override journal is broken, the rest of it is skipped")
+			}
+			return nil
+		}
+		switch e.Op {
+		case overrideJournalPut:
+			if e.Override == nil {
+				break
+			}
+			// the entries written before the layer was checked may be invalid
+			if _, err := db.record(e.Override); err != nil {
+				log.FromContext(ctx).WarnWithFields(log.Fields{"err": err, "id": e.Override.ID}, "The invalid override is skipped")
+				break
+			}
+			db.overrides[e.Override.ID] = e.Override
+		case overrideJournalDelete:
+			delete(db.overrides, e.ID)
+		}
+		if source.ModTimeVersion(e.At).Compare(db.version) > 0 {
+			db.version = source.ModTimeVersion(e.At)
+		}
+	}
+}
+
+// compact rewrites the journal with the current overrides and opens it for appending
+func (db *OverrideDatabase) compact() error {
+	if err := os.MkdirAll(filepath.Dir(db.journalPath), 0755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(overrideJournalEntry{Op: overrideJournalCompact, At: time.Time(db.version)}); err != nil {
+		return err
+	}
+	for _, o := range db.sorted() {
+		if err := enc.Encode(overrideJournalEntry{Op: overrideJournalPut, At: time.Time(db.version), ID: o.ID, Override: o}); err != nil {
+			return err
+		}
+	}
+
+	temp := db.journalPath + ".tmp"
+	if err := os.WriteFile(temp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, db.journalPath); err != nil {
+		return err
+	}
+	journal, err := os.OpenFile(db.journalPath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	db.journal = journal
+	return nil
+}
+
+// write appends the entries to the journal at once
+func (db *OverrideDatabase) write(entries ...overrideJournalEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	if _, err := db.journal.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return db.journal.Sync()
+}
+
+// nextVersion is strictly after the current one. The versions are compared in seconds, so the changes made
+// within a second get the next seconds.
+func (db *OverrideDatabase) nextVersion(now time.Time) time.Time {
+	next := now.Truncate(time.Second)
+	if last := time.Time(db.version); !next.After(last) {
+		next = last.Truncate(time.Second).Add(time.Second)
+	}
+	return next
+}
+
+// build builds the layer of the active overrides, the more specific networks win. It's nil if there are no active overrides.
+func (db *OverrideDatabase) build(overrides map[string]*entity.GeoIPOverride, version source.ModTimeVersion) (*DatabasePatch, error) {
+	now := time.Now()
+	records := make([]MMDBRecord, 0, len(overrides))
+	for _, o := range overrides {
+		if o.Expired(now) {
+			continue
+		}
+		rec, err := db.record(o)
+		if err != nil {
+			return nil, fmt.Errorf("override %s: %w", o.ID, err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	patch, err := NewDatabasePatch(newSortedRecordReader(records))
+	if err != nil {
+		return nil, err
+	}
+	patch.name = overridesPatchName
+	meta := patch.db.Metadata
+	meta.Description = map[string]string{"en": "runtime overrides"}
+	meta.BuildEpoch = uint(time.Time(version).Unix())
+	return patch.WithMetadata(meta), nil
+}
+
+// swap sets the overrides and their layer after the change is written to the journal
+func (db *OverrideDatabase) swap(overrides map[string]*entity.GeoIPOverride, version source.ModTimeVersion, patch *DatabasePatch) {
+	db.overrides = overrides
+	db.version = version
+	db.db.Store(patch)
+	db.scheduleExpiry(db.nextExpiry())
+}
+
+// nextExpiry is the earliest expiration time of the active overrides, it's zero if they don't expire
+func (db *OverrideDatabase) nextExpiry() time.Time {
+	now := time.Now()
+	var next time.Time
+	for _, o := range db.overrides {
+		if o.ExpiresAt != nil && !o.Expired(now) && (next.IsZero() || o.ExpiresAt.Before(next)) {
+			next = *o.ExpiresAt
+		}
+	}
+	return next
+}
+
+// scheduleExpiry removes the expired overrides at the time, the lookups don't check the expiration
+func (db *OverrideDatabase) scheduleExpiry(at time.Time) {
+	if db.expiry != nil {
+		db.expiry.Stop()
+		db.expiry = nil
+	}
+	if at.IsZero() || db.closed {
+		return
+	}
+	db.expiry = time.AfterFunc(time.Until(at), func() {
+		ctx := context.Background()
+		if err := db.RemoveExpired(ctx); err != nil {
+			log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "Failed to remove the expired overrides")
+			db.mtx.Lock()
+			defer db.mtx.Unlock()
+			db.scheduleExpiry(time.Now().Add(overrideExpiryRetryPeriod))
+		}
+	})
+}
+
+func (db *OverrideDatabase) record(o *entity.GeoIPOverride) (MMDBRecord, error) {
+	_, network, err := net.ParseCIDR(o.Network)
+	if err != nil {
+		return MMDBRecord{}, fmt.Errorf("network: %w: %w", utils.ErrInvalidArgument, err)
+	}
+	data, err := db.toMMDB(o.Record)
+	if err != nil {
+		return MMDBRecord{}, err
+	}
+	return MMDBRecord{Network: network, Data: data}, nil
+}
+
+func (db *OverrideDatabase) sorted() []*entity.GeoIPOverride {
+	res := make([]*entity.GeoIPOverride, 0, len(db.overrides))
+	for _, o := range db.overrides {
+		res = append(res, o)
+	}
+	slices.SortFunc(res, func(a, b *entity.GeoIPOverride) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return res
+}
+
+// List returns the overrides in the order they were created, the expired ones included
+func (db *OverrideDatabase) List(ctx context.Context) []*entity.GeoIPOverride {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.sorted()
+}
+
+func (db *OverrideDatabase) Get(ctx context.Context, id string) (*entity.GeoIPOverride, error) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	o, ok := db.overrides[id]
+	if !ok {
+		return nil, fmt.Errorf("override %s: %w", id, utils.ErrNotFound)
+	}
+	return o, nil
+}
+
+// Add stores the override with a new ID. The network can't have several overrides.
+func (db *OverrideDatabase) Add(ctx context.Context, o entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	o.ID = hex.EncodeToString(id)
+	o.CreatedAt = time.Time{}
+	return db.put(o)
+}
+
+// Replace replaces the override with the ID, the creation time is kept
+func (db *OverrideDatabase) Replace(ctx context.Context, id string, o entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	existing, ok := db.overrides[id]
+	if !ok {
+		return nil, fmt.Errorf("override %s: %w", id, utils.ErrNotFound)
+	}
+	o.ID = id
+	o.CreatedAt = existing.CreatedAt
+	return db.put(o)
+}
+
+func (db *OverrideDatabase) put(o entity.GeoIPOverride) (*entity.GeoIPOverride, error) {
+	rec, err := db.record(&o)
+	if err != nil {
+		return nil, err
+	}
+	o.Network = rec.Network.String()
+	for _, existing := range db.overrides {
+		if existing.ID != o.ID && existing.Network == o.Network {
+			return nil, fmt.Errorf("network %s is overridden by %s: %w", o.Network, existing.ID, utils.ErrAlreadyExists)
+		}
+	}
+
+	now := time.Now()
+	o.UpdatedAt = now
+	if o.CreatedAt.IsZero() {
+		o.CreatedAt = now
+	}
+	at := db.nextVersion(now)
+	overrides := maps.Clone(db.overrides)
+	overrides[o.ID] = &o
+	// the layer is built before the change is journaled, so the journal has only the valid overrides
+	patch, err := db.build(overrides, source.ModTimeVersion(at))
+	if err != nil {
+		return nil, err
+	}
+	if err := db.write(overrideJournalEntry{Op: overrideJournalPut, At: at, ID: o.ID, Override: &o}); err != nil {
+		return nil, err
+	}
+	db.swap(overrides, source.ModTimeVersion(at), patch)
+	return &o, nil
+}
+
+func (db *OverrideDatabase) Remove(ctx context.Context, id string) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if _, ok := db.overrides[id]; !ok {
+		return fmt.Errorf("override %s: %w", id, utils.ErrNotFound)
+	}
+	return db.remove(id)
+}
+
+func (db *OverrideDatabase) remove(ids ...string) error {
+	at := db.nextVersion(time.Now())
+	overrides := maps.Clone(db.overrides)
+	entries := make([]overrideJournalEntry, 0, len(ids))
+	for _, id := range ids {
+		delete(overrides, id)
+		entries = append(entries, overrideJournalEntry{Op: overrideJournalDelete, At: at, ID: id})
+	}
+	patch, err := db.build(overrides, source.ModTimeVersion(at))
+	if err != nil {
+		return err
+	}
+	if err := db.write(entries...); err != nil {
+		return err
+	}
+	db.swap(overrides, source.ModTimeVersion(at), patch)
+	return nil
+}
+
+// RemoveExpired deletes the expired overrides. It's called when the first of them expires and on each update.
+func (db *OverrideDatabase) RemoveExpired(ctx context.Context) error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	now := time.Now()
+	var expired []string
+	for id, o := range db.overrides {
+		if o.Expired(now) {
+			expired = append(expired, id)
+		}
+	}
+	if len(expired) == 0 {
+		// the timer may fire early if the clock is changed
+		db.scheduleExpiry(db.nextExpiry())
+		return nil
+	}
+	log.FromContext(ctx).InfoWithFields(log.Fields{"ids": expired}, "Removing expired overrides")
+	return db.remove(expired...)
+}
+
+// Expiring returns the active overrides that expire before the time
+func (db *OverrideDatabase) Expiring(until time.Time) []*entity.ExpiringGeoIPRecord {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	now := time.Now()
+	var res []*entity.ExpiringGeoIPRecord
+	for _, o := range db.sorted() {
+		if o.ExpiresAt == nil || o.Expired(now) || o.ExpiresAt.After(until) {
+			continue
+		}
+		res = append(res, &entity.ExpiringGeoIPRecord{
+			Network:    o.Network,
+			ValidUntil: *o.ExpiresAt,
+			Comment:    o.Reason,
+			OverrideID: o.ID,
+			Author:     o.Author,
+		})
+	}
+	return res
+}
+
+func (db *OverrideDatabase) Version() source.ModTimeVersion {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.version
+}
+
+// Close stops the removal of the expired overrides and closes the journal
+func (db *OverrideDatabase) Close() error {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	db.closed = true
+	db.scheduleExpiry(time.Time{})
+	return db.journal.Close()
+}
+
+// patch returns nil if there are no active overrides
+func (db *OverrideDatabase) patch() *DatabasePatch {
+	return db.db.Load()
+}
+
+func (db *OverrideDatabase) Lookup(ctx context.Context, ip net.IP, result interface{}) error {
+	patch := db.db.Load()
+	if patch == nil {
+		return utils.ErrNotFound
+	}
+	return patch.Lookup(ctx, ip, result)
+}
+
+func (db *OverrideDatabase) Networks(ctx context.Context, options ...maxminddb.NetworksOption) (*maxminddb.Networks, error) {
+	patch := db.db.Load()
+	if patch == nil {
+		return nil, ErrNoDatabases
+	}
+	return patch.Networks(ctx, options...)
+}
+
+func (db *OverrideDatabase) RawData(ctx context.Context) (io.Reader, error) {
+	patch := db.db.Load()
+	if patch == nil {
+		return nil, ErrNoDatabases
+	}
+	return patch.RawData(ctx)
+}
+
+// MetaData of the empty layer has no nodes, so the layer is skipped by the merge
+func (db *OverrideDatabase) MetaData(ctx context.Context) (*maxminddb.Metadata, error) {
+	patch := db.db.Load()
+	if patch == nil {
+		return &maxminddb.Metadata{}, nil
+	}
+	return patch.MetaData(ctx)
+}
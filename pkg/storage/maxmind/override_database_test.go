@@ -0,0 +1,156 @@
+package maxmind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOverrides(t *testing.T, path string) *OverrideDatabase {
+	db, err := NewOverrideDatabase[entity.City](context.Background(), path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func testOverride(network, isoCode string) entity.GeoIPOverride {
+	return entity.GeoIPOverride{
+		Network: network,
+		Record:  json.RawMessage(`{"country": {"isoCode": "` + isoCode + `"}}`),
+		Author:  "test",
+		Reason:  "test",
+	}
+}
+
+func overrideCountry(t *testing.T, db *OverrideDatabase, ip string) (string, error) {
+	var record struct {
+		Country struct {
+			IsoCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+	}
+	err := db.Lookup(context.Background(), net.ParseIP(ip), &record)
+	return record.Country.IsoCode, err
+}
+
+func journalLines(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var res []string
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		res = append(res, string(line))
+	}
+	return res
+}
+
+func TestOverrideExpires(t *testing.T) {
+	db := newTestOverrides(t, filepath.Join(t.TempDir(), "overrides.jsonl"))
+	ctx := context.Background()
+
+	expiring := testOverride("1.2.3.0/24", "BY")
+	expiresAt := time.Now().Add(300 * time.Millisecond)
+	expiring.ExpiresAt = &expiresAt
+	_, err := db.Add(ctx, expiring)
+	require.NoError(t, err)
+	_, err = db.Add(ctx, testOverride("5.6.7.0/24", "PL"))
+	require.NoError(t, err)
+
+	country, err := overrideCountry(t, db, "1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "BY", country)
+
+	assert.Eventually(t, func() bool {
+		country, _ := overrideCountry(t, db, "1.2.3.4")
+		return country == ""
+	}, 3*time.Second, 20*time.Millisecond, "the expired override is removed without an update")
+	assert.Len(t, db.List(ctx), 1)
+	country, err = overrideCountry(t, db, "5.6.7.8")
+	require.NoError(t, err)
+	assert.Equal(t, "PL", country)
+}
+
+func TestOverrideJournalReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.jsonl")
+	db := newTestOverrides(t, path)
+	ctx := context.Background()
+
+	removed, err := db.Add(ctx, testOverride("1.2.3.0/24", "BY"))
+	require.NoError(t, err)
+	kept, err := db.Add(ctx, testOverride("5.6.7.0/24", "PL"))
+	require.NoError(t, err)
+	_, err = db.Replace(ctx, kept.ID, testOverride("5.6.7.0/24", "DE"))
+	require.NoError(t, err)
+	require.NoError(t, db.Remove(ctx, removed.ID))
+	version := db.Version()
+	assert.Len(t, journalLines(t, path), 5, "compact, put, put, put, delete")
+	require.NoError(t, db.Close())
+
+	db = newTestOverrides(t, path)
+	overrides := db.List(ctx)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, kept.ID, overrides[0].ID)
+	assert.Zero(t, version.Compare(db.Version()))
+	country, err := overrideCountry(t, db, "5.6.7.8")
+	require.NoError(t, err)
+	assert.Equal(t, "DE", country)
+	_, err = overrideCountry(t, db, "1.2.3.4")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	assert.Len(t, journalLines(t, path), 2, "the journal is compacted to the current overrides")
+}
+
+func TestOverrideFailedPutIsNotJournaled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.jsonl")
+	db := newTestOverrides(t, path)
+	ctx := context.Background()
+
+	_, err := db.Add(ctx, testOverride("1.2.3.0/24", "BY"))
+	require.NoError(t, err)
+	lines := journalLines(t, path)
+
+	invalid := testOverride("5.6.7.0/24", "PL")
+	invalid.Record = json.RawMessage(`{"unknown": 1}`)
+	_, err = db.Add(ctx, invalid)
+	assert.ErrorIs(t, err, utils.ErrInvalidArgument)
+	_, err = db.Add(ctx, testOverride("1.2.3.0/24", "PL"))
+	assert.ErrorIs(t, err, utils.ErrAlreadyExists)
+
+	assert.Equal(t, lines, journalLines(t, path))
+	assert.Len(t, db.List(ctx), 1)
+	require.NoError(t, db.Close())
+	assert.Len(t, newTestOverrides(t, path).List(ctx), 1)
+}
+
+func TestOverrideReplaySkipsInvalidEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.jsonl")
+	valid := testOverride("1.2.3.0/24", "BY")
+	valid.ID = "valid"
+	invalid := testOverride("5.6.7.0/24", "PL")
+	invalid.ID = "invalid"
+	invalid.Record = json.RawMessage(`{"unknown": 1}`)
+
+	var journal bytes.Buffer
+	enc := json.NewEncoder(&journal)
+	for _, o := range []entity.GeoIPOverride{valid, invalid} {
+		require.NoError(t, enc.Encode(overrideJournalEntry{Op: overrideJournalPut, At: time.Now(), ID: o.ID, Override: &o}))
+	}
+	journal.WriteString(`{"op": "put", "at": "2024-`) // written partially
+	require.NoError(t, os.WriteFile(path, journal.Bytes(), 0644))
+
+	db := newTestOverrides(t, path)
+	overrides := db.List(context.Background())
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "valid", overrides[0].ID)
+	country, err := overrideCountry(t, db, "1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "BY", country)
+}
@@ -0,0 +1,168 @@
+package maxmind
+
+import (
+	"context"
+	"net"
+	"reflect"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// AnalyzePatches reports the networks of the patches that overlap the networks of the other patches
+// or don't change the upstream records. The patches are from the bottom layer to the top one,
+// upstream is optional. The reported networks are limited if limit is positive.
+func AnalyzePatches(ctx context.Context, upstream NetworkDatabase, limit int, patches ...*DatabasePatch) (*entity.PatchAnalysis, error) {
+	res := &entity.PatchAnalysis{Networks: []*entity.PatchNetworkAnalysis{}}
+	for _, patch := range patches {
+		res.Patches = append(res.Patches, patch.name)
+	}
+
+	for i, patch := range patches {
+		networks := patch.db.Networks(maxminddb.SkipAliasedNetworks)
+		for networks.Next() {
+			var data map[string]interface{}
+			network, err := networks.Network(&data)
+			if err != nil {
+				return nil, err
+			}
+			analysis, err := analyzePatchNetwork(ctx, upstream, patches, i, network, data)
+			if err != nil {
+				return nil, err
+			}
+
+			res.Total++
+			if len(analysis.Overlaps) > 0 {
+				res.Overlaps++
+			}
+			if analysis.Winner != analysis.Patch {
+				res.Shadowed++
+			}
+			if analysis.NoOp {
+				res.NoOps++
+			}
+			if len(analysis.Overlaps) == 0 && !analysis.NoOp {
+				continue
+			}
+			if limit > 0 && len(res.Networks) >= limit {
+				res.Truncated = true
+				continue
+			}
+			res.Networks = append(res.Networks, analysis)
+		}
+		if err := networks.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func analyzePatchNetwork(
+	ctx context.Context,
+	upstream NetworkDatabase,
+	patches []*DatabasePatch,
+	i int,
+	network *net.IPNet,
+	data map[string]interface{},
+) (*entity.PatchNetworkAnalysis, error) {
+	patch := patches[i]
+	res := &entity.PatchNetworkAnalysis{
+		Patch:   patch.name,
+		Network: network.String(),
+		Merge:   patch.MergesAt(ctx, network.IP),
+		Winner:  patch.name,
+	}
+
+	for j, other := range patches {
+		if j == i {
+			continue
+		}
+		overlaps, err := other.overlapping(network)
+		if err != nil {
+			return nil, err
+		}
+		for _, overlap := range overlaps {
+			res.Overlaps = append(res.Overlaps, entity.PatchNetwork{Patch: other.name, Network: overlap.String()})
+			if j > i && containsNetwork(overlap, network) {
+				res.Winner = other.name
+			}
+		}
+	}
+
+	if upstream == nil {
+		return res, nil
+	}
+	var upstreamData map[string]interface{}
+	upstreamNetwork, ok, err := upstream.LookupNetwork(ctx, network.IP, &upstreamData)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		res.Upstream = upstreamNetwork.String()
+		// the empty full record removes the upstream one
+		res.NoOp = containsNetwork(upstreamNetwork, network) && containsData(upstreamData, data) &&
+			(res.Merge || !isZeroData(data))
+	}
+	return res, nil
+}
+
+// overlapping returns the network of the patch that contains the network, or the ones within it
+func (db *DatabasePatch) overlapping(network *net.IPNet) ([]*net.IPNet, error) {
+	containing, ok, err := db.db.LookupNetwork(network.IP, &struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	if ok && containsNetwork(containing, network) {
+		return []*net.IPNet{containing}, nil
+	}
+
+	var res []*net.IPNet
+	networks := db.db.NetworksWithin(network, maxminddb.SkipAliasedNetworks)
+	for networks.Next() {
+		within, err := networks.Network(&struct{}{})
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, within)
+	}
+	return res, networks.Err()
+}
+
+// containsNetwork reports whether the network a contains the whole network b, a is the network of b.IP
+func containsNetwork(a, b *net.IPNet) bool {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+	return aOnes <= bOnes
+}
+
+// containsData reports whether the upstream record has all the values of the patch record, the zero values are skipped
+func containsData(upstream, value interface{}) bool {
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return isZeroData(value) || reflect.DeepEqual(upstream, value)
+	}
+	upstreamMap, _ := upstream.(map[string]interface{})
+	for key, v := range valueMap {
+		if !containsData(upstreamMap[key], v) {
+			return false
+		}
+	}
+	return true
+}
+
+func isZeroData(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for _, v := range value {
+			if !isZeroData(v) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		return len(value) == 0
+	}
+	return reflect.ValueOf(value).IsZero()
+}
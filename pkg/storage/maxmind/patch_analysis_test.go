@@ -0,0 +1,113 @@
+package maxmind
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNamedPatch(t *testing.T, name, records string) *DatabasePatch {
+	patch, err := NewDatabasePatchFromContent[entity.CityPatchRecord](name, []byte(records))
+	require.NoError(t, err)
+	return patch
+}
+
+// newTestPatchLayers returns the upstream db and the patches from the bottom layer to the top one
+func newTestPatchLayers(t *testing.T) (*MaxmindDatabase, []*DatabasePatch) {
+	upstream := newTestMaxmindDatabase(t, `{
+		"1.2.0.0/16": {"city": {"geoNameID": 625144, "names": {"en": "Minsk"}}, "country": {"geoNameID": 630336, "isoCode": "BY"}},
+		"5.6.0.0/16": {"country": {"geoNameID": 798544, "isoCode": "PL"}}
+	}`)
+	return upstream, []*DatabasePatch{
+		newTestNamedPatch(t, "a.json", `{
+			"1.2.3.0/24": {"country": {"geoNameID": 630336, "isoCode": "BY"}},
+			"5.6.7.0/24": {"country": {"isoCode": "DE"}},
+			"9.9.9.0/24": {"country": {"isoCode": "US"}}
+		}`),
+		newTestNamedPatch(t, "b.json", `{
+			"1.2.3.128/25": {"city": {"names": {"en": "Minsk"}}, "merge": true},
+			"1.2.4.0/24": {"city": {"names": {"ru": "Минск"}}, "merge": true}
+		}`),
+		newTestNamedPatch(t, "c.json", `{
+			"5.6.0.0/16": {"country": {"isoCode": "UA"}}
+		}`),
+	}
+}
+
+func TestAnalyzePatches(t *testing.T) {
+	upstream, patches := newTestPatchLayers(t)
+	analysis, err := AnalyzePatches(context.Background(), upstream, 0, patches...)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.json", "b.json", "c.json"}, analysis.Patches)
+	assert.Equal(t, []*entity.PatchNetworkAnalysis{
+		{
+			Patch: "a.json", Network: "1.2.3.0/24", Upstream: "1.2.0.0/16", Winner: "a.json", NoOp: true,
+			Overlaps: []entity.PatchNetwork{{Patch: "b.json", Network: "1.2.3.128/25"}}, // the later partial network doesn't win
+		},
+		{
+			Patch: "a.json", Network: "5.6.7.0/24", Upstream: "5.6.0.0/16", Winner: "c.json",
+			Overlaps: []entity.PatchNetwork{{Patch: "c.json", Network: "5.6.0.0/16"}},
+		},
+		{
+			Patch: "b.json", Network: "1.2.3.128/25", Upstream: "1.2.0.0/16", Winner: "b.json", Merge: true, NoOp: true,
+			Overlaps: []entity.PatchNetwork{{Patch: "a.json", Network: "1.2.3.0/24"}},
+		},
+		{
+			Patch: "c.json", Network: "5.6.0.0/16", Upstream: "5.6.0.0/16", Winner: "c.json",
+			Overlaps: []entity.PatchNetwork{{Patch: "a.json", Network: "5.6.7.0/24"}},
+		},
+	}, analysis.Networks, "the networks without overlaps that change the upstream records aren't reported")
+	assert.Equal(t, 6, analysis.Total)
+	assert.Equal(t, 4, analysis.Overlaps)
+	assert.Equal(t, 1, analysis.Shadowed)
+	assert.Equal(t, 2, analysis.NoOps)
+	assert.False(t, analysis.Truncated)
+}
+
+func TestAnalyzePatchesLimit(t *testing.T) {
+	upstream, patches := newTestPatchLayers(t)
+	analysis, err := AnalyzePatches(context.Background(), upstream, 1, patches...)
+	require.NoError(t, err)
+	assert.Len(t, analysis.Networks, 1)
+	assert.True(t, analysis.Truncated)
+	assert.Equal(t, 6, analysis.Total, "the counts aren't limited")
+	assert.Equal(t, 2, analysis.NoOps)
+}
+
+func TestAnalyzePatchesWithoutUpstream(t *testing.T) {
+	_, patches := newTestPatchLayers(t)
+	analysis, err := AnalyzePatches(context.Background(), nil, 0, patches...)
+	require.NoError(t, err)
+	assert.Zero(t, analysis.NoOps)
+	for _, network := range analysis.Networks {
+		assert.Empty(t, network.Upstream)
+		assert.NotEmpty(t, network.Overlaps, "only the overlaps are reported")
+	}
+	assert.Len(t, analysis.Networks, 4)
+}
+
+func TestAnalyzePatchesNoOp(t *testing.T) {
+	upstream, _ := newTestPatchLayers(t)
+	tests := []struct {
+		name    string
+		records string
+		want    bool
+	}{
+		{"the same values", `{"1.2.3.0/24": {"country": {"isoCode": "BY"}}}`, true},
+		{"another value", `{"1.2.3.0/24": {"country": {"isoCode": "PL"}}}`, false},
+		{"a new value", `{"1.2.3.0/24": {"city": {"names": {"ru": "Минск"}}, "merge": true}}`, false},
+		{"the same values of the merge record", `{"1.2.3.0/24": {"city": {"names": {"en": "Minsk"}}, "merge": true}}`, true},
+		{"the network is wider than the upstream one", `{"1.0.0.0/8": {"country": {"isoCode": "BY"}}}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := AnalyzePatches(context.Background(), upstream, 0, newTestNamedPatch(t, "patch.json", tt.records))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, analysis.NoOps == 1)
+		})
+	}
+}
@@ -2,14 +2,17 @@ package maxmind
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/bldsoft/geos/pkg/entity"
 )
 
 type PatchedDatabase struct {
 	*MultiMaxMindDB
-	db     *MaxmindDatabase
-	custom *CustomDatabase
+	db        *MaxmindDatabase
+	custom    *CustomDatabase
+	overrides *OverrideDatabase
 }
 
 func NewPatchedDatabase(db *MaxmindDatabase) *PatchedDatabase {
@@ -19,11 +22,25 @@ func NewPatchedDatabase(db *MaxmindDatabase) *PatchedDatabase {
 	}
 }
 
+// SetCustom adds the MMDB patches on top of the db, the lookups return the patched records
 func (db *PatchedDatabase) SetCustom(custom *CustomDatabase) *PatchedDatabase {
 	db.custom = custom
+	db.MultiMaxMindDB.Add(custom)
 	return db
 }
 
+// SetOverrides adds the runtime overrides on top of the patches, so it's called after SetCustom
+func (db *PatchedDatabase) SetOverrides(overrides *OverrideDatabase) *PatchedDatabase {
+	db.overrides = overrides
+	db.MultiMaxMindDB.Add(overrides)
+	return db
+}
+
+// Overrides returns nil if the overrides aren't set
+func (db *PatchedDatabase) Overrides() *OverrideDatabase {
+	return db.overrides
+}
+
 func (db *PatchedDatabase) Update(ctx context.Context, force bool) error {
 	if err := db.db.Update(ctx, force); err != nil {
 		return err
@@ -35,6 +52,49 @@ func (db *PatchedDatabase) Update(ctx context.Context, force bool) error {
 	return nil
 }
 
+// PatchValidityChanged reports whether a patch record has become valid or expired since the last update,
+// the patches are rebuilt on the next update even if the sources haven't changed
+func (db *PatchedDatabase) PatchValidityChanged() bool {
+	return db.custom != nil && db.custom.ValidityChanged()
+}
+
+// RemoveExpiredOverrides is called on each update, the overrides don't depend on the update sources
+func (db *PatchedDatabase) RemoveExpiredOverrides(ctx context.Context) error {
+	if db.overrides == nil {
+		return nil
+	}
+	return db.overrides.RemoveExpired(ctx)
+}
+
+// Expiring returns the patch records and the runtime overrides that expire before the time, the earliest first
+func (db *PatchedDatabase) Expiring(until time.Time) []*entity.ExpiringGeoIPRecord {
+	var res []*entity.ExpiringGeoIPRecord
+	if db.custom != nil {
+		res = append(res, db.custom.Expiring(until)...)
+	}
+	if db.overrides != nil {
+		res = append(res, db.overrides.Expiring(until)...)
+	}
+	slices.SortStableFunc(res, func(a, b *entity.ExpiringGeoIPRecord) int {
+		return a.ValidUntil.Compare(b.ValidUntil)
+	})
+	return res
+}
+
+// AnalyzePatches reports the overlaps of the patches and the runtime overrides, see AnalyzePatches
+func (db *PatchedDatabase) AnalyzePatches(ctx context.Context, limit int) (*entity.PatchAnalysis, error) {
+	var patches []*DatabasePatch
+	if db.custom != nil {
+		patches = append(patches, db.custom.patches()...)
+	}
+	if db.overrides != nil {
+		if patch := db.overrides.patch(); patch != nil {
+			patches = append(patches, patch)
+		}
+	}
+	return AnalyzePatches(ctx, db.db, limit, patches...)
+}
+
 func (db *PatchedDatabase) CheckUpdates(ctx context.Context) (entity.Update[entity.PatchedMMDBVersion], error) {
 	dbUpdate, err := db.db.CheckUpdates(ctx)
 	if err != nil {
@@ -54,5 +114,11 @@ func (db *PatchedDatabase) CheckUpdates(ctx context.Context) (entity.Update[enti
 		res.RemoteVersion.Patch = (*entity.ModTimeVersion)(&customUpdate.RemoteVersion)
 	}
 
+	if db.overrides != nil {
+		version := entity.ModTimeVersion(db.overrides.Version())
+		res.CurrentVersion.Overrides = &version
+		res.RemoteVersion.Overrides = &version
+	}
+
 	return res, nil
 }
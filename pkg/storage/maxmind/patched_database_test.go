@@ -0,0 +1,55 @@
+package maxmind
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bldsoft/geos/pkg/entity"
+	"github.com/bldsoft/geos/pkg/storage/source"
+	"github.com/maxmind/mmdbwriter"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMaxmindDatabase(t *testing.T, records string) *MaxmindDatabase {
+	reader, err := NewJSONRecordReader[entity.City](strings.NewReader(records))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = WriteMMDB(&buf, mmdbwriter.Options{IncludeReservedNetworks: true}, reader)
+	require.NoError(t, err)
+	raw := buf.Bytes()
+	dbReader, err := maxminddb.FromBytes(raw)
+	require.NoError(t, err)
+
+	var db MaxmindDatabase
+	db.reader.Store(dbReader)
+	db.dbRaw.Store(&raw)
+	return &db
+}
+
+func TestPatchedDatabaseLooksUpPatches(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "patch.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"1.2.3.0/24": {"country": {"geoNameID": 2921044, "isoCode": "DE"}},
+		"1.2.4.0/24": {"city": {"names": {"ru": "Минск"}}, "merge": true}
+	}`), 0644))
+	db := NewPatchedDatabase(newTestMaxmindDatabase(t, `{
+		"1.2.0.0/16": {"city": {"geoNameID": 625144, "names": {"en": "Minsk"}}, "country": {"geoNameID": 630336, "isoCode": "BY"}}
+	}`)).SetCustom(NewCustomDatabase[entity.CityPatchRecord](ctx, source.NewTSUpdatableFile(path, "")))
+
+	lookup := func(ip string) entity.City {
+		var city entity.City
+		require.NoError(t, db.Lookup(ctx, net.ParseIP(ip), &city))
+		return city
+	}
+	assert.Equal(t, "DE", lookup("1.2.3.4").Country.IsoCode, "the patch record replaces the record")
+	assert.Equal(t, map[string]string{"en": "Minsk", "ru": "Минск"}, lookup("1.2.4.1").City.Names, "the merge record is merged")
+	assert.Equal(t, "BY", lookup("1.2.5.1").Country.IsoCode, "outside of the patch")
+}
@@ -0,0 +1,31 @@
+package maxmind
+
+import (
+	"cmp"
+	"io"
+	"slices"
+)
+
+type sliceRecordReader struct {
+	records []MMDBRecord
+}
+
+// newSortedRecordReader reads the larger networks first, so that the smaller ones are merged on top of them.
+// The records of the same size keep the order.
+func newSortedRecordReader(records []MMDBRecord) *sliceRecordReader {
+	slices.SortStableFunc(records, func(a, b MMDBRecord) int {
+		aOnes, _ := a.Network.Mask.Size()
+		bOnes, _ := b.Network.Mask.Size()
+		return cmp.Compare(aOnes, bOnes)
+	})
+	return &sliceRecordReader{records: records}
+}
+
+func (r *sliceRecordReader) ReadMMDBRecord() (MMDBRecord, error) {
+	if len(r.records) == 0 {
+		return MMDBRecord{}, io.EOF
+	}
+	rec := r.records[0]
+	r.records = r.records[1:]
+	return rec, nil
+}
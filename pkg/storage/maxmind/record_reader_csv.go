@@ -0,0 +1,127 @@
+package maxmind
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bldsoft/geos/pkg/utils"
+)
+
+// CSV patch columns of PatchRecordMeta, they follow the record columns and need the header
+const (
+	csvColumnValidFrom  = "valid_from"
+	csvColumnValidUntil = "valid_until"
+	csvColumnComment    = "comment"
+	csvColumnMerge      = "merge"
+)
+
+// NewCSVRecordReader reads the CSV rows of the network and the columns of T in the MarshalCSV order, like the CSV dump.
+// The header is optional, with the header the valid_from, valid_until (RFC 3339), comment and merge columns may follow.
+func NewCSVRecordReader[T PatchEntity](r io.Reader) (MMDBRecordReader, error) {
+	var zero T
+	if _, ok := any(&zero).(CSVUnmarshaler); !ok {
+		return nil, fmt.Errorf("%T CSV: %w", zero, utils.ErrUnknownFormat)
+	}
+	names, _, err := zero.MarshalCSV()
+	if err != nil {
+		return nil, err
+	}
+
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	firstRow := 1
+	metaColumns := make(map[int]string)
+	if len(rows) > 0 && len(rows[0]) > 0 && rows[0][0] == "network" {
+		if metaColumns, err = csvMetaColumns(rows[0], names); err != nil {
+			return nil, err
+		}
+		rows, firstRow = rows[1:], 2
+	}
+
+	records := make([]MMDBRecord, 0, len(rows))
+	for i, row := range rows {
+		record, err := csvRecord[T](row, len(names), metaColumns)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", firstRow+i, err)
+		}
+		records = append(records, record)
+	}
+	return newSortedRecordReader(records), nil
+}
+
+// csvMetaColumns checks the header and returns the indexes of the PatchRecordMeta columns
+func csvMetaColumns(header, names []string) (map[int]string, error) {
+	if len(header) < len(names)+1 {
+		return nil, fmt.Errorf("header: %d columns, at least %d expected: %w", len(header), len(names)+1, utils.ErrInvalidArgument)
+	}
+	for i, name := range names {
+		if !strings.EqualFold(header[i+1], name) {
+			return nil, fmt.Errorf("header: column %d is %q, %q expected: %w", i+2, header[i+1], name, utils.ErrInvalidArgument)
+		}
+	}
+	res := make(map[int]string)
+	for i := len(names) + 1; i < len(header); i++ {
+		switch header[i] {
+		case csvColumnValidFrom, csvColumnValidUntil, csvColumnComment, csvColumnMerge:
+			res[i] = header[i]
+		default:
+			return nil, fmt.Errorf("header: unknown column %q: %w", header[i], utils.ErrInvalidArgument)
+		}
+	}
+	return res, nil
+}
+
+func csvRecord[T PatchEntity](row []string, columns int, metaColumns map[int]string) (record MMDBRecord, err error) {
+	if len(row) != columns+1+len(metaColumns) {
+		return record, fmt.Errorf("%d columns, %d expected: %w", len(row), columns+1+len(metaColumns), utils.ErrInvalidArgument)
+	}
+	if _, record.Network, err = net.ParseCIDR(row[0]); err != nil {
+		return record, err
+	}
+
+	var value T
+	if err := any(&value).(CSVUnmarshaler).UnmarshalCSV(row[1 : columns+1]); err != nil {
+		return record, err
+	}
+
+	for i, name := range metaColumns {
+		if len(row[i]) == 0 {
+			continue
+		}
+		switch name {
+		case csvColumnValidFrom:
+			record.ValidFrom, err = parseCSVTime(name, row[i])
+		case csvColumnValidUntil:
+			record.ValidUntil, err = parseCSVTime(name, row[i])
+		case csvColumnComment:
+			record.Comment = row[i]
+		case csvColumnMerge:
+			if record.Merge, err = strconv.ParseBool(row[i]); err != nil {
+				err = fmt.Errorf("%s: %w: %w", name, utils.ErrInvalidArgument, err)
+			}
+		}
+		if err != nil {
+			return record, err
+		}
+	}
+	record.Data = patchRecordData(value, record.Merge)
+	return record, record.PatchRecordMeta.Validate()
+}
+
+func parseCSVTime(name, value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %w", name, utils.ErrInvalidArgument, err)
+	}
+	return &t, nil
+}
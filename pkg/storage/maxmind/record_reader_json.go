@@ -2,70 +2,79 @@ package maxmind
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net"
-	"sort"
-
-	"github.com/bldsoft/geos/pkg/entity"
+	"slices"
 )
 
-type JSONRecordReader struct {
-	records    []MMDBRecord
-	currentIdx int
-}
-
-func NewJSONRecordReader(r io.Reader) (recordReader *JSONRecordReader, err error) {
-	m := make(map[string]entity.City)
-	// m := make(map[string]interface{})
+// NewJSONRecordReader reads the JSON map of the networks to the records of type T with the optional PatchRecordMeta fields
+func NewJSONRecordReader[T MMDBEntity](r io.Reader) (MMDBRecordReader, error) {
+	m := make(map[string]json.RawMessage)
 	dec := json.NewDecoder(r)
 	if err := dec.Decode(&m); err != nil {
 		return nil, err
 	}
 
-	res := JSONRecordReader{}
-
-	records := make([]MMDBRecord, 0, len(m))
-
 	keys := make([]string, 0, len(m))
 	for key := range m {
 		keys = append(keys, key)
 	}
-	sort.Slice(keys, func(i, j int) bool {
-		_, iNetwork, err := net.ParseCIDR(keys[i])
-		if err != nil {
-			return true
-		}
-		_, jNetwork, err := net.ParseCIDR(keys[j])
-		if err != nil {
-			return false
-		}
-		iOnes, _ := iNetwork.Mask.Size()
-		jOnes, _ := jNetwork.Mask.Size()
-		return iOnes < jOnes
-	})
+	slices.Sort(keys)
 
+	records := make([]MMDBRecord, 0, len(m))
 	for _, key := range keys {
-		value := m[key]
-		var record MMDBRecord
-		_, record.Network, err = net.ParseCIDR(key)
+		record, err := jsonRecord[T](key, m[key])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s: %w", key, err)
 		}
-		record.Data = value.ToMMDBType()
-		// record.Data = toMMDBType(value).(mmdbtype.Map)
 		records = append(records, record)
 	}
+	return newSortedRecordReader(records), nil
+}
 
-	res.records = records
-
-	return &res, nil
+// NewNDJSONRecordReader reads the JSON lines of the records of type T with the network field
+// and the optional PatchRecordMeta fields
+func NewNDJSONRecordReader[T MMDBEntity](r io.Reader) (MMDBRecordReader, error) {
+	dec := json.NewDecoder(r)
+	var records []MMDBRecord
+	for line := 1; ; line++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		var network struct {
+			Network string `json:"network"`
+		}
+		if err := json.Unmarshal(raw, &network); err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		record, err := jsonRecord[T](network.Network, raw)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	return newSortedRecordReader(records), nil
 }
 
-func (r *JSONRecordReader) ReadMMDBRecord() (record MMDBRecord, err error) {
-	if r.currentIdx >= len(r.records) {
-		return record, io.EOF
+func jsonRecord[T MMDBEntity](network string, raw json.RawMessage) (record MMDBRecord, err error) {
+	if _, record.Network, err = net.ParseCIDR(network); err != nil {
+		return record, err
+	}
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return record, err
+	}
+	if err := json.Unmarshal(raw, &record.PatchRecordMeta); err != nil {
+		return record, err
+	}
+	if err := record.PatchRecordMeta.Validate(); err != nil {
+		return record, err
 	}
-	rec := r.records[r.currentIdx]
-	r.currentIdx++
-	return rec, nil
+	record.Data = patchRecordData(value, record.Merge)
+	return record, nil
 }
@@ -2,34 +2,73 @@ package source
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 
 	"github.com/mkrou/geonames"
+	"github.com/mkrou/geonames/models"
 	"golang.org/x/sync/errgroup"
 )
 
 const (
-	geonamesBaseURL = "http://download.geonames.org/export/dump"
+	geonamesBaseURL       = "http://download.geonames.org/export/dump"
+	geonamesPostalBaseURL = "http://download.geonames.org/export/zip"
+
+	// GeoNamesAllCountriesPostalCodes is the postal code dump of all the countries
+	GeoNamesAllCountriesPostalCodes = "allCountries"
 )
 
+// GeoNamesCityDumps are the dumps the cities can be loaded from, from the smallest to the largest
+var GeoNamesCityDumps = []models.GeoNameFile{
+	geonames.Cities15000,
+	geonames.Cities5000,
+	geonames.Cities1000,
+	geonames.Cities500,
+	geonames.AllCountries,
+}
+
+// ParseGeoNamesCityDump returns the city dump by its name, with or without the extension, e.g. "cities5000"
+func ParseGeoNamesCityDump(name string) (models.GeoNameFile, error) {
+	for _, dump := range GeoNamesCityDumps {
+		if strings.EqualFold(name, string(dump)) || strings.EqualFold(name+".zip", string(dump)) {
+			return dump, nil
+		}
+	}
+	return "", fmt.Errorf("unknown GeoNames city dump %q", name)
+}
+
+type GeoNamesCityFile struct {
+	Dump models.GeoNameFile
+	*UpdatableFile[ModTimeVersion]
+}
+
 type GeoNamesSource struct {
 	CountriesFile      *UpdatableFile[ModTimeVersion]
 	AdminDivisionsFile *UpdatableFile[ModTimeVersion]
-	Cities500File      *UpdatableFile[ModTimeVersion]
-}
+	CitiesFiles        []GeoNamesCityFile               // cities500 by default, see WithCityDumps
+	FeatureClasses     []string                         // the feature classes of the cities to load, all if empty
+	CityAlternateNames bool                             // keep the alternate names of the cities, see WithoutCityAlternateNames
+	AlternateNamesFile *UpdatableFile[ModTimeVersion]   // optional, see WithAlternateNames
+	Languages          []string                         // the languages of the alternate names to load, all if empty
+	Admin2CodesFile    *UpdatableFile[ModTimeVersion]   // optional, see WithAdmin2Codes
+	PostalCodesFiles   []*UpdatableFile[ModTimeVersion] // optional, see WithPostalCodes
 
-func NewGeoNamesSource(dirPath string) *GeoNamesSource {
-	res := &GeoNamesSource{}
+	dirPath string
+}
 
-	join := func(base, path string) string {
-		url, err := url.JoinPath(base, path)
-		if err != nil {
-			panic(err)
-		}
-		return url
+func join(base, path string) string {
+	url, err := url.JoinPath(base, path)
+	if err != nil {
+		panic(err)
 	}
+	return url
+}
+
+func NewGeoNamesSource(dirPath string) *GeoNamesSource {
+	res := &GeoNamesSource{dirPath: dirPath, CityAlternateNames: true}
 	res.CountriesFile = NewTSUpdatableFile(
 		filepath.Join(dirPath, geonames.Countries.String()),
 		join(geonamesBaseURL, geonames.Countries.String()),
@@ -38,22 +77,91 @@ func NewGeoNamesSource(dirPath string) *GeoNamesSource {
 		filepath.Join(dirPath, geonames.AdminDivisions.String()),
 		join(geonamesBaseURL, geonames.AdminDivisions.String()),
 	)
-	res.Cities500File = NewTSUpdatableFile(
-		filepath.Join(dirPath, string(geonames.Cities500)),
-		join(geonamesBaseURL, string(geonames.Cities500)),
+
+	return res.WithCityDumps([]models.GeoNameFile{geonames.Cities500}, nil)
+}
+
+// WithCityDumps replaces the dumps the cities are loaded from. The cities of several dumps are merged by ID.
+// Only the cities of the feature classes are loaded (all if empty), it's useful for allCountries that contains
+// all the features, not only the populated places.
+func (s *GeoNamesSource) WithCityDumps(dumps []models.GeoNameFile, featureClasses []string) *GeoNamesSource {
+	s.CitiesFiles = nil
+	for _, dump := range dumps {
+		s.CitiesFiles = append(s.CitiesFiles, GeoNamesCityFile{
+			Dump: dump,
+			UpdatableFile: NewTSUpdatableFile(
+				filepath.Join(s.dirPath, string(dump)),
+				join(geonamesBaseURL, string(dump)),
+			),
+		})
+	}
+	s.FeatureClasses = featureClasses
+	return s
+}
+
+// WithoutCityAlternateNames drops the alternatenames column of the city dumps, the cities are found only by
+// the name and the ASCII name then. The column is the most of the city dump.
+func (s *GeoNamesSource) WithoutCityAlternateNames() *GeoNamesSource {
+	s.CityAlternateNames = false
+	return s
+}
+
+// WithAlternateNames adds alternateNamesV2 to the source, it's used to localize the names
+func (s *GeoNamesSource) WithAlternateNames(languages []string) *GeoNamesSource {
+	s.AlternateNamesFile = NewTSUpdatableFile(
+		filepath.Join(s.dirPath, string(geonames.AlternateNames)),
+		join(geonamesBaseURL, string(geonames.AlternateNames)),
+	)
+	s.Languages = languages
+	return s
+}
+
+// WithAdmin2Codes adds admin2Codes.txt to the source: the second-level administrative divisions
+func (s *GeoNamesSource) WithAdmin2Codes() *GeoNamesSource {
+	s.Admin2CodesFile = NewTSUpdatableFile(
+		filepath.Join(s.dirPath, geonames.AdminSubDivisions.String()),
+		join(geonamesBaseURL, geonames.AdminSubDivisions.String()),
 	)
+	return s
+}
 
-	return res
+// WithPostalCodes adds the postal code dumps of the countries (ISO codes) to the source.
+// Use GeoNamesAllCountriesPostalCodes to load all the countries from one dump.
+func (s *GeoNamesSource) WithPostalCodes(countries []string) *GeoNamesSource {
+	for _, country := range countries {
+		if country != GeoNamesAllCountriesPostalCodes {
+			country = strings.ToUpper(country)
+		}
+		// the postal dumps are named like the main ones, so they are kept in a subdirectory
+		s.PostalCodesFiles = append(s.PostalCodesFiles, NewTSUpdatableFile(
+			filepath.Join(s.dirPath, "postal", country+".zip"),
+			join(geonamesPostalBaseURL, country+".zip"),
+		))
+	}
+	return s
+}
+
+func (s *GeoNamesSource) files() []*UpdatableFile[ModTimeVersion] {
+	files := []*UpdatableFile[ModTimeVersion]{
+		s.CountriesFile,
+		s.AdminDivisionsFile,
+	}
+	for _, file := range s.CitiesFiles {
+		files = append(files, file.UpdatableFile)
+	}
+	if s.AlternateNamesFile != nil {
+		files = append(files, s.AlternateNamesFile)
+	}
+	if s.Admin2CodesFile != nil {
+		files = append(files, s.Admin2CodesFile)
+	}
+	return append(files, s.PostalCodesFiles...)
 }
 
 func (s *GeoNamesSource) Update(ctx context.Context, force bool) error {
 	var eg errgroup.Group
 
-	for _, file := range []*UpdatableFile[ModTimeVersion]{
-		s.CountriesFile,
-		s.AdminDivisionsFile,
-		s.Cities500File,
-	} {
+	for _, file := range s.files() {
 		eg.Go(func() error {
 			return file.Update(ctx, force)
 		})
@@ -62,15 +170,26 @@ func (s *GeoNamesSource) Update(ctx context.Context, force bool) error {
 	return eg.Wait()
 }
 
+// Version is the latest modification time of the local files
+func (s *GeoNamesSource) Version(ctx context.Context) (ModTimeVersion, error) {
+	var res ModTimeVersion
+	for _, file := range s.files() {
+		version, err := file.Version(ctx)
+		if err != nil {
+			return ModTimeVersion{}, err
+		}
+		if version.Compare(res) > 0 {
+			res = version
+		}
+	}
+	return res, nil
+}
+
 func (s *GeoNamesSource) CheckUpdates(ctx context.Context) (Update[ModTimeVersion], error) {
 	var eg errgroup.Group
 	var res atomic.Pointer[Update[ModTimeVersion]]
 
-	for _, file := range []*UpdatableFile[ModTimeVersion]{
-		s.CountriesFile,
-		s.AdminDivisionsFile,
-		s.Cities500File,
-	} {
+	for _, file := range s.files() {
 		eg.Go(func() error {
 			update, err := file.CheckUpdates(ctx)
 			if err != nil {
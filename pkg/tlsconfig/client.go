@@ -0,0 +1,41 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// ClientConfig is the TLS config of geos clients. Set CertFile and KeyFile for mTLS.
+type ClientConfig struct {
+	CAFile             string // CA bundle to verify the server certificate, the system pool is used if it's empty
+	CertFile           string
+	KeyFile            string
+	ServerName         string // overrides the server name from the address
+	InsecureSkipVerify bool
+}
+
+func (c ClientConfig) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if len(c.CAFile) != 0 {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if len(c.CertFile) != 0 || len(c.KeyFile) != 0 {
+		if len(c.CertFile) == 0 || len(c.KeyFile) == 0 {
+			return nil, fmt.Errorf("tls: both client certificate and key are required")
+		}
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
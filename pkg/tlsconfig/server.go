@@ -0,0 +1,182 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/bldsoft/gost/log"
+)
+
+// ClientAuth modes
+const (
+	ClientAuthNone             = "none"
+	ClientAuthRequest          = "request"
+	ClientAuthRequireAny       = "require-any"
+	ClientAuthVerifyIfGiven    = "verify-if-given"
+	ClientAuthRequireAndVerify = "require-and-verify"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	ClientAuthNone:             tls.NoClientCert,
+	ClientAuthRequest:          tls.RequestClientCert,
+	ClientAuthRequireAny:       tls.RequireAnyClientCert,
+	ClientAuthVerifyIfGiven:    tls.VerifyClientCertIfGiven,
+	ClientAuthRequireAndVerify: tls.RequireAndVerifyClientCert,
+}
+
+type ServerConfig struct {
+	CertFile     string        `mapstructure:"CERT_FILE" description:"Path to the PEM server certificate (chain). TLS is off if it isn't set"`
+	KeyFile      string        `mapstructure:"KEY_FILE" description:"Path to the PEM private key of the server certificate"`
+	CAFile       string        `mapstructure:"CA_FILE" description:"Path to the PEM CA bundle to verify client certificates"`
+	ClientAuth   string        `mapstructure:"CLIENT_AUTH" description:"Client certificate policy: none, request, require-any, verify-if-given, require-and-verify (mTLS)"`
+	ReloadPeriod time.Duration `mapstructure:"RELOAD_PERIOD" description:"How often the certificate, key and CA files are checked for changes. 0 disables the reload"`
+}
+
+func (c *ServerConfig) SetDefaults() {
+	c.ClientAuth = ClientAuthNone
+	c.ReloadPeriod = 10 * time.Second
+}
+
+func (c *ServerConfig) Enabled() bool {
+	return len(c.CertFile) != 0
+}
+
+func (c *ServerConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if len(c.KeyFile) == 0 {
+		return fmt.Errorf("KEY_FILE is required with CERT_FILE")
+	}
+	clientAuth, ok := clientAuthTypes[c.ClientAuth]
+	if !ok {
+		return fmt.Errorf("CLIENT_AUTH: unknown value %q", c.ClientAuth)
+	}
+	if (clientAuth == tls.VerifyClientCertIfGiven || clientAuth == tls.RequireAndVerifyClientCert) && len(c.CAFile) == 0 {
+		return fmt.Errorf("CLIENT_AUTH %s: CA_FILE is required", c.ClientAuth)
+	}
+	return nil
+}
+
+func (c *ServerConfig) files() []string {
+	return slices.DeleteFunc([]string{c.CertFile, c.KeyFile, c.CAFile}, func(path string) bool { return len(path) == 0 })
+}
+
+// Reloader keeps the server TLS config up to date with the certificate files.
+// The connections that are already established keep the previous certificate.
+type Reloader struct {
+	config   ServerConfig
+	tls      atomic.Pointer[tls.Config]
+	modTimes map[string]time.Time
+}
+
+func NewReloader(config ServerConfig) (*Reloader, error) {
+	r := &Reloader{config: config}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, path := range r.config.files() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   clientAuthTypes[r.config.ClientAuth],
+		// both gRPC and REST servers use HTTP/2
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if len(r.config.CAFile) != 0 {
+		if config.ClientCAs, err = loadCertPool(r.config.CAFile); err != nil {
+			return err
+		}
+	}
+	r.tls.Store(config)
+	r.modTimes = modTimes
+	return nil
+}
+
+// TLSConfig returns the config for the server. The certificates are taken from the last loaded files.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.tls.Load(), nil
+		},
+	}
+}
+
+func (r *Reloader) modified() (bool, error) {
+	for path, modTime := range r.modTimes {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		if !info.ModTime().Equal(modTime) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Run reloads the certificate files when they are modified.
+func (r *Reloader) Run(ctx context.Context) error {
+	if r.config.ReloadPeriod <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(r.config.ReloadPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			modified, err := r.modified()
+			if err != nil {
+				log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err}, "failed to check tls files")
+				continue
+			}
+			if !modified {
+				continue
+			}
+			if err := r.load(); err != nil {
+				// the files may be in the middle of an update, it'll be retried on the next tick
+				log.FromContext(ctx).ErrorWithFields(log.Fields{"err": err, "cert": r.config.CertFile}, "failed to reload tls certificate, the previous one is kept")
+				continue
+			}
+			log.FromContext(ctx).InfoWithFields(log.Fields{"cert": r.config.CertFile}, "tls certificate reloaded")
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%s: no certificates found", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,211 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newTestCert issues the certificate by the parent, it's self-signed if the parent is nil
+func newTestCert(t *testing.T, commonName string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write writes the PEM certificate and key, their modification time is moved forward
+// so the change is noticed whatever the resolution of the file system clock is
+func (c *testCert) write(t *testing.T, certFile, keyFile string, modTime time.Time) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+// serveTLS accepts the connections, writes "ok" after the handshake and closes them
+func serveTLS(t *testing.T, config *tls.Config) string {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if conn.(*tls.Conn).Handshake() == nil {
+					_, _ = conn.Write([]byte("ok"))
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// dial returns the common name of the server certificate and the response
+func dial(addr string, config ClientConfig) (string, string, error) {
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		return "", "", err
+	}
+	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	if err != nil {
+		return "", "", err
+	}
+	defer conn.Close()
+	resp, err := io.ReadAll(conn)
+	if err != nil {
+		return "", "", err
+	}
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, string(resp), nil
+}
+
+func TestReloaderReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.der}), 0o600))
+	config := ServerConfig{
+		CertFile:     filepath.Join(dir, "cert.pem"),
+		KeyFile:      filepath.Join(dir, "key.pem"),
+		ClientAuth:   ClientAuthNone,
+		ReloadPeriod: 10 * time.Millisecond,
+	}
+	now := time.Now()
+	newTestCert(t, "first", ca).write(t, config.CertFile, config.KeyFile, now)
+
+	reloader, err := NewReloader(config)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = reloader.Run(ctx) }()
+	addr := serveTLS(t, reloader.TLSConfig())
+	client := ClientConfig{CAFile: caFile, ServerName: "localhost"}
+
+	commonName, _, err := dial(addr, client)
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName)
+
+	newTestCert(t, "second", ca).write(t, config.CertFile, config.KeyFile, now.Add(time.Minute))
+	assert.Eventually(t, func() bool {
+		commonName, _, err := dial(addr, client)
+		return err == nil && commonName == "second"
+	}, 3*time.Second, 10*time.Millisecond, "the new connections get the new certificate")
+
+	// a broken file is ignored until it's fixed
+	require.NoError(t, os.WriteFile(config.KeyFile, []byte("not a key"), 0o600))
+	require.NoError(t, os.Chtimes(config.KeyFile, now.Add(2*time.Minute), now.Add(2*time.Minute)))
+	time.Sleep(50 * time.Millisecond)
+	commonName, _, err = dial(addr, client)
+	require.NoError(t, err)
+	assert.Equal(t, "second", commonName, "the previous certificate is kept")
+
+	newTestCert(t, "third", ca).write(t, config.CertFile, config.KeyFile, now.Add(3*time.Minute))
+	assert.Eventually(t, func() bool {
+		commonName, _, err := dial(addr, client)
+		return err == nil && commonName == "third"
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestReloaderVerifiesClients(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.der}), 0o600))
+	config := ServerConfig{
+		CertFile:   filepath.Join(dir, "cert.pem"),
+		KeyFile:    filepath.Join(dir, "key.pem"),
+		CAFile:     caFile,
+		ClientAuth: ClientAuthRequireAndVerify,
+	}
+	require.NoError(t, config.Validate())
+	newTestCert(t, "server", ca).write(t, config.CertFile, config.KeyFile, time.Now())
+	reloader, err := NewReloader(config)
+	require.NoError(t, err)
+	addr := serveTLS(t, reloader.TLSConfig())
+
+	clientCert := func(commonName string, parent *testCert) ClientConfig {
+		certFile, keyFile := filepath.Join(dir, commonName+".pem"), filepath.Join(dir, commonName+"-key.pem")
+		newTestCert(t, commonName, parent).write(t, certFile, keyFile, time.Now())
+		return ClientConfig{CAFile: caFile, ServerName: "localhost", CertFile: certFile, KeyFile: keyFile}
+	}
+
+	_, resp, err := dial(addr, clientCert("client", ca))
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, _, err = dial(addr, ClientConfig{CAFile: caFile, ServerName: "localhost"})
+	assert.Error(t, err, "no client certificate")
+
+	_, _, err = dial(addr, clientCert("stranger", newTestCert(t, "other ca", nil)))
+	assert.Error(t, err, "the client certificate of another CA")
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ServerConfig
+		wantErr bool
+	}{
+		{"disabled", ServerConfig{}, false},
+		{"no key", ServerConfig{CertFile: "cert.pem", ClientAuth: ClientAuthNone}, true},
+		{"unknown client auth", ServerConfig{CertFile: "cert.pem", KeyFile: "key.pem", ClientAuth: "always"}, true},
+		{"verification without CA", ServerConfig{CertFile: "cert.pem", KeyFile: "key.pem", ClientAuth: ClientAuthVerifyIfGiven}, true},
+		{"no verification without CA", ServerConfig{CertFile: "cert.pem", KeyFile: "key.pem", ClientAuth: ClientAuthRequireAny}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
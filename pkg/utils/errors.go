@@ -8,3 +8,8 @@ var ErrNotAvailable = errors.New("not available")
 var ErrNotFound = errors.New("not found")
 var ErrUnknownFormat = errors.New("unknown format")
 var ErrUpdateInProgress = errors.New("database update is already in progress")
+var ErrHostnameLookupDisabled = errors.New("hostname lookup is disabled")
+var ErrUnauthorized = errors.New("unauthorized")
+var ErrForbidden = errors.New("forbidden")
+var ErrInvalidArgument = errors.New("invalid argument")
+var ErrAlreadyExists = errors.New("already exists")
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// letters that aren't decomposed into a base letter and a diacritic
+var foldSpecial = map[rune]string{
+	'ß': "ss", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'ħ': "h",
+	'æ': "ae", 'œ': "oe", 'þ': "th", 'ı': "i", 'ŀ': "l",
+}
+
+// FoldName lower-cases the name and strips the diacritics: "São Paulo" -> "sao paulo".
+// The apostrophes and dashes are replaced by spaces and the spaces are collapsed.
+func FoldName(name string) string {
+	return foldName(name, nil)
+}
+
+var germanUmlauts = map[rune]string{'ä': "ae", 'ö': "oe", 'ü': "ue"}
+
+// FoldNameVariants returns FoldName and its transliteration variants: "München" -> "munchen", "muenchen"
+func FoldNameVariants(name string) []string {
+	res := []string{FoldName(name)}
+	if strings.ContainsAny(strings.ToLower(name), "äöü") {
+		res = append(res, foldName(name, germanUmlauts))
+	}
+	return res
+}
+
+func foldName(name string, translit map[rune]string) string {
+	var sb strings.Builder
+	sb.Grow(len(name))
+	space := true // trims the leading spaces
+	writeSpace := func() {
+		if !space {
+			sb.WriteByte(' ')
+			space = true
+		}
+	}
+	for _, r := range strings.ToLower(name) {
+		if s, ok := translit[r]; ok {
+			sb.WriteString(s)
+			space = false
+			continue
+		}
+		for _, r := range norm.NFD.String(string(r)) {
+			switch {
+			case unicode.Is(unicode.Mn, r):
+			case unicode.IsSpace(r) || unicode.IsPunct(r):
+				writeSpace()
+			default:
+				if s, ok := foldSpecial[r]; ok {
+					sb.WriteString(s)
+				} else {
+					sb.WriteRune(r)
+				}
+				space = false
+			}
+		}
+	}
+	return strings.TrimSuffix(sb.String(), " ")
+}
+
+// EditDistance returns the number of insertions, deletions, substitutions and transpositions of adjacent letters
+// to turn a into b (optimal string alignment). If it's greater than max, max+1 is returned.
+func EditDistance(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return max + 1
+	}
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return min(prev[len(rb)], max+1)
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Minsk", "minsk"},
+		{"São Paulo", "sao paulo"},
+		{"Zürich", "zurich"},
+		{"Kraków", "krakow"},
+		{"Łódź", "lodz"},
+		{"Straße", "strasse"},
+		{"Tromsø", "tromso"},
+		{"Đà Nẵng", "da nang"},
+		{"Ærøskøbing", "aeroskobing"},
+		{"İstanbul", "istanbul"},
+		{"Москва", "москва"},
+		{"Йошкар-Ола", "иошкар ола"},
+		{"L'Aquila", "l aquila"},
+		{"  Saint-Jean   d’Acre ", "saint jean d acre"},
+		{"Dziewięć.", "dziewiec"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FoldName(tt.name))
+		})
+	}
+}
+
+func TestFoldNameVariants(t *testing.T) {
+	assert.Equal(t, []string{"munchen", "muenchen"}, FoldNameVariants("München"))
+	assert.Equal(t, []string{"koln", "koeln"}, FoldNameVariants("KÖLN"))
+	assert.Equal(t, []string{"sao paulo"}, FoldNameVariants("São Paulo"))
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"minsk", "minsk", 2, 0},
+		{"minsk", "minks", 2, 1}, // transposition
+		{"minsk", "mnsk", 2, 1},  // deletion
+		{"minsk", "minskk", 2, 1},
+		{"minsk", "pinsk", 2, 1},
+		{"warszawa", "warsaw", 2, 2},
+		{"warszawa", "warsw", 2, 3}, // cut off at max+1
+		{"ab", "abcdef", 2, 3},      // the length difference is enough
+		{"москва", "моксва", 1, 1},  // runes, not bytes
+		{"", "abc", 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, EditDistance(tt.a, tt.b, tt.max))
+			assert.Equal(t, tt.want, EditDistance(tt.b, tt.a, tt.max), "symmetric")
+		})
+	}
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"archive/tar"
 	"io"
+	"slices"
 
 	"github.com/klauspost/compress/gzip"
 )
@@ -40,3 +41,33 @@ func UnpackTarGz(src io.Reader) (map[string][]byte, error) {
 	}
 	return files, nil
 }
+
+// PackTarGz writes the files to the archive in the order of the names, UnpackTarGz reads them back
+func PackTarGz(w io.Writer, files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		header := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}